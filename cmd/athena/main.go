@@ -7,39 +7,73 @@ import (
 	migratecmd "github.com/forbole/juno/v5/cmd/migrate"
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
 	startcmd "github.com/forbole/juno/v5/cmd/start"
+	"github.com/forbole/juno/v5/logging"
+	junotypes "github.com/forbole/juno/v5/types"
 	"github.com/forbole/juno/v5/types/params"
 
+	databasecmd "github.com/desmos-labs/athena/v2/cmd/database"
 	parsecmd "github.com/desmos-labs/athena/v2/cmd/parse"
+	snapshotcmd "github.com/desmos-labs/athena/v2/cmd/snapshot"
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
+	verifycmd "github.com/desmos-labs/athena/v2/cmd/verify"
 	desmosdb "github.com/desmos-labs/athena/v2/database"
 	"github.com/desmos-labs/athena/v2/x"
+	"github.com/desmos-labs/athena/v2/x/gaps"
 )
 
 func main() {
 	// Setup the config
+	modulesRegistrar := x.NewModulesRegistrar()
 	parseCfg := parsecmdtypes.NewConfig().
-		WithRegistrar(x.NewModulesRegistrar()).
+		WithRegistrar(modulesRegistrar).
 		WithEncodingConfigBuilder(func() params.EncodingConfig {
 			config := desmosapp.MakeEncodingConfig()
 			return params.EncodingConfig(config)
 		}).
-		WithDBBuilder(desmosdb.Builder)
+		WithDBBuilder(desmosdb.Builder).
+		WithLogger(gaps.NewLogger(logging.DefaultLogger()))
 
 	cfg := junocmd.NewConfig("athena").
 		WithParseConfig(parseCfg)
 
+	// Refuse to start parsing if the database schema is behind
+	startCmd := startcmd.NewStartCmd(cfg.GetParseConfig())
+	startCmd.PreRunE = junotypes.ConcatCobraCmdFuncs(startCmd.PreRunE, cmdutils.CheckSchemaPreRunE(cfg.GetParseConfig()))
+
 	// Run the command
 	rootCmd := junocmd.RootCmd(cfg.GetName())
 
 	rootCmd.AddCommand(
 		junocmd.VersionCmd(),
 		initcmd.NewInitCmd(cfg.GetInitConfig()),
-		startcmd.NewStartCmd(cfg.GetParseConfig()),
+		startCmd,
 		parsecmd.NewParseCmd(cfg.GetParseConfig()),
+		databasecmd.NewDatabaseCmd(cfg.GetParseConfig()),
+		verifycmd.NewVerifyCmd(cfg.GetParseConfig()),
+		snapshotcmd.NewSnapshotCmd(cfg.GetParseConfig()),
 		migratecmd.NewMigrateCmd(cfg.GetName(), cfg.GetParseConfig()),
 	)
 
 	executor := junocmd.PrepareRootCmd(cfg.GetName(), rootCmd)
-	err := executor.Execute()
+
+	// Run the command, shutting down the parser if any of the long-running services fails
+	executeErr := make(chan error, 1)
+	go func() {
+		executeErr <- executor.Execute()
+	}()
+
+	var err error
+	select {
+	case err = <-executeErr:
+	case <-modulesRegistrar.Failed():
+		err = modulesRegistrar.Shutdown()
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	// Wait for the long-running services to be stopped gracefully
+	err = modulesRegistrar.Stop()
 	if err != nil {
 		panic(err)
 	}
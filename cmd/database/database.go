@@ -0,0 +1,25 @@
+package database
+
+import (
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/spf13/cobra"
+
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
+)
+
+// NewDatabaseCmd returns the Cobra command allowing to manage the database
+func NewDatabaseCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "database",
+		Short:             "Manage the database schema",
+		PersistentPreRunE: cmdutils.RunPersistentPreRuns(parsecmdtypes.ReadConfigPreRunE(parseCfg)),
+	}
+
+	cmd.AddCommand(
+		migrateCmd(parseCfg),
+		statusCmd(parseCfg),
+		rollbackCmd(parseCfg),
+	)
+
+	return cmd
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"fmt"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/spf13/cobra"
+
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
+	"github.com/desmos-labs/athena/v2/database"
+)
+
+const (
+	flagTo       = "to"
+	flagBaseline = "baseline"
+)
+
+// migrateCmd returns the Cobra command allowing to migrate the database schema
+func migrateCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the pending schema migrations, or revert the applied ones",
+		Long: `Apply all the pending schema migrations, each one inside its own transaction.
+
+If the --to flag is set, only the migrations up to the given version are applied. If the given version is lower than 
+the latest applied migration, all the applied migrations having a greater version are reverted instead.
+
+If the --baseline flag is set, the migrations up to the given version are marked as applied without being run. 
+This should be used once on databases whose schema has been created manually.`,
+		Example: `athena database migrate
+athena database migrate --to 14-profile-counters
+athena database migrate --baseline 14-profile-counters`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			toVersion, err := cmd.Flags().GetString(flagTo)
+			if err != nil {
+				return err
+			}
+
+			baselineVersion, err := cmd.Flags().GetString(flagBaseline)
+			if err != nil {
+				return err
+			}
+
+			db, err := cmdutils.GetDatabase(parseCfg)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			if baselineVersion != "" {
+				marked, err := db.Baseline(baselineVersion)
+				printVersions(cmd, "marked as applied", marked)
+				return err
+			}
+
+			if toVersion != "" {
+				reverted, err := revertIfNeeded(db, toVersion)
+				printVersions(cmd, "reverted", reverted)
+				if err != nil || len(reverted) > 0 {
+					return err
+				}
+			}
+
+			applied, err := db.MigrateUp(toVersion)
+			printVersions(cmd, "applied", applied)
+			return err
+		},
+	}
+
+	cmd.Flags().String(flagTo, "", "Version to migrate to (defaults to the latest one)")
+	cmd.Flags().String(flagBaseline, "", "Mark the migrations up to this version as applied, without running them")
+
+	return cmd
+}
+
+// revertIfNeeded reverts the applied migrations having a version greater than the given one
+func revertIfNeeded(db *database.Db, toVersion string) ([]string, error) {
+	migrations, err := database.Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, migration := range migrations {
+		if migration.Version == toVersion {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown migration version: %s", toVersion)
+	}
+
+	return db.MigrateDown(toVersion)
+}
+
+// printVersions prints the given migration versions along with the given action
+func printVersions(cmd *cobra.Command, action string, versions []string) {
+	if len(versions) == 0 {
+		cmd.Printf("no migrations %s\n", action)
+		return
+	}
+
+	for _, version := range versions {
+		cmd.Printf("migration %s %s\n", version, action)
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/spf13/cobra"
+
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
+)
+
+const (
+	flagToHeight = "to-height"
+)
+
+// rollbackCmd returns the Cobra command allowing to roll back the database to a given height
+func rollbackCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Remove all the data stored above the given height",
+		Long: `Remove all the data stored above the given height, restoring the rows that have been updated or deleted 
+above it using the change journal. The blocks above the given height are removed as well, so that they can be parsed 
+again once the chain has been reorganised.
+
+This should be run while Athena is stopped.`,
+		Example: `athena database rollback --to-height 1000`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := cmd.Flags().GetInt64(flagToHeight)
+			if err != nil {
+				return err
+			}
+
+			db, err := cmdutils.GetDatabase(parseCfg)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			err = db.RollbackToHeight(height)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("database rolled back to height %d\n", height)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagToHeight, 0, "Height to which the database should be rolled back")
+	_ = cmd.MarkFlagRequired(flagToHeight)
+
+	return cmd
+}
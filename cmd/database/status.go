@@ -0,0 +1,49 @@
+package database
+
+import (
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/spf13/cobra"
+
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
+	"github.com/desmos-labs/athena/v2/database"
+)
+
+// statusCmd returns the Cobra command allowing to print the status of the schema migrations
+func statusCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Print the status of all the schema migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := cmdutils.GetDatabase(parseCfg)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			migrations, err := database.Migrations()
+			if err != nil {
+				return err
+			}
+
+			applied, err := db.GetAppliedMigrations()
+			if err != nil {
+				return err
+			}
+
+			appliedAt := map[string]string{}
+			for _, migration := range applied {
+				appliedAt[migration.Version] = migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+
+			for _, migration := range migrations {
+				status, found := appliedAt[migration.Version]
+				if !found {
+					status = "pending"
+				}
+				cmd.Printf("%-30s %s\n", migration.Version, status)
+			}
+
+			return nil
+		},
+	}
+}
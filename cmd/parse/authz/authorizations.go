@@ -6,8 +6,8 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/database"
-	"github.com/desmos-labs/athena/v2/x/authz"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
 )
 
 // authorizationsCmd returns a Cobra command that allows to refresh all the authorizations
@@ -16,26 +16,32 @@ func authorizationsCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 		Use:   "authorizations",
 		Short: "Fetch all the authorizations from the node and save them properly",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
-
-			// Build the module
-			authzModule := authz.NewModule(parseCtx.Node, parseCtx.EncodingConfig.Codec, db)
+			authzModule, err := modules.Authz()
+			if err != nil {
+				return err
+			}
 
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
 			if err != nil {
 				return err
 			}
 
 			// Refresh the authorizations
 			log.Info().Int64("height", height).Msg("refreshing authz authorizations")
-			return authzModule.RefreshAuthorizations(height)
+			return runner.Run([]refresh.Unit{refresh.NewUnit("authz", 0, 0, func() error {
+				return authzModule.RefreshAuthorizations(height)
+			})})
 		},
 	}
 }
@@ -0,0 +1,182 @@
+package container
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/node/remote"
+	"github.com/forbole/juno/v5/types/config"
+
+	"github.com/desmos-labs/athena/v2/database"
+	"github.com/desmos-labs/athena/v2/x/authz"
+	"github.com/desmos-labs/athena/v2/x/contracts"
+	"github.com/desmos-labs/athena/v2/x/failures"
+	"github.com/desmos-labs/athena/v2/x/feegrant"
+	"github.com/desmos-labs/athena/v2/x/gaps"
+	"github.com/desmos-labs/athena/v2/x/posts"
+	"github.com/desmos-labs/athena/v2/x/profiles"
+	profilesscore "github.com/desmos-labs/athena/v2/x/profiles-score"
+	"github.com/desmos-labs/athena/v2/x/reactions"
+	"github.com/desmos-labs/athena/v2/x/relationships"
+	"github.com/desmos-labs/athena/v2/x/reports"
+	"github.com/desmos-labs/athena/v2/x/subspaces"
+)
+
+// Container contains the modules built by the registrar of the parse config, allowing the parse commands to
+// get the ones they need by name. Since the modules are built by the registrar, they are configured the same
+// way they are when parsing the chain (e.g. with the custom registrar options and the tombstones mode).
+type Container struct {
+	cdc     codec.Codec
+	node    node.Node
+	db      database.Database
+	modules modules.Modules
+}
+
+// NewContainer builds a new Container instance using the given configs.
+// It returns an error if the given config refers to a local node, since Athena only supports remote ones.
+func NewContainer(cfg config.Config, parseCfg *parsecmdtypes.Config) (*Container, error) {
+	if _, ok := cfg.Node.Details.(*remote.Details); !ok {
+		return nil, fmt.Errorf("cannot run Athena on a local node: please set a remote node inside the config file")
+	}
+
+	parseCtx, err := parsecmdtypes.GetParserContext(cfg, parseCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Container{
+		cdc:     parseCtx.EncodingConfig.Codec,
+		node:    parseCtx.Node,
+		db:      database.Cast(parseCtx.Database),
+		modules: parseCtx.Modules,
+	}, nil
+}
+
+// Codec returns the codec used to decode the chain data
+func (c *Container) Codec() codec.Codec {
+	return c.cdc
+}
+
+// Node returns the node used to query the chain
+func (c *Container) Node() node.Node {
+	return c.node
+}
+
+// Database returns the Athena database
+func (c *Container) Database() database.Database {
+	return c.db
+}
+
+// LatestHeight returns the latest height of the chain
+func (c *Container) LatestHeight() (int64, error) {
+	return c.node.LatestHeight()
+}
+
+// LastParsedHeight returns the highest height stored inside the database
+func (c *Container) LastParsedHeight() (int64, error) {
+	return c.db.GetLastBlockHeight()
+}
+
+// IsEnabled tells whether the module having the given name is enabled inside the config
+func (c *Container) IsEnabled(name string) bool {
+	_, err := c.Module(name)
+	return err == nil
+}
+
+// Module returns the module having the given name, or an error if such module is not enabled inside the config
+func (c *Container) Module(name string) (modules.Module, error) {
+	module, found := c.modules.FindByName(name)
+	if !found {
+		return nil, fmt.Errorf("module %s is not enabled: please add it to the chain modules inside the config file", name)
+	}
+	return module, nil
+}
+
+// getModule returns the module having the given name, making sure it has the expected type and is not nil
+func getModule[T interface {
+	comparable
+	modules.Module
+}](c *Container, name string) (T, error) {
+	var empty T
+
+	module, err := c.Module(name)
+	if err != nil {
+		return empty, err
+	}
+
+	typed, ok := module.(T)
+	if !ok {
+		return empty, fmt.Errorf("invalid %s module type: %T", name, module)
+	}
+
+	// Some modules are built as nil when their configuration is missing
+	if typed == empty {
+		return empty, fmt.Errorf("module %s is not configured", name)
+	}
+
+	return typed, nil
+}
+
+// Authz returns the x/authz module
+func (c *Container) Authz() (*authz.Module, error) {
+	return getModule[*authz.Module](c, "authz")
+}
+
+// Contracts returns the contracts module
+func (c *Container) Contracts() (*contracts.Module, error) {
+	return getModule[*contracts.Module](c, "contracts")
+}
+
+// Failures returns the failures module
+func (c *Container) Failures() (*failures.Module, error) {
+	return getModule[*failures.Module](c, "failures")
+}
+
+// Feegrant returns the x/feegrant module
+func (c *Container) Feegrant() (*feegrant.Module, error) {
+	return getModule[*feegrant.Module](c, "feegrant")
+}
+
+// Gaps returns the gaps module
+func (c *Container) Gaps() (*gaps.Module, error) {
+	return getModule[*gaps.Module](c, "gaps")
+}
+
+// Posts returns the x/posts module
+func (c *Container) Posts() (*posts.Module, error) {
+	return getModule[*posts.Module](c, "posts")
+}
+
+// Profiles returns the x/profiles module
+func (c *Container) Profiles() (*profiles.Module, error) {
+	return getModule[*profiles.Module](c, "profiles")
+}
+
+// ProfilesScore returns the profiles score module
+func (c *Container) ProfilesScore() (*profilesscore.Module, error) {
+	return getModule[*profilesscore.Module](c, "profiles:score")
+}
+
+// Reactions returns the x/reactions module
+func (c *Container) Reactions() (*reactions.Module, error) {
+	return getModule[*reactions.Module](c, "reactions")
+}
+
+// Relationships returns the x/relationships module
+func (c *Container) Relationships() (*relationships.Module, error) {
+	return getModule[*relationships.Module](c, "relationships")
+}
+
+// Reports returns the x/reports module
+func (c *Container) Reports() (*reports.Module, error) {
+	return getModule[*reports.Module](c, "reports")
+}
+
+// Subspaces returns the x/subspaces module
+func (c *Container) Subspaces() (*subspaces.Module, error) {
+	return getModule[*subspaces.Module](c, "subspaces")
+}
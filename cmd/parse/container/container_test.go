@@ -0,0 +1,26 @@
+package container_test
+
+import (
+	"testing"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	nodeconfig "github.com/forbole/juno/v5/node/config"
+	"github.com/forbole/juno/v5/node/local"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+)
+
+func TestNewContainer_LocalNode(t *testing.T) {
+	cfg := config.Config{
+		Node: nodeconfig.Config{
+			Type:    nodeconfig.TypeLocal,
+			Details: local.NewDetails("/tmp/desmos"),
+		},
+	}
+
+	_, err := container.NewContainer(cfg, parsecmdtypes.NewConfig())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "local node")
+}
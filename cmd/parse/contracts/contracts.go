@@ -1,20 +1,13 @@
 package contracts
 
 import (
-	"fmt"
-
-	"github.com/desmos-labs/athena/v2/x/subspaces"
-
-	contractsbuilder "github.com/desmos-labs/athena/v2/x/contracts/builder"
-
-	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
-	"github.com/forbole/juno/v5/node/remote"
 	"github.com/forbole/juno/v5/types/config"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/database"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
 )
 
 // contractsCmd returns a Cobra command that allows to refresh the smart contracts data for a single subspace
@@ -24,25 +17,18 @@ func contractsCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 		Args:  cobra.RangeArgs(0, 1),
 		Short: "Refresh all the smart contracts data",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			remoteCfg, ok := config.Cfg.Node.Details.(*remote.Details)
-			if !ok {
-				panic(fmt.Errorf("cannot run Athena on local node"))
+			contractsModule, err := modules.Contracts()
+			if err != nil {
+				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
-
-			grpcConnection := remote.MustCreateGrpcConnection(remoteCfg.GRPC)
-			subspacesModule := subspaces.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			contractsModule := contractsbuilder.BuildModule(config.Cfg, parseCtx.Node, grpcConnection, db)
-
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
 			if err != nil {
 				return err
 			}
@@ -50,33 +36,19 @@ func contractsCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 			// Refresh the smart contracts data
 			log.Info().Int64("height", height).Msg("refreshing contracts")
 
-			var subspaceIDs []uint64
-			if len(args) > 0 {
-				subspaceID, err := subspacestypes.ParseSubspaceID(args[0])
-				if err != nil {
-					return err
-				}
-				subspaceIDs = []uint64{subspaceID}
-			} else {
-				subs, err := subspacesModule.QueryAllSubspaces(height)
-				if err != nil {
-					return err
-				}
-
-				subspaceIDs = make([]uint64, len(subs))
-				for i, subspace := range subs {
-					subspaceIDs[i] = subspace.ID
-				}
+			subspaceIDs, err := refresh.GetSubspacesIDs(args, modules, height)
+			if err != nil {
+				return err
 			}
 
-			for _, subspaceID := range subspaceIDs {
-				err = contractsModule.RefreshData(height, subspaceID)
-				if err != nil {
-					return err
-				}
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
+			if err != nil {
+				return err
 			}
 
-			return nil
+			return runner.Run(refresh.SubspacesUnits("contracts", subspaceIDs, func(subspaceID uint64) error {
+				return contractsModule.RefreshData(height, subspaceID)
+			}))
 		},
 	}
 }
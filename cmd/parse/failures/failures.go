@@ -0,0 +1,74 @@
+package failures
+
+import (
+	"encoding/json"
+	"fmt"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+)
+
+const (
+	flagDryRun = "dry-run"
+)
+
+// NewFailedMessagesCmd returns the Cobra command allowing to handle again the messages whose handling failed
+func NewFailedMessagesCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "failed-messages",
+		Short: "Handle again the messages whose handling failed",
+		Long: `Handle again all the messages stored inside the failed_message table, regardless of how many times they have 
+already been retried. Messages that are handled successfully are removed, while the ones that are still failing are 
+printed as JSON. When the --dry-run flag is provided, the failed messages are only printed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dryRun, err := cmd.Flags().GetBool(flagDryRun)
+			if err != nil {
+				return err
+			}
+
+			modules, err := container.NewContainer(config.Cfg, parseCfg)
+			if err != nil {
+				return err
+			}
+
+			failuresModule, err := modules.Failures()
+			if err != nil {
+				return err
+			}
+
+			messages, err := modules.Database().GetFailedMessages()
+			if err != nil {
+				return err
+			}
+
+			if !dryRun && len(messages) > 0 {
+				log.Info().Int("failed messages", len(messages)).Msg("retrying failed messages")
+				err = failuresModule.Retry(messages)
+				if err != nil {
+					return err
+				}
+
+				messages, err = modules.Database().GetFailedMessages()
+				if err != nil {
+					return err
+				}
+			}
+
+			bz, err := json.MarshalIndent(messages, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		},
+	}
+
+	cmd.Flags().Bool(flagDryRun, false, "Only print the failed messages without handling them again")
+
+	return cmd
+}
@@ -6,9 +6,8 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/x/feegrant"
-
-	"github.com/desmos-labs/athena/v2/database"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
 )
 
 // allowancesCmd returns a Cobra command that allows to refresh all the allowances
@@ -17,26 +16,32 @@ func allowancesCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 		Use:   "allowances",
 		Short: "Fetch all the authorizations from the node and save them properly",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
-
-			// Build the module
-			feegrantModule := feegrant.NewModule(parseCtx.Node, parseCtx.EncodingConfig.Codec, db)
+			feegrantModule, err := modules.Feegrant()
+			if err != nil {
+				return err
+			}
 
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
 			if err != nil {
 				return err
 			}
 
 			// Refresh the authorizations
 			log.Info().Int64("height", height).Msg("refreshing fee grant allowances")
-			return feegrantModule.RefreshFeeGrants(height)
+			return runner.Run([]refresh.Unit{refresh.NewUnit("feegrant", 0, 0, func() error {
+				return feegrantModule.RefreshFeeGrants(height)
+			})})
 		},
 	}
 }
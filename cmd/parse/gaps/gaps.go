@@ -0,0 +1,105 @@
+package gaps
+
+import (
+	"encoding/json"
+	"fmt"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+)
+
+const (
+	flagFrom   = "from"
+	flagTo     = "to"
+	flagDryRun = "dry-run"
+)
+
+// NewGapsCmd returns the Cobra command allowing to find and parse again the heights that have not been parsed properly
+func NewGapsCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gaps",
+		Short: "Find the missing heights and the ones whose handlers returned an error, and parse them again",
+		Long: `Find the heights between --from and --to (both included) whose block is missing from the database and store
+them inside the failed_height table, along with the heights on which the modules handlers returned an error.
+All the failed heights are then parsed again: missing blocks using all the enabled modules, the other heights using
+only the module that failed on them. Finally, the heights that are still failing are printed as JSON.
+
+If --from is not provided, the start height of the parser is used. If --to is not provided, the latest stored height
+is used. When the --dry-run flag is provided, the failed heights are only stored and printed.`,
+		Example: `athena parse gaps --from 1000 --to 2000`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := cmd.Flags().GetInt64(flagFrom)
+			if err != nil {
+				return err
+			}
+
+			to, err := cmd.Flags().GetInt64(flagTo)
+			if err != nil {
+				return err
+			}
+
+			dryRun, err := cmd.Flags().GetBool(flagDryRun)
+			if err != nil {
+				return err
+			}
+
+			modules, err := container.NewContainer(config.Cfg, parseCfg)
+			if err != nil {
+				return err
+			}
+
+			gapsModule, err := modules.Gaps()
+			if err != nil {
+				return err
+			}
+
+			if from == 0 {
+				from = gapsModule.StartHeight()
+			}
+
+			if to == 0 {
+				to, err = modules.Database().GetLastBlockHeight()
+				if err != nil {
+					return err
+				}
+			}
+
+			log.Info().Int64("from", from).Int64("to", to).Msg("finding gaps")
+			failedHeights, err := gapsModule.FindGaps(from, to)
+			if err != nil {
+				return err
+			}
+
+			if !dryRun && len(failedHeights) > 0 {
+				log.Info().Int("failed heights", len(failedHeights)).Msg("backfilling failed heights")
+				err = gapsModule.Backfill(failedHeights)
+				if err != nil {
+					return err
+				}
+
+				failedHeights, err = modules.Database().GetFailedHeights()
+				if err != nil {
+					return err
+				}
+			}
+
+			bz, err := json.MarshalIndent(failedHeights, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		},
+	}
+
+	cmd.Flags().Int64(flagFrom, 0, "Height from which to start looking for missing blocks (defaults to the start height)")
+	cmd.Flags().Int64(flagTo, 0, "Height at which to stop looking for missing blocks (defaults to the latest stored height)")
+	cmd.Flags().Bool(flagDryRun, false, "Only store and print the failed heights without parsing them again")
+
+	return cmd
+}
@@ -0,0 +1,121 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+)
+
+const (
+	flagModules = "modules"
+	flagFrom    = "from"
+	flagTo      = "to"
+
+	// notificationsModuleName is the name of the notifications module, which is never replayed
+	notificationsModuleName = "notifications"
+)
+
+// NewMessagesCmd returns the Cobra command allowing to replay the messages of a height range for some modules only
+func NewMessagesCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "messages",
+		Short: "Replay the messages of a height range dispatching them only to the given modules",
+		Long: `Fetch the blocks having a height between --from and --to (both included) from the node and replay the
+messages of their transactions, including the ones inside a MsgExec, dispatching them only to the given modules.
+If --to is not provided, the messages are replayed up to the latest height.
+
+The notifications module cannot be replayed, so that no notification is sent during the replay.`,
+		Example: `athena parse messages --modules reactions,posts --from 1000 --to 2000`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := cmd.Flags().GetStringSlice(flagModules)
+			if err != nil {
+				return err
+			}
+
+			from, err := cmd.Flags().GetInt64(flagFrom)
+			if err != nil {
+				return err
+			}
+
+			to, err := cmd.Flags().GetInt64(flagTo)
+			if err != nil {
+				return err
+			}
+
+			if len(names) == 0 {
+				return fmt.Errorf("at least one module must be provided using the --%s flag", flagModules)
+			}
+
+			if from <= 0 {
+				return fmt.Errorf("invalid --%s height: %d", flagFrom, from)
+			}
+
+			modulesContainer, err := container.NewContainer(config.Cfg, parseCfg)
+			if err != nil {
+				return err
+			}
+
+			replayedModules, err := getMessageModules(modulesContainer, names)
+			if err != nil {
+				return err
+			}
+
+			if to == 0 {
+				to, err = modulesContainer.LatestHeight()
+				if err != nil {
+					return err
+				}
+			}
+
+			if to < from {
+				return fmt.Errorf("invalid height range: --%s %d is lower than --%s %d", flagTo, to, flagFrom, from)
+			}
+
+			log.Info().Strs("modules", names).Int64("from", from).Int64("to", to).Msg("replaying messages")
+			err = NewReplayer(modulesContainer.Node(), modulesContainer.Codec(), replayedModules).Replay(from, to)
+			if err != nil {
+				return err
+			}
+
+			log.Info().Strs("modules", names).Int64("from", from).Int64("to", to).Msg("messages replayed")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice(flagModules, nil, "Comma-separated list of the modules the messages should be dispatched to")
+	cmd.Flags().Int64(flagFrom, 0, "Height from which to start replaying the messages")
+	cmd.Flags().Int64(flagTo, 0, "Height at which to stop replaying the messages (defaults to the latest height)")
+
+	return cmd
+}
+
+// getMessageModules returns the modules having the given names, making sure they all handle messages
+func getMessageModules(modulesContainer *container.Container, names []string) ([]modules.Module, error) {
+	replayedModules := make([]modules.Module, len(names))
+	for i, name := range names {
+		if strings.EqualFold(name, notificationsModuleName) {
+			return nil, fmt.Errorf("the %s module cannot be replayed", notificationsModuleName)
+		}
+
+		module, err := modulesContainer.Module(name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, isMessageModule := module.(modules.MessageModule)
+		_, isAuthzMessageModule := module.(modules.AuthzMessageModule)
+		if !isMessageModule && !isAuthzMessageModule {
+			return nil, fmt.Errorf("module %s does not handle messages", name)
+		}
+
+		replayedModules[i] = module
+	}
+	return replayedModules, nil
+}
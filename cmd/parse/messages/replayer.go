@@ -0,0 +1,127 @@
+package messages
+
+import (
+	"fmt"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/forbole/juno/v5/modules"
+	juno "github.com/forbole/juno/v5/types"
+	"github.com/rs/zerolog/log"
+)
+
+// Node represents the node used to fetch the blocks and transactions that should be replayed
+type Node interface {
+	Block(height int64) (*tmctypes.ResultBlock, error)
+	Txs(block *tmctypes.ResultBlock) ([]*juno.Tx, error)
+}
+
+// Replayer allows to replay the messages contained inside a range of blocks, dispatching them only to a set of modules
+type Replayer struct {
+	node    Node
+	cdc     codec.Codec
+	modules []modules.Module
+}
+
+// NewReplayer returns a new Replayer instance that dispatches the messages to the given modules
+func NewReplayer(node Node, cdc codec.Codec, modules []modules.Module) *Replayer {
+	return &Replayer{
+		node:    node,
+		cdc:     cdc,
+		modules: modules,
+	}
+}
+
+// Replay replays all the messages contained inside the blocks having a height between from and to (both included).
+// It stops at the first error, returning it along with the height at which it happened.
+func (r *Replayer) Replay(from int64, to int64) error {
+	for height := from; height <= to; height++ {
+		err := r.replayHeight(height)
+		if err != nil {
+			return fmt.Errorf("error while replaying height %d: %s", height, err)
+		}
+	}
+	return nil
+}
+
+// replayHeight replays all the messages contained inside the block at the given height
+func (r *Replayer) replayHeight(height int64) error {
+	log.Debug().Int64("height", height).Msg("replaying messages")
+
+	block, err := r.node.Block(height)
+	if err != nil {
+		return fmt.Errorf("failed to get block from node: %s", err)
+	}
+
+	txs, err := r.node.Txs(block)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions for block: %s", err)
+	}
+
+	for _, tx := range txs {
+		err = r.replayTx(tx)
+		if err != nil {
+			return fmt.Errorf("error while replaying tx %s: %s", tx.TxHash, err)
+		}
+	}
+
+	return nil
+}
+
+// replayTx dispatches all the messages contained inside the given transaction
+func (r *Replayer) replayTx(tx *juno.Tx) error {
+	for index, msgAny := range tx.Body.Messages {
+		var msg sdk.Msg
+		err := r.cdc.UnpackAny(msgAny, &msg)
+		if err != nil {
+			return fmt.Errorf("error while unpacking message %d: %s", index, err)
+		}
+
+		err = r.handleMessage(index, msg, tx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleMessage dispatches the given message to the modules, making sure the messages included inside a
+// MsgExec are dispatched as well
+func (r *Replayer) handleMessage(index int, msg sdk.Msg, tx *juno.Tx) error {
+	for _, module := range r.modules {
+		if messageModule, ok := module.(modules.MessageModule); ok {
+			err := messageModule.HandleMsg(index, msg, tx)
+			if err != nil {
+				return fmt.Errorf("error while handling %s message %d with module %s: %s",
+					sdk.MsgTypeURL(msg), index, module.Name(), err)
+			}
+		}
+	}
+
+	msgExec, ok := msg.(*authz.MsgExec)
+	if !ok {
+		return nil
+	}
+
+	for authzIndex, msgAny := range msgExec.Msgs {
+		var executedMsg sdk.Msg
+		err := r.cdc.UnpackAny(msgAny, &executedMsg)
+		if err != nil {
+			return fmt.Errorf("error while unpacking MsgExec inner message %d: %s", authzIndex, err)
+		}
+
+		for _, module := range r.modules {
+			if messageModule, ok := module.(modules.AuthzMessageModule); ok {
+				err = messageModule.HandleMsgExec(index, msgExec, authzIndex, executedMsg, tx)
+				if err != nil {
+					return fmt.Errorf("error while handling %s MsgExec inner message %d with module %s: %s",
+						sdk.MsgTypeURL(executedMsg), authzIndex, module.Name(), err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package messages_test
+
+import (
+	"testing"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/desmos-labs/desmos/v7/app"
+	"github.com/forbole/juno/v5/modules"
+	juno "github.com/forbole/juno/v5/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/messages"
+)
+
+// mockNode returns the transactions associated to each height
+type mockNode struct {
+	txs map[int64][]*juno.Tx
+}
+
+func (n *mockNode) Block(height int64) (*tmctypes.ResultBlock, error) {
+	return &tmctypes.ResultBlock{Block: &tmtypes.Block{Header: tmtypes.Header{Height: height}}}, nil
+}
+
+func (n *mockNode) Txs(block *tmctypes.ResultBlock) ([]*juno.Tx, error) {
+	return n.txs[block.Block.Height], nil
+}
+
+// mockModule records the messages it handles
+type mockModule struct {
+	name     string
+	handled  []string
+	executed []string
+}
+
+func (m *mockModule) Name() string {
+	return m.name
+}
+
+func (m *mockModule) HandleMsg(_ int, msg sdk.Msg, _ *juno.Tx) error {
+	m.handled = append(m.handled, sdk.MsgTypeURL(msg))
+	return nil
+}
+
+func (m *mockModule) HandleMsgExec(_ int, _ *authz.MsgExec, _ int, executedMsg sdk.Msg, _ *juno.Tx) error {
+	m.executed = append(m.executed, sdk.MsgTypeURL(executedMsg))
+	return nil
+}
+
+func buildTx(t *testing.T, hash string, msgs ...sdk.Msg) *juno.Tx {
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		msgAny, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		anys[i] = msgAny
+	}
+	return &juno.Tx{
+		Tx:         &tx.Tx{Body: &tx.TxBody{Messages: anys}},
+		TxResponse: &sdk.TxResponse{TxHash: hash},
+	}
+}
+
+func TestReplayer_Replay(t *testing.T) {
+	cdc := app.MakeEncodingConfig().Codec
+
+	msgSend := banktypes.NewMsgSend(
+		sdk.AccAddress("sender"),
+		sdk.AccAddress("recipient"),
+		sdk.NewCoins(sdk.NewInt64Coin("udsm", 100)),
+	)
+	msgExec := authz.NewMsgExec(sdk.AccAddress("grantee"), []sdk.Msg{msgSend})
+
+	node := &mockNode{txs: map[int64][]*juno.Tx{
+		1: {buildTx(t, "A", msgSend)},
+		2: {buildTx(t, "B", &msgExec)},
+		3: {buildTx(t, "C", msgSend, msgSend)},
+	}}
+
+	module := &mockModule{name: "bank"}
+	err := messages.NewReplayer(node, cdc, []modules.Module{module}).Replay(2, 3)
+	require.NoError(t, err)
+
+	// The message of height 1 should not be replayed, while the one inside the MsgExec should be unwrapped
+	msgSendURL := sdk.MsgTypeURL(msgSend)
+	require.Equal(t, []string{sdk.MsgTypeURL(&msgExec), msgSendURL, msgSendURL}, module.handled)
+	require.Equal(t, []string{msgSendURL}, module.executed)
+}
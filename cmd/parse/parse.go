@@ -2,6 +2,7 @@ package parse
 
 import (
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	junotypes "github.com/forbole/juno/v5/types"
 	"github.com/spf13/cobra"
 
 	parseblocks "github.com/forbole/juno/v5/cmd/parse/blocks"
@@ -9,26 +10,41 @@ import (
 
 	parseauthz "github.com/desmos-labs/athena/v2/cmd/parse/authz"
 	parsecontracts "github.com/desmos-labs/athena/v2/cmd/parse/contracts"
+	parsefailures "github.com/desmos-labs/athena/v2/cmd/parse/failures"
 	parsefeegrant "github.com/desmos-labs/athena/v2/cmd/parse/feegrant"
+	parsegaps "github.com/desmos-labs/athena/v2/cmd/parse/gaps"
+	parsemessages "github.com/desmos-labs/athena/v2/cmd/parse/messages"
 	parseposts "github.com/desmos-labs/athena/v2/cmd/parse/posts"
 	parseprofiles "github.com/desmos-labs/athena/v2/cmd/parse/profiles"
 	parsereactions "github.com/desmos-labs/athena/v2/cmd/parse/reactions"
+	parserefresh "github.com/desmos-labs/athena/v2/cmd/parse/refresh"
 	parserelationships "github.com/desmos-labs/athena/v2/cmd/parse/relationships"
 	parsereports "github.com/desmos-labs/athena/v2/cmd/parse/reports"
 	parsesubspaces "github.com/desmos-labs/athena/v2/cmd/parse/subspaces"
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
 )
 
 // NewParseCmd returns the Cobra command allowing to parse some data without having to re-sync the whole database
 func NewParseCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:               "parse",
-		Short:             "Apply some fixes without the need to re-syncing the whole database from scratch",
-		PersistentPreRunE: runPersistentPreRuns(parsecmdtypes.ReadConfigPreRunE(parseCfg)),
+		Use:   "parse",
+		Short: "Apply some fixes without the need to re-syncing the whole database from scratch",
+		PersistentPreRunE: cmdutils.RunPersistentPreRuns(junotypes.ConcatCobraCmdFuncs(
+			parsecmdtypes.ReadConfigPreRunE(parseCfg),
+			cmdutils.CheckSchemaPreRunE(parseCfg),
+		)),
 	}
 
 	cmd.AddCommand(
 		parsegenesis.NewGenesisCmd(parseCfg),
 		parseblocks.NewBlocksCmd(parseCfg),
+		parsemessages.NewMessagesCmd(parseCfg),
+		parsegaps.NewGapsCmd(parseCfg),
+		parsefailures.NewFailedMessagesCmd(parseCfg),
+	)
+
+	// The Athena commands refresh the data using the shared refresh runner
+	refreshCmds := []*cobra.Command{
 		parseauthz.NewAuthzCmd(parseCfg),
 		parsecontracts.NewContractsCmd(parseCfg),
 		parsefeegrant.NewFeeGrant(parseCfg),
@@ -38,22 +54,11 @@ func NewParseCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
 		parseposts.NewPostsCmd(parseCfg),
 		parsereactions.NewReactionsCmd(parseCfg),
 		parsereports.NewReportsCmd(parseCfg),
-	)
+	}
+	for _, refreshCmd := range refreshCmds {
+		parserefresh.AddFlags(refreshCmd)
+		cmd.AddCommand(refreshCmd)
+	}
 
 	return cmd
 }
-
-func runPersistentPreRuns(preRun func(_ *cobra.Command, _ []string) error) func(_ *cobra.Command, _ []string) error {
-	return func(cmd *cobra.Command, args []string) error {
-		if root := cmd.Root(); root != nil {
-			if root.PersistentPreRunE != nil {
-				err := root.PersistentPreRunE(root, args)
-				if err != nil {
-					return err
-				}
-			}
-		}
-
-		return preRun(cmd, args)
-	}
-}
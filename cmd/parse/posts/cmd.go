@@ -14,6 +14,8 @@ func NewPostsCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
 
 	cmd.AddCommand(
 		postsCmd(parseCfg),
+		postCmd(parseCfg),
+		countersCmd(parseCfg),
 	)
 
 	return cmd
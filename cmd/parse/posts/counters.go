@@ -0,0 +1,47 @@
+package posts
+
+import (
+	"fmt"
+
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+	"github.com/rs/zerolog/log"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/database"
+)
+
+// countersCmd returns a Cobra command that allows to rebuild the posts counters
+func countersCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "counters [[subspace-id]]",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "Rebuild the posts counters from the stored data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			if err != nil {
+				return err
+			}
+
+			// Get the database
+			db, ok := parseCtx.Database.(*database.Db)
+			if !ok {
+				return fmt.Errorf("database is not a Athena database instance")
+			}
+
+			var subspaceID *uint64
+			if len(args) > 0 {
+				id, err := subspacestypes.ParseSubspaceID(args[0])
+				if err != nil {
+					return err
+				}
+				subspaceID = &id
+			}
+
+			log.Info().Msg("rebuilding posts counters")
+			return db.RebuildPostsCounters(subspaceID)
+		},
+	}
+}
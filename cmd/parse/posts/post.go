@@ -0,0 +1,72 @@
+package posts
+
+import (
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+)
+
+// postCmd returns a Cobra command that allows to refresh a single post and the data depending on it
+func postCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "post [subspace-id] [post-id]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Refresh a single post along with its transactions, attachments, poll answers and tips",
+		Long: `Refresh a single post along with its transactions, attachments and poll answers. 
+If the contracts module is enabled, the tips sent to the post are refreshed as well.
+If the post does not exist on chain anymore, it is removed from the database.`,
+		Example: `athena parse posts post 1 10`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subspaceID, err := subspacestypes.ParseSubspaceID(args[0])
+			if err != nil {
+				return err
+			}
+
+			postID, err := poststypes.ParsePostID(args[1])
+			if err != nil {
+				return err
+			}
+
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
+			if err != nil {
+				return err
+			}
+
+			postsModule, err := modules.Posts()
+			if err != nil {
+				return err
+			}
+
+			// Get the latest height
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Uint64("post id", postID).
+				Msg("refreshing post")
+			err = postsModule.RefreshPost(height, subspaceID, postID)
+			if err != nil {
+				return err
+			}
+
+			if !modules.IsEnabled("contracts") {
+				return nil
+			}
+
+			contractsModule, err := modules.Contracts()
+			if err != nil {
+				return err
+			}
+
+			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Uint64("post id", postID).
+				Msg("refreshing post tips")
+			return contractsModule.RefreshPostData(height, subspaceID, postID)
+		},
+	}
+}
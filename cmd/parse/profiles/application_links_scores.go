@@ -6,8 +6,8 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/database"
-	profilesscorebuilder "github.com/desmos-labs/athena/v2/x/profiles-score/builder"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
 )
 
 // applicationLinksScoresCmd returns a Cobra command that allows to fix the application links scores for all the profiles
@@ -16,24 +16,32 @@ func applicationLinksScoresCmd(parseConfig *parsecmdtypes.Config) *cobra.Command
 		Use:   "application-links",
 		Short: "Fetch the application links stored on chain and save them",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
+			profilesScoreModule, err := modules.ProfilesScore()
+			if err != nil {
+				return err
+			}
 
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
 			if err != nil {
 				return err
 			}
 
 			// Refresh the application link scores
-			profilesScoreModule := profilesscorebuilder.BuildModule(config.Cfg, db)
 			log.Info().Int64("height", height).Msg("refreshing applications links scores")
-			return profilesScoreModule.RefreshApplicationLinksScores()
+			return runner.Run([]refresh.Unit{refresh.NewUnit("application_links_scores", 0, 0, func() error {
+				return profilesScoreModule.RefreshApplicationLinksScores()
+			})})
 		},
 	}
 }
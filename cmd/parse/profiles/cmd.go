@@ -14,8 +14,10 @@ func NewProfilesCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
 
 	cmd.AddCommand(
 		profilesCmd(parseCfg),
+		profileCmd(parseCfg),
 		applicationLinksCmd(parseCfg),
 		applicationLinksScoresCmd(parseCfg),
+		scoresCmd(parseCfg),
 		chainLinksCmd(parseCfg),
 	)
 
@@ -0,0 +1,42 @@
+package profiles
+
+import (
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+)
+
+// profileCmd returns a Cobra command that allows to refresh a single profile and the data depending on it
+func profileCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "profile [address]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Refresh a single profile along with its chain links and application links",
+		Long: `Refresh the profile of the given user along with their chain links, default chain links and application links.
+If the profile does not exist on chain anymore, it is removed from the database.`,
+		Example: `athena parse profiles profile desmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
+			if err != nil {
+				return err
+			}
+
+			profilesModule, err := modules.Profiles()
+			if err != nil {
+				return err
+			}
+
+			// Get the latest height
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			log.Info().Int64("height", height).Str("address", args[0]).Msg("refreshing profile")
+			return profilesModule.RefreshProfile(height, args[0])
+		},
+	}
+}
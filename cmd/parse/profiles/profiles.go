@@ -1,16 +1,13 @@
 package profiles
 
 import (
-	"fmt"
-
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
-	"github.com/forbole/juno/v5/node/remote"
 	"github.com/forbole/juno/v5/types/config"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/database"
-	"github.com/desmos-labs/athena/v2/x/profiles"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
 )
 
 // profilesCmd returns a Cobra command that allows to fix the profiles
@@ -19,31 +16,32 @@ func profilesCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 		Use:   "profiles",
 		Short: "Fetch the profiles stored on chain and save them",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			remoteCfg, ok := config.Cfg.Node.Details.(*remote.Details)
-			if !ok {
-				panic(fmt.Errorf("cannot run Athena on local node"))
+			profilesModule, err := modules.Profiles()
+			if err != nil {
+				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
-
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
 			if err != nil {
 				return err
 			}
 
-			grpcConnection := remote.MustCreateGrpcConnection(remoteCfg.GRPC)
-			profilesModule := profiles.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
+			if err != nil {
+				return err
+			}
 
-			// Refresh the chain links
+			// Refresh the profiles
 			log.Info().Int64("height", height).Msg("refreshing profiles, this might take a while")
-			return profilesModule.RefreshProfiles(height)
+			return runner.Run([]refresh.Unit{refresh.NewUnit("profiles", 0, 0, func() error {
+				return profilesModule.RefreshProfiles(height)
+			})})
 		},
 	}
 }
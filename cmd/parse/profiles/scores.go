@@ -0,0 +1,47 @@
+package profiles
+
+import (
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
+)
+
+// scoresCmd returns a Cobra command that allows to compute the aggregated score of all the profiles
+func scoresCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "scores",
+		Short: "Compute the aggregated score of all the profiles using the stored application links scores",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
+			if err != nil {
+				return err
+			}
+
+			profilesScoreModule, err := modules.ProfilesScore()
+			if err != nil {
+				return err
+			}
+
+			// Get the latest height
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
+			if err != nil {
+				return err
+			}
+
+			// Refresh the profiles scores
+			log.Info().Int64("height", height).Msg("refreshing profiles scores")
+			return runner.Run([]refresh.Unit{refresh.NewUnit("profiles_scores", 0, 0, func() error {
+				return profilesScoreModule.RefreshProfilesScores()
+			})})
+		},
+	}
+}
@@ -15,6 +15,7 @@ func NewReactionsCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
 	cmd.AddCommand(
 		registeredReactionsCmd(parseCfg),
 		reactionsCmd(parseCfg),
+		postReactionsCmd(parseCfg),
 		paramsCmd(parseCfg),
 	)
 
@@ -0,0 +1,53 @@
+package reactions
+
+import (
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+)
+
+// postReactionsCmd returns a Cobra command that allows to refresh the reactions of a single post
+func postReactionsCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "post [subspace-id] [post-id]",
+		Args:    cobra.ExactArgs(2),
+		Short:   "Refresh the reactions of a single post",
+		Example: `athena parse reactions post 1 10`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subspaceID, err := subspacestypes.ParseSubspaceID(args[0])
+			if err != nil {
+				return err
+			}
+
+			postID, err := poststypes.ParsePostID(args[1])
+			if err != nil {
+				return err
+			}
+
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
+			if err != nil {
+				return err
+			}
+
+			reactionsModule, err := modules.Reactions()
+			if err != nil {
+				return err
+			}
+
+			// Get the latest height
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Uint64("post id", postID).
+				Msg("refreshing post reactions")
+			return reactionsModule.RefreshReactionsData(height, subspaceID, postID)
+		},
+	}
+}
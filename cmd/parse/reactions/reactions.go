@@ -1,21 +1,14 @@
 package reactions
 
 import (
-	"fmt"
-
-	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
-
-	"github.com/rs/zerolog/log"
-
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
-	"github.com/forbole/juno/v5/node/remote"
 	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/database"
-	"github.com/desmos-labs/athena/v2/x/posts"
-	"github.com/desmos-labs/athena/v2/x/reactions"
-	"github.com/desmos-labs/athena/v2/x/subspaces"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
+	"github.com/desmos-labs/athena/v2/types"
 )
 
 // reactionsCmd returns a Cobra command that allows to refresh all the reactions
@@ -25,26 +18,23 @@ func reactionsCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 		Args:  cobra.RangeArgs(0, 1),
 		Short: "Refresh all the reactions data",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			remoteCfg, ok := config.Cfg.Node.Details.(*remote.Details)
-			if !ok {
-				panic(fmt.Errorf("cannot run Athena on local node"))
+			postsModule, err := modules.Posts()
+			if err != nil {
+				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
-
-			grpcConnection := remote.MustCreateGrpcConnection(remoteCfg.GRPC)
-			subspacesModule := subspaces.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			postsModule := posts.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			reactionsModule := reactions.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
+			reactionsModule, err := modules.Reactions()
+			if err != nil {
+				return err
+			}
 
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
 			if err != nil {
 				return err
 			}
@@ -52,25 +42,17 @@ func reactionsCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 			// Get the subspaces
 			log.Info().Int64("height", height).Msg("refreshing reactions")
 
-			var subspaceIDs []uint64
-			if len(args) > 0 {
-				subspaceID, err := subspacestypes.ParseSubspaceID(args[0])
-				if err != nil {
-					return err
-				}
-				subspaceIDs = []uint64{subspaceID}
-			} else {
-				subs, err := subspacesModule.QueryAllSubspaces(height)
-				if err != nil {
-					return err
-				}
+			subspaceIDs, err := refresh.GetSubspacesIDs(args, modules, height)
+			if err != nil {
+				return err
+			}
 
-				subspaceIDs = make([]uint64, len(subs))
-				for i, subspace := range subs {
-					subspaceIDs[i] = subspace.ID
-				}
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
+			if err != nil {
+				return err
 			}
 
+			var units []refresh.Unit
 			for _, subspaceID := range subspaceIDs {
 				// Get the posts
 				posts, err := postsModule.QuerySubspacePosts(height, subspaceID)
@@ -78,16 +60,12 @@ func reactionsCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 					return err
 				}
 
-				for _, post := range posts {
-					// Refresh the reactions
-					err = reactionsModule.RefreshReactionsData(height, post.SubspaceID, post.ID)
-					if err != nil {
-						return err
-					}
-				}
+				units = append(units, refresh.PostsUnits("reactions", posts, func(post types.Post) error {
+					return reactionsModule.RefreshReactionsData(height, post.SubspaceID, post.ID)
+				})...)
 			}
 
-			return nil
+			return runner.Run(units)
 		},
 	}
 }
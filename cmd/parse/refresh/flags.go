@@ -0,0 +1,87 @@
+package refresh
+
+import (
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+const (
+	FlagWorkers = "workers"
+	FlagResume  = "resume"
+)
+
+// AddFlags adds the flags used to configure the refresh runner to the given command and all its children
+func AddFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Int(FlagWorkers, 1, "Number of units that are refreshed concurrently")
+	cmd.PersistentFlags().Bool(FlagResume, false, "Skip the units completed by a previous interrupted run")
+}
+
+// NewRunnerFromFlags returns a new Runner instance configured using the flags of the given command
+func NewRunnerFromFlags(cmd *cobra.Command, db Database, height int64) (*Runner, error) {
+	workers, err := cmd.Flags().GetInt(FlagWorkers)
+	if err != nil {
+		return nil, err
+	}
+
+	resume, err := cmd.Flags().GetBool(FlagResume)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRunner(db, height).WithWorkers(workers).WithResume(resume), nil
+}
+
+// GetSubspacesIDs returns the id of the subspace given as the first argument, if any, or the ids of all the
+// subspaces existing at the given height otherwise
+func GetSubspacesIDs(args []string, modules *container.Container, height int64) ([]uint64, error) {
+	if len(args) > 0 {
+		subspaceID, err := subspacestypes.ParseSubspaceID(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return []uint64{subspaceID}, nil
+	}
+
+	subspacesModule, err := modules.Subspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	subs, err := subspacesModule.QueryAllSubspaces(height)
+	if err != nil {
+		return nil, err
+	}
+
+	subspaceIDs := make([]uint64, len(subs))
+	for i, subspace := range subs {
+		subspaceIDs[i] = subspace.ID
+	}
+	return subspaceIDs, nil
+}
+
+// SubspacesUnits returns the units refreshing the given module for each one of the given subspaces
+func SubspacesUnits(module string, subspaceIDs []uint64, refresh func(subspaceID uint64) error) []Unit {
+	units := make([]Unit, len(subspaceIDs))
+	for i, subspaceID := range subspaceIDs {
+		subspaceID := subspaceID
+		units[i] = NewUnit(module, subspaceID, 0, func() error {
+			return refresh(subspaceID)
+		})
+	}
+	return units
+}
+
+// PostsUnits returns the units refreshing the given module for each one of the given posts
+func PostsUnits(module string, posts []types.Post, refresh func(post types.Post) error) []Unit {
+	units := make([]Unit, len(posts))
+	for i, post := range posts {
+		post := post
+		units[i] = NewUnit(module, post.SubspaceID, post.ID, func() error {
+			return refresh(post)
+		})
+	}
+	return units
+}
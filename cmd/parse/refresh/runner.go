@@ -0,0 +1,230 @@
+package refresh
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// Database represents the database used to store the refresh checkpoints
+type Database interface {
+	SaveRefreshCheckpoint(checkpoint types.RefreshCheckpoint) error
+	GetRefreshCheckpoints(modules []string) ([]types.RefreshCheckpoint, error)
+	DeleteRefreshCheckpoints(modules []string) error
+}
+
+// Unit represents a single unit of work of a refresh, identified by its module, subspace and post
+type Unit struct {
+	Module     string
+	SubspaceID uint64
+	PostID     uint64
+	Refresh    func() error
+}
+
+func NewUnit(module string, subspaceID uint64, postID uint64, refresh func() error) Unit {
+	return Unit{
+		Module:     module,
+		SubspaceID: subspaceID,
+		PostID:     postID,
+		Refresh:    refresh,
+	}
+}
+
+// key returns the key used to identify the checkpoint of the unit
+func (u Unit) key() string {
+	return types.EntityKey(u.Module, u.SubspaceID, u.PostID)
+}
+
+// Runner allows to run the units of a refresh concurrently, recording the completed ones so that an
+// interrupted refresh can be resumed
+type Runner struct {
+	db      Database
+	height  int64
+	workers int
+	resume  bool
+
+	// progressInterval is the minimum interval between two progress logs
+	progressInterval time.Duration
+}
+
+// NewRunner returns a new Runner instance that refreshes the data at the given height using a single worker
+func NewRunner(db Database, height int64) *Runner {
+	return &Runner{
+		db:               db,
+		height:           height,
+		workers:          1,
+		progressInterval: 10 * time.Second,
+	}
+}
+
+// WithWorkers sets the number of units that are refreshed concurrently
+func (r *Runner) WithWorkers(workers int) *Runner {
+	if workers > 0 {
+		r.workers = workers
+	}
+	return r
+}
+
+// WithResume sets whether the units completed by a previous run should be skipped
+func (r *Runner) WithResume(resume bool) *Runner {
+	r.resume = resume
+	return r
+}
+
+// Run refreshes the given units, stopping at the first error. When not resuming, the checkpoints of the modules
+// of the given units are removed before starting.
+func (r *Runner) Run(units []Unit) error {
+	modules := unitsModules(units)
+	units, err := r.pendingUnits(modules, units)
+	if err != nil {
+		return err
+	}
+
+	if len(units) == 0 {
+		return nil
+	}
+
+	progress := newProgress(modules, len(units), r.progressInterval)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var runErr error
+	stop := make(chan struct{})
+	jobs := make(chan Unit)
+
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for unit := range jobs {
+				err := r.runUnit(unit)
+				if err != nil {
+					once.Do(func() {
+						runErr = err
+						close(stop)
+					})
+					return
+				}
+				progress.completed()
+			}
+		}()
+	}
+
+dispatch:
+	for _, unit := range units {
+		select {
+		case jobs <- unit:
+		case <-stop:
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return runErr
+}
+
+// unitsModules returns the modules of the given units, without duplicates
+func unitsModules(units []Unit) []string {
+	var modules []string
+	seen := map[string]bool{}
+	for _, unit := range units {
+		if !seen[unit.Module] {
+			seen[unit.Module] = true
+			modules = append(modules, unit.Module)
+		}
+	}
+	return modules
+}
+
+// pendingUnits returns the units among the given ones that need to be refreshed
+func (r *Runner) pendingUnits(modules []string, units []Unit) ([]Unit, error) {
+	if !r.resume {
+		err := r.db.DeleteRefreshCheckpoints(modules)
+		if err != nil {
+			return nil, fmt.Errorf("error while deleting refresh checkpoints: %s", err)
+		}
+		return units, nil
+	}
+
+	checkpoints, err := r.db.GetRefreshCheckpoints(modules)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting refresh checkpoints: %s", err)
+	}
+
+	completed := map[string]bool{}
+	for _, checkpoint := range checkpoints {
+		completed[types.EntityKey(checkpoint.Module, checkpoint.SubspaceID, checkpoint.PostID)] = true
+	}
+
+	var pending []Unit
+	for _, unit := range units {
+		if !completed[unit.key()] {
+			pending = append(pending, unit)
+		}
+	}
+
+	if skipped := len(units) - len(pending); skipped > 0 {
+		log.Info().Strs("modules", modules).Int("skipped", skipped).Msg("resuming refresh")
+	}
+
+	return pending, nil
+}
+
+// runUnit refreshes the given unit, storing its checkpoint once completed
+func (r *Runner) runUnit(unit Unit) error {
+	err := unit.Refresh()
+	if err != nil {
+		return fmt.Errorf("error while refreshing %s (subspace %d, post %d): %s",
+			unit.Module, unit.SubspaceID, unit.PostID, err)
+	}
+
+	return r.db.SaveRefreshCheckpoint(types.NewRefreshCheckpoint(unit.Module, unit.SubspaceID, unit.PostID, r.height))
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// progress keeps track of the completed units of a run, logging the progress along with the estimated remaining time
+type progress struct {
+	mu sync.Mutex
+
+	modules  []string
+	total    int
+	done     int
+	start    time.Time
+	lastLog  time.Time
+	interval time.Duration
+}
+
+func newProgress(modules []string, total int, interval time.Duration) *progress {
+	now := time.Now()
+	return &progress{
+		modules:  modules,
+		total:    total,
+		start:    now,
+		lastLog:  now,
+		interval: interval,
+	}
+}
+
+// completed marks a unit as completed, logging the progress if enough time has passed since the last log
+func (p *progress) completed() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	if p.done < p.total && time.Since(p.lastLog) < p.interval {
+		return
+	}
+	p.lastLog = time.Now()
+
+	elapsed := time.Since(p.start)
+	eta := time.Duration(float64(elapsed) / float64(p.done) * float64(p.total-p.done))
+	log.Info().Strs("modules", p.modules).Int("completed", p.done).Int("total", p.total).
+		Str("elapsed", elapsed.Round(time.Second).String()).Str("eta", eta.Round(time.Second).String()).
+		Msg("refresh progress")
+}
@@ -0,0 +1,111 @@
+package refresh_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// mockDatabase stores the refresh checkpoints in memory
+type mockDatabase struct {
+	mu          sync.Mutex
+	checkpoints map[string]types.RefreshCheckpoint
+}
+
+func newMockDatabase() *mockDatabase {
+	return &mockDatabase{checkpoints: map[string]types.RefreshCheckpoint{}}
+}
+
+func (db *mockDatabase) SaveRefreshCheckpoint(checkpoint types.RefreshCheckpoint) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.checkpoints[types.EntityKey(checkpoint.Module, checkpoint.SubspaceID, checkpoint.PostID)] = checkpoint
+	return nil
+}
+
+func (db *mockDatabase) GetRefreshCheckpoints(modules []string) ([]types.RefreshCheckpoint, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var checkpoints []types.RefreshCheckpoint
+	for _, checkpoint := range db.checkpoints {
+		for _, module := range modules {
+			if checkpoint.Module == module {
+				checkpoints = append(checkpoints, checkpoint)
+			}
+		}
+	}
+	return checkpoints, nil
+}
+
+func (db *mockDatabase) DeleteRefreshCheckpoints(modules []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key, checkpoint := range db.checkpoints {
+		for _, module := range modules {
+			if checkpoint.Module == module {
+				delete(db.checkpoints, key)
+			}
+		}
+	}
+	return nil
+}
+
+// buildUnits returns the units refreshing the posts with the given ids, recording the refreshed ones
+func buildUnits(refreshed *sync.Map, failingPostID uint64, postIDs ...uint64) []refresh.Unit {
+	units := make([]refresh.Unit, len(postIDs))
+	for i, postID := range postIDs {
+		postID := postID
+		units[i] = refresh.NewUnit("reactions", 1, postID, func() error {
+			if postID == failingPostID {
+				return fmt.Errorf("grpc error")
+			}
+			refreshed.Store(postID, true)
+			return nil
+		})
+	}
+	return units
+}
+
+func countRefreshed(refreshed *sync.Map) int {
+	count := 0
+	refreshed.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func TestRunner_Run(t *testing.T) {
+	db := newMockDatabase()
+
+	// Run a refresh that fails on a single unit
+	refreshed := &sync.Map{}
+	err := refresh.NewRunner(db, 10).WithWorkers(4).Run(buildUnits(refreshed, 3, 1, 2, 3, 4, 5, 6))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "grpc error")
+
+	_, found := db.checkpoints[types.EntityKey("reactions", 1, 3)]
+	require.False(t, found)
+	require.Len(t, db.checkpoints, countRefreshed(refreshed))
+
+	// Resume the refresh, making sure only the units that were not completed are refreshed
+	completed := len(db.checkpoints)
+	resumed := &sync.Map{}
+	err = refresh.NewRunner(db, 10).WithWorkers(4).WithResume(true).Run(buildUnits(resumed, 0, 1, 2, 3, 4, 5, 6))
+	require.NoError(t, err)
+	require.Equal(t, 6-completed, countRefreshed(resumed))
+	require.Len(t, db.checkpoints, 6)
+
+	// Run the refresh again without resuming, making sure all the units are refreshed
+	restarted := &sync.Map{}
+	err = refresh.NewRunner(db, 10).Run(buildUnits(restarted, 0, 1, 2, 3, 4, 5, 6))
+	require.NoError(t, err)
+	require.Equal(t, 6, countRefreshed(restarted))
+}
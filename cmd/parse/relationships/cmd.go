@@ -15,6 +15,7 @@ func NewRelationshipsCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
 	cmd.AddCommand(
 		relationshipsCmd(parseCfg),
 		userBlocksCmd(parseCfg),
+		userCmd(parseCfg),
 	)
 
 	return cmd
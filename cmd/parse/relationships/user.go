@@ -0,0 +1,63 @@
+package relationships
+
+import (
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
+)
+
+// userCmd returns a Cobra command that allows to refresh the relationships and user blocks created by a single user
+func userCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "user [address] [[subspace-id]]",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "Refresh the relationships and user blocks created by the given user",
+		Long: `Refresh the relationships and user blocks created by the given user inside the given subspace, or inside all 
+the subspaces if none is given. 
+
+The relationships and user blocks in which the user is the counterparty or the blocked user are not refreshed, 
+since the chain does not allow to query them without knowing their creator: run this command for their creator 
+instead, or refresh the whole subspace using athena parse relationships relationships and user-blocks.`,
+		Example: `athena parse relationships user desmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s 1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user := args[0]
+
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
+			if err != nil {
+				return err
+			}
+
+			relationshipsModule, err := modules.Relationships()
+			if err != nil {
+				return err
+			}
+
+			// Get the latest height
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			// Get the subspaces
+			subspaceIDs, err := refresh.GetSubspacesIDs(args[1:], modules, height)
+			if err != nil {
+				return err
+			}
+
+			for _, subspaceID := range subspaceIDs {
+				log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Str("user", user).
+					Msg("refreshing user relationships and blocks")
+				err = relationshipsModule.RefreshUserData(height, subspaceID, user)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -1,27 +1,19 @@
 package subspaces
 
 import (
-	"fmt"
-
-	contractsbuilder "github.com/desmos-labs/athena/v2/x/contracts/builder"
-
 	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
-	"github.com/forbole/juno/v5/node/remote"
 	"github.com/forbole/juno/v5/types/config"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/database"
-	"github.com/desmos-labs/athena/v2/x/posts"
-	"github.com/desmos-labs/athena/v2/x/profiles"
-	"github.com/desmos-labs/athena/v2/x/reactions"
-	"github.com/desmos-labs/athena/v2/x/relationships"
-	"github.com/desmos-labs/athena/v2/x/reports"
-	"github.com/desmos-labs/athena/v2/x/subspaces"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
+	"github.com/desmos-labs/athena/v2/types"
 )
 
-// subspaceCmd returns a Cobra command that allows to refresh a single subspace and all the data within it
+// subspaceCmd returns a Cobra command that allows to refresh a single subspace and all the data within it.
+// The data of the modules that are not enabled inside the config is not refreshed.
 func subspaceCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 	return &cobra.Command{
 		Use:   "subspace [subspace-id]",
@@ -33,108 +25,172 @@ func subspaceCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 				return err
 			}
 
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			remoteCfg, ok := config.Cfg.Node.Details.(*remote.Details)
-			if !ok {
-				panic(fmt.Errorf("cannot run Athena on local node"))
+			subspacesModule, err := modules.Subspaces()
+			if err != nil {
+				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
-
-			grpcConnection := remote.MustCreateGrpcConnection(remoteCfg.GRPC)
-			profilesModule := profiles.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			subspacesModule := subspaces.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			postsModule := posts.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			reactionsModule := reactions.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			relationshipsModule := relationships.NewModule(profilesModule, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			reportsModule := reports.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-			contractsModule := contractsbuilder.BuildModule(config.Cfg, parseCtx.Node, grpcConnection, db)
-
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
 			if err != nil {
 				return err
 			}
 
-			// Refresh x/subspace data
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing subspace")
-			err = subspacesModule.RefreshSubspaceData(height, subspaceID)
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
 			if err != nil {
 				return err
 			}
 
-			// Refresh x/relationships data
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing relationships")
-			err = relationshipsModule.RefreshRelationshipsData(height, subspaceID)
+			// Refresh x/subspace data
+			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing subspace")
+			err = runner.Run([]refresh.Unit{
+				refresh.NewUnit("subspaces", subspaceID, 0, func() error {
+					return subspacesModule.RefreshSubspaceData(height, subspaceID)
+				}),
+			})
 			if err != nil {
 				return err
 			}
 
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing user blocks")
-			err = relationshipsModule.RefreshUserBlocksData(height, subspaceID)
+			// Refresh the data that depends only on the subspace
+			units, err := subspaceUnits(modules, height, subspaceID)
 			if err != nil {
 				return err
 			}
 
-			// Refresh x/posts data
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing posts")
-			err = postsModule.RefreshPostsData(height, subspaceID)
+			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).
+				Msg("refreshing relationships, user blocks, posts, reactions params, registered reactions and reports reasons")
+			err = runner.Run(units)
 			if err != nil {
-				return nil
+				return err
 			}
 
-			// Refresh x/reactions data
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing reactions params")
-			err = reactionsModule.RefreshParamsData(height, subspaceID)
+			// Refresh the data that depends on the posts
+			units, err = postsUnits(modules, height, subspaceID)
 			if err != nil {
 				return err
 			}
 
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing registered reactions")
-			err = reactionsModule.RefreshRegisteredReactionsData(height, subspaceID)
-			if err != nil {
-				return nil
-			}
+			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).
+				Msg("refreshing reactions, reports and contracts")
+			return runner.Run(units)
+		},
+	}
+}
 
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing reactions")
-			posts, err := postsModule.QuerySubspacePosts(height, subspaceID)
-			if err != nil {
-				return err
-			}
+// subspaceUnits returns the units refreshing the data of the enabled modules that depends only on the given subspace
+func subspaceUnits(modules *container.Container, height int64, subspaceID uint64) ([]refresh.Unit, error) {
+	var units []refresh.Unit
+
+	if modules.IsEnabled("relationships") {
+		relationshipsModule, err := modules.Relationships()
+		if err != nil {
+			return nil, err
+		}
+
+		units = append(units,
+			refresh.NewUnit("relationships", subspaceID, 0, func() error {
+				return relationshipsModule.RefreshRelationshipsData(height, subspaceID)
+			}),
+			refresh.NewUnit("user_blocks", subspaceID, 0, func() error {
+				return relationshipsModule.RefreshUserBlocksData(height, subspaceID)
+			}),
+		)
+	}
 
-			for _, post := range posts {
-				err = reactionsModule.RefreshReactionsData(height, post.SubspaceID, post.ID)
-				if err != nil {
-					return err
-				}
-			}
+	if modules.IsEnabled("posts") {
+		postsModule, err := modules.Posts()
+		if err != nil {
+			return nil, err
+		}
 
-			// Refresh x/reports data
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing reports reasons")
-			err = reportsModule.RefreshReasonsData(height, subspaceID)
-			if err != nil {
-				return err
-			}
+		units = append(units, refresh.NewUnit("posts", subspaceID, 0, func() error {
+			return postsModule.RefreshPostsData(height, subspaceID)
+		}))
+	}
 
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing reports")
-			err = reportsModule.RefreshReportsData(height, subspaceID)
-			if err != nil {
-				return err
-			}
+	if modules.IsEnabled("reactions") {
+		reactionsModule, err := modules.Reactions()
+		if err != nil {
+			return nil, err
+		}
+
+		units = append(units,
+			refresh.NewUnit("reactions_params", subspaceID, 0, func() error {
+				return reactionsModule.RefreshParamsData(height, subspaceID)
+			}),
+			refresh.NewUnit("registered_reactions", subspaceID, 0, func() error {
+				return reactionsModule.RefreshRegisteredReactionsData(height, subspaceID)
+			}),
+		)
+	}
 
-			// Refresh smart contracts details
-			log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Msg("refreshing contracts")
-			err = contractsModule.RefreshData(height, subspaceID)
-			if err != nil {
-				return err
-			}
+	if modules.IsEnabled("reports") {
+		reportsModule, err := modules.Reports()
+		if err != nil {
+			return nil, err
+		}
 
-			return nil
-		},
+		units = append(units, refresh.NewUnit("reports_reasons", subspaceID, 0, func() error {
+			return reportsModule.RefreshReasonsData(height, subspaceID)
+		}))
 	}
+
+	return units, nil
+}
+
+// postsUnits returns the units refreshing the data of the enabled modules that depends on the posts of the
+// given subspace
+func postsUnits(modules *container.Container, height int64, subspaceID uint64) ([]refresh.Unit, error) {
+	var units []refresh.Unit
+
+	if modules.IsEnabled("posts") && modules.IsEnabled("reactions") {
+		postsModule, err := modules.Posts()
+		if err != nil {
+			return nil, err
+		}
+
+		reactionsModule, err := modules.Reactions()
+		if err != nil {
+			return nil, err
+		}
+
+		posts, err := postsModule.QuerySubspacePosts(height, subspaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		units = append(units, refresh.PostsUnits("reactions", posts, func(post types.Post) error {
+			return reactionsModule.RefreshReactionsData(height, post.SubspaceID, post.ID)
+		})...)
+	}
+
+	if modules.IsEnabled("reports") {
+		reportsModule, err := modules.Reports()
+		if err != nil {
+			return nil, err
+		}
+
+		units = append(units, refresh.NewUnit("reports", subspaceID, 0, func() error {
+			return reportsModule.RefreshReportsData(height, subspaceID)
+		}))
+	}
+
+	if modules.IsEnabled("contracts") {
+		contractsModule, err := modules.Contracts()
+		if err != nil {
+			return nil, err
+		}
+
+		units = append(units, refresh.NewUnit("contracts", subspaceID, 0, func() error {
+			return contractsModule.RefreshData(height, subspaceID)
+		}))
+	}
+
+	return units, nil
 }
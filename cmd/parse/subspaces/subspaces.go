@@ -1,16 +1,13 @@
 package subspaces
 
 import (
-	"fmt"
-
 	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
-	"github.com/forbole/juno/v5/node/remote"
 	"github.com/forbole/juno/v5/types/config"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
-	"github.com/desmos-labs/athena/v2/database"
-	"github.com/desmos-labs/athena/v2/x/subspaces"
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	"github.com/desmos-labs/athena/v2/cmd/parse/refresh"
 )
 
 // subspacesCmd returns a Cobra command that allows to refresh all the subspaces
@@ -19,30 +16,31 @@ func subspacesCmd(parseConfig *parsecmdtypes.Config) *cobra.Command {
 		Use:   "all",
 		Short: "Fetch all the subspaces and their data from the node and save them properly",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			parseCtx, err := parsecmdtypes.GetParserContext(config.Cfg, parseConfig)
+			modules, err := container.NewContainer(config.Cfg, parseConfig)
 			if err != nil {
 				return err
 			}
 
-			remoteCfg, ok := config.Cfg.Node.Details.(*remote.Details)
-			if !ok {
-				panic(fmt.Errorf("cannot run Athena on local node"))
+			subspacesModule, err := modules.Subspaces()
+			if err != nil {
+				return err
 			}
 
-			// Get the database
-			db := database.Cast(parseCtx.Database)
-
-			grpcConnection := remote.MustCreateGrpcConnection(remoteCfg.GRPC)
-			profilesModule := subspaces.NewModule(parseCtx.Node, grpcConnection, parseCtx.EncodingConfig.Codec, db)
-
 			// Get the latest height
-			height, err := parseCtx.Node.LatestHeight()
+			height, err := modules.LatestHeight()
+			if err != nil {
+				return err
+			}
+
+			runner, err := refresh.NewRunnerFromFlags(cmd, modules.Database(), height)
 			if err != nil {
 				return err
 			}
 
 			log.Info().Int64("height", height).Msg("refreshing subspaces")
-			return profilesModule.RefreshSubspacesData(height)
+			return runner.Run([]refresh.Unit{refresh.NewUnit("subspaces", 0, 0, func() error {
+				return subspacesModule.RefreshSubspacesData(height)
+			})})
 		},
 	}
 }
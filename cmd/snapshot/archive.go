@@ -0,0 +1,526 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatVersion represents the version of the archive format written by this binary
+	FormatVersion = 1
+
+	// FormatNDJSON represents the format of the archives containing one JSON object for each row
+	FormatNDJSON = "ndjson"
+
+	// FormatCSV represents the format of the archives containing a CSV header followed by one record for each row,
+	// using the PostgreSQL text representation of the values
+	FormatCSV = "csv"
+
+	manifestFileName = "manifest.json"
+	tablesDir        = "tables"
+
+	// csvNull represents the NULL values inside the CSV files. The values made of backslashes followed by N
+	// are escaped by adding another backslash, so that they are not confused with it.
+	csvNull = `\N`
+)
+
+// Manifest contains the details of a snapshot archive
+type Manifest struct {
+	FormatVersion int    `json:"format_version"`
+	Format        string `json:"format"`
+	SchemaVersion string `json:"schema_version"`
+
+	// StartHeight is the height of the first block contained inside the snapshot
+	StartHeight int64 `json:"start_height"`
+
+	// Height is the highest height up to which all the blocks following the start height are contained
+	// inside the snapshot, so that parsing can be resumed from the next one without skipping any block
+	Height int64 `json:"height"`
+
+	// LatestHeight is the height of the latest block contained inside the snapshot. It is greater than Height
+	// if some blocks are missing from the snapshot.
+	LatestHeight int64 `json:"latest_height"`
+
+	CreatedAt time.Time `json:"created_at"`
+	Tables    []Table   `json:"tables"`
+}
+
+// CheckStartHeight makes sure that, once the snapshot is imported, parsing will resume from a height that does not
+// cause any block missing from the snapshot to be skipped
+func (m Manifest) CheckStartHeight(startHeight int64) error {
+	// Without a start height, parsing resumes from the latest stored block
+	if startHeight == 0 {
+		if m.Height != m.LatestHeight {
+			return fmt.Errorf("snapshot is missing some blocks after height %d: please set parsing.start_height "+
+				"to %d inside the configuration before importing it", m.Height, m.Height+1)
+		}
+		return nil
+	}
+
+	if startHeight > m.Height+1 {
+		return fmt.Errorf("parsing start height %d is greater than the height following the snapshot one: "+
+			"please set parsing.start_height to %d inside the configuration", startHeight, m.Height+1)
+	}
+
+	if startHeight < m.StartHeight {
+		return fmt.Errorf("parsing start height %d is lower than the first height of the snapshot %d: "+
+			"please set parsing.start_height to %d inside the configuration",
+			startHeight, m.StartHeight, m.Height+1)
+	}
+
+	return nil
+}
+
+// tableFileSuffix returns the suffix of the table files of the archives having the given format
+func tableFileSuffix(format string) string {
+	return "." + format + ".gz"
+}
+
+// validateFormat returns an error if the given archive format is not supported
+func validateFormat(format string) error {
+	if format != FormatNDJSON && format != FormatCSV {
+		return fmt.Errorf("unsupported snapshot format %s: expected %s or %s", format, FormatNDJSON, FormatCSV)
+	}
+	return nil
+}
+
+// encodeCSVValue returns the given value as a CSV field
+func encodeCSVValue(value sql.NullString) string {
+	if !value.Valid {
+		return csvNull
+	}
+	if isNullLike(value.String) {
+		return `\` + value.String
+	}
+	return value.String
+}
+
+// decodeCSVValue returns the value represented by the given CSV field
+func decodeCSVValue(field string) sql.NullString {
+	if field == csvNull {
+		return sql.NullString{}
+	}
+	if isNullLike(field) {
+		return sql.NullString{String: field[1:], Valid: true}
+	}
+	return sql.NullString{String: field, Valid: true}
+}
+
+// isNullLike tells whether the given value is made of one or more backslashes followed by N
+func isNullLike(value string) bool {
+	return len(value) >= 2 && strings.HasSuffix(value, "N") && strings.Trim(value[:len(value)-1], `\`) == ""
+}
+
+// Table contains the details of a table stored inside a snapshot archive
+type Table struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+	Rows int64  `json:"rows"`
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// ArchiveWriter writes a snapshot archive, which is a tar file containing one gzipped NDJSON or CSV file for each
+// table and a manifest.json file, written last, describing its content
+type ArchiveWriter struct {
+	tw     *tar.Writer
+	format string
+	tables []Table
+}
+
+// NewArchiveWriter returns a new ArchiveWriter instance writing the archive to the given writer,
+// using the given format for the table files
+func NewArchiveWriter(w io.Writer, format string) (*ArchiveWriter, error) {
+	err := validateFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArchiveWriter{tw: tar.NewWriter(w), format: format}, nil
+}
+
+// WriteTable writes the rows of the given table to an NDJSON archive. The given export function is called with a
+// function that should be used to write each row, encoded as a JSON object, and must return the number of rows.
+func (w *ArchiveWriter) WriteTable(name string, export func(write func(row []byte) error) (int64, error)) error {
+	if w.format != FormatNDJSON {
+		return fmt.Errorf("rows encoded as JSON cannot be written to a %s archive", w.format)
+	}
+
+	return w.writeTableFile(name, func(writer *bufio.Writer) (int64, error) {
+		return export(func(row []byte) error {
+			_, err := writer.Write(row)
+			if err != nil {
+				return err
+			}
+			return writer.WriteByte('\n')
+		})
+	})
+}
+
+// WriteCSVTable writes the rows of the given table to a CSV archive. The given export function is called with a
+// function that should be used to write the values of the given columns for each row, and must return the number
+// of rows.
+func (w *ArchiveWriter) WriteCSVTable(
+	name string, columns []string, export func(write func(values []sql.NullString) error) (int64, error),
+) error {
+	if w.format != FormatCSV {
+		return fmt.Errorf("rows encoded as CSV cannot be written to a %s archive", w.format)
+	}
+
+	return w.writeTableFile(name, func(writer *bufio.Writer) (int64, error) {
+		csvWriter := csv.NewWriter(writer)
+		err := csvWriter.Write(columns)
+		if err != nil {
+			return 0, err
+		}
+
+		record := make([]string, len(columns))
+		rows, err := export(func(values []sql.NullString) error {
+			for i, value := range values {
+				record[i] = encodeCSVValue(value)
+			}
+			return csvWriter.Write(record)
+		})
+		if err != nil {
+			return rows, err
+		}
+
+		csvWriter.Flush()
+		return rows, csvWriter.Error()
+	})
+}
+
+// writeTableFile writes the gzipped file of the given table to the archive, using the given function
+// to write its rows
+func (w *ArchiveWriter) writeTableFile(name string, writeRows func(writer *bufio.Writer) (int64, error)) error {
+	// The size of each tar entry must be known before writing it, so the rows are compressed into a temporary file
+	tmpFile, err := os.CreateTemp("", "athena-snapshot-*"+tableFileSuffix(w.format))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	gzipWriter := gzip.NewWriter(tmpFile)
+	bufWriter := bufio.NewWriter(gzipWriter)
+	rows, err := writeRows(bufWriter)
+	if err != nil {
+		return fmt.Errorf("error while exporting table %s: %s", name, err)
+	}
+
+	err = bufWriter.Flush()
+	if err != nil {
+		return err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	size, err := tmpFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	_, err = tmpFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	file := path.Join(tablesDir, name+tableFileSuffix(w.format))
+	err = w.writeEntry(file, size, tmpFile)
+	if err != nil {
+		return err
+	}
+
+	w.tables = append(w.tables, Table{Name: name, File: file, Rows: rows})
+	return nil
+}
+
+// Close writes the manifest of the archive and closes it. The format version, format, creation time and tables
+// of the given manifest are set by the writer.
+func (w *ArchiveWriter) Close(manifest Manifest) error {
+	manifest.FormatVersion = FormatVersion
+	manifest.Format = w.format
+	manifest.CreatedAt = time.Now().UTC()
+	manifest.Tables = w.tables
+
+	bz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = w.writeEntry(manifestFileName, int64(len(bz)), bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+
+	return w.tw.Close()
+}
+
+// writeEntry writes a new file having the given name, size and content to the archive
+func (w *ArchiveWriter) writeEntry(name string, size int64, content io.Reader) error {
+	err := w.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w.tw, content)
+	return err
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// ArchiveReader reads a snapshot archive written by an ArchiveWriter
+type ArchiveReader struct {
+	file     *os.File
+	manifest Manifest
+}
+
+// OpenArchive opens the snapshot archive at the given path, reading and validating its manifest
+func OpenArchive(filePath string) (*ArchiveReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &ArchiveReader{file: file}
+	err = reader.readManifest()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// readManifest reads the manifest of the archive, making sure its format is supported
+func (r *ArchiveReader) readManifest() error {
+	tr := tar.NewReader(r.file)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("invalid snapshot archive: %s not found", manifestFileName)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid snapshot archive: %s", err)
+		}
+
+		if header.Name != manifestFileName {
+			continue
+		}
+
+		err = json.NewDecoder(tr).Decode(&r.manifest)
+		if err != nil {
+			return fmt.Errorf("invalid snapshot manifest: %s", err)
+		}
+
+		if r.manifest.FormatVersion != FormatVersion {
+			return fmt.Errorf("unsupported snapshot format version %d: expected %d",
+				r.manifest.FormatVersion, FormatVersion)
+		}
+
+		return validateFormat(r.manifest.Format)
+	}
+}
+
+// Manifest returns the manifest of the archive
+func (r *ArchiveReader) Manifest() Manifest {
+	return r.manifest
+}
+
+// ReadTables reads the rows of all the tables of an NDJSON archive listed inside the manifest, in their order,
+// calling the given function with batches of at most batchSize rows. It returns an error if the number of rows
+// of a table does not match the one stored inside the manifest.
+func (r *ArchiveReader) ReadTables(batchSize int, handle func(table string, rows []json.RawMessage) error) error {
+	if r.manifest.Format != FormatNDJSON {
+		return fmt.Errorf("rows encoded as JSON cannot be read from a %s archive", r.manifest.Format)
+	}
+
+	return r.readTableFiles(func(content io.Reader, table Table) error {
+		return readTable(content, table, batchSize, handle)
+	})
+}
+
+// ReadCSVTables reads the rows of all the tables of a CSV archive listed inside the manifest, in their order,
+// calling the given function with the columns of the table and batches of at most batchSize rows. It returns an
+// error if the number of rows of a table does not match the one stored inside the manifest.
+func (r *ArchiveReader) ReadCSVTables(
+	batchSize int, handle func(table string, columns []string, rows [][]sql.NullString) error,
+) error {
+	if r.manifest.Format != FormatCSV {
+		return fmt.Errorf("rows encoded as CSV cannot be read from a %s archive", r.manifest.Format)
+	}
+
+	return r.readTableFiles(func(content io.Reader, table Table) error {
+		return readCSVTable(content, table, batchSize, handle)
+	})
+}
+
+// readTableFiles calls the given function with the content of each table file listed inside the manifest,
+// making sure they are stored in the same order
+func (r *ArchiveReader) readTableFiles(readFile func(content io.Reader, table Table) error) error {
+	_, err := r.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	tables := make(map[string]Table, len(r.manifest.Tables))
+	for _, table := range r.manifest.Tables {
+		tables[table.File] = table
+	}
+
+	tr := tar.NewReader(r.file)
+	read := 0
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid snapshot archive: %s", err)
+		}
+
+		table, found := tables[header.Name]
+		if !found {
+			continue
+		}
+
+		if read >= len(r.manifest.Tables) || table.Name != r.manifest.Tables[read].Name {
+			return fmt.Errorf("invalid snapshot archive: unexpected table %s", table.Name)
+		}
+
+		err = readFile(tr, table)
+		if err != nil {
+			return fmt.Errorf("error while reading table %s: %s", table.Name, err)
+		}
+		read++
+	}
+
+	if read != len(r.manifest.Tables) {
+		return fmt.Errorf("invalid snapshot archive: %d tables found, expected %d", read, len(r.manifest.Tables))
+	}
+
+	return nil
+}
+
+// readTable reads the gzipped NDJSON rows of the given table, calling the given function with batches of rows
+func readTable(
+	content io.Reader, table Table, batchSize int, handle func(table string, rows []json.RawMessage) error,
+) error {
+	gzipReader, err := gzip.NewReader(content)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	var count int64
+	var batch []json.RawMessage
+	bufReader := bufio.NewReader(gzipReader)
+	for {
+		line, err := bufReader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			batch = append(batch, line)
+			count++
+		}
+
+		if len(batch) > 0 && (len(batch) == batchSize || errors.Is(err, io.EOF)) {
+			handleErr := handle(table.Name, batch)
+			if handleErr != nil {
+				return handleErr
+			}
+			batch = nil
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	if count != table.Rows {
+		return fmt.Errorf("%d rows found, expected %d", count, table.Rows)
+	}
+
+	return nil
+}
+
+// readCSVTable reads the gzipped CSV rows of the given table, calling the given function with batches of rows
+func readCSVTable(
+	content io.Reader, table Table, batchSize int, handle func(table string, columns []string, rows [][]sql.NullString) error,
+) error {
+	gzipReader, err := gzip.NewReader(content)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	csvReader := csv.NewReader(gzipReader)
+	columns, err := csvReader.Read()
+	if err != nil {
+		return fmt.Errorf("error while reading header: %s", err)
+	}
+
+	var count int64
+	var batch [][]sql.NullString
+	for {
+		record, err := csvReader.Read()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		if record != nil {
+			values := make([]sql.NullString, len(record))
+			for i, field := range record {
+				values[i] = decodeCSVValue(field)
+			}
+			batch = append(batch, values)
+			count++
+		}
+
+		if len(batch) > 0 && (len(batch) == batchSize || errors.Is(err, io.EOF)) {
+			handleErr := handle(table.Name, columns, batch)
+			if handleErr != nil {
+				return handleErr
+			}
+			batch = nil
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	if count != table.Rows {
+		return fmt.Errorf("%d rows found, expected %d", count, table.Rows)
+	}
+
+	return nil
+}
+
+// Close closes the archive
+func (r *ArchiveReader) Close() error {
+	return r.file.Close()
+}
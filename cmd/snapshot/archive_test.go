@@ -0,0 +1,220 @@
+package snapshot_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/cmd/snapshot"
+)
+
+// exportRows returns an export function writing the given rows
+func exportRows(rows ...string) func(write func(row []byte) error) (int64, error) {
+	return func(write func(row []byte) error) (int64, error) {
+		for _, row := range rows {
+			err := write([]byte(row))
+			if err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(rows)), nil
+	}
+}
+
+// exportValues returns an export function writing the given rows of values
+func exportValues(rows ...[]sql.NullString) func(write func(values []sql.NullString) error) (int64, error) {
+	return func(write func(values []sql.NullString) error) (int64, error) {
+		for _, row := range rows {
+			err := write(row)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return int64(len(rows)), nil
+	}
+}
+
+// value returns a non-NULL value
+func value(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: true}
+}
+
+func TestArchive(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "snapshot.tar")
+
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+
+	writer, err := snapshot.NewArchiveWriter(file, snapshot.FormatNDJSON)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteTable("block", exportRows(`{"height":1}`, `{"height":2}`, `{"height":3}`)))
+	require.NoError(t, writer.WriteTable("profile", exportRows()))
+	require.NoError(t, writer.WriteTable("post", exportRows(`{"id":1,"text":"line\nbreak"}`)))
+	require.Error(t, writer.WriteCSVTable("post", []string{"id"}, exportValues()))
+	require.NoError(t, writer.Close(snapshot.Manifest{
+		SchemaVersion: "25-failed-messages",
+		StartHeight:   1,
+		Height:        3,
+		LatestHeight:  5,
+	}))
+	require.NoError(t, file.Close())
+
+	archive, err := snapshot.OpenArchive(filePath)
+	require.NoError(t, err)
+	defer archive.Close()
+
+	manifest := archive.Manifest()
+	require.Equal(t, snapshot.FormatVersion, manifest.FormatVersion)
+	require.Equal(t, snapshot.FormatNDJSON, manifest.Format)
+	require.Equal(t, "25-failed-messages", manifest.SchemaVersion)
+	require.Equal(t, int64(1), manifest.StartHeight)
+	require.Equal(t, int64(3), manifest.Height)
+	require.Equal(t, int64(5), manifest.LatestHeight)
+	require.Equal(t, []snapshot.Table{
+		{Name: "block", File: "tables/block.ndjson.gz", Rows: 3},
+		{Name: "profile", File: "tables/profile.ndjson.gz", Rows: 0},
+		{Name: "post", File: "tables/post.ndjson.gz", Rows: 1},
+	}, manifest.Tables)
+
+	var read []string
+	err = archive.ReadTables(2, func(table string, rows []json.RawMessage) error {
+		for _, row := range rows {
+			read = append(read, fmt.Sprintf("%s %s", table, row))
+		}
+		read = append(read, "batch")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`block {"height":1}`,
+		`block {"height":2}`,
+		"batch",
+		`block {"height":3}`,
+		"batch",
+		`post {"id":1,"text":"line\nbreak"}`,
+		"batch",
+	}, read)
+}
+
+func TestArchive_CSV(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "snapshot.tar")
+
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+
+	rows := [][]sql.NullString{
+		{value("1"), value("line\nbreak, \"quoted\"")},
+		{value("2"), {}},
+		{value("3"), value(`\N`)},
+		{value("4"), value(`\\N`)},
+		{value("5"), value("")},
+	}
+
+	writer, err := snapshot.NewArchiveWriter(file, snapshot.FormatCSV)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteCSVTable("post", []string{"id", "text"}, exportValues(rows...)))
+	require.NoError(t, writer.WriteCSVTable("profile", []string{"address"}, exportValues()))
+	require.Error(t, writer.WriteTable("block", exportRows(`{"height":1}`)))
+	require.NoError(t, writer.Close(snapshot.Manifest{SchemaVersion: "25-failed-messages", StartHeight: 1, Height: 1, LatestHeight: 1}))
+	require.NoError(t, file.Close())
+
+	archive, err := snapshot.OpenArchive(filePath)
+	require.NoError(t, err)
+	defer archive.Close()
+
+	manifest := archive.Manifest()
+	require.Equal(t, snapshot.FormatCSV, manifest.Format)
+	require.Equal(t, []snapshot.Table{
+		{Name: "post", File: "tables/post.csv.gz", Rows: 5},
+		{Name: "profile", File: "tables/profile.csv.gz", Rows: 0},
+	}, manifest.Tables)
+
+	require.Error(t, archive.ReadTables(2, func(table string, rows []json.RawMessage) error {
+		return nil
+	}))
+
+	var read [][]sql.NullString
+	var batches int
+	err = archive.ReadCSVTables(2, func(table string, columns []string, batch [][]sql.NullString) error {
+		require.Equal(t, "post", table)
+		require.Equal(t, []string{"id", "text"}, columns)
+		read = append(read, batch...)
+		batches++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, batches)
+	require.Equal(t, rows, read)
+}
+
+func TestManifest_CheckStartHeight(t *testing.T) {
+	testCases := []struct {
+		name        string
+		manifest    snapshot.Manifest
+		startHeight int64
+		shouldErr   bool
+	}{
+		{
+			name:        "missing start height with contiguous blocks returns no error",
+			manifest:    snapshot.Manifest{StartHeight: 1, Height: 10, LatestHeight: 10},
+			startHeight: 0,
+			shouldErr:   false,
+		},
+		{
+			name:        "missing start height with missing blocks returns error",
+			manifest:    snapshot.Manifest{StartHeight: 1, Height: 5, LatestHeight: 10},
+			startHeight: 0,
+			shouldErr:   true,
+		},
+		{
+			name:        "start height lower than the snapshot start height returns error",
+			manifest:    snapshot.Manifest{StartHeight: 3, Height: 5, LatestHeight: 10},
+			startHeight: 2,
+			shouldErr:   true,
+		},
+		{
+			name:        "start height greater than the height following the snapshot one returns error",
+			manifest:    snapshot.Manifest{StartHeight: 1, Height: 5, LatestHeight: 10},
+			startHeight: 7,
+			shouldErr:   true,
+		},
+		{
+			name:        "start height following the snapshot one returns no error",
+			manifest:    snapshot.Manifest{StartHeight: 1, Height: 5, LatestHeight: 10},
+			startHeight: 6,
+			shouldErr:   false,
+		},
+		{
+			name:        "start height inside the snapshot returns no error",
+			manifest:    snapshot.Manifest{StartHeight: 1, Height: 5, LatestHeight: 10},
+			startHeight: 1,
+			shouldErr:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.manifest.CheckStartHeight(tc.startHeight)
+			if tc.shouldErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenArchive_MissingManifest(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "snapshot.tar")
+	require.NoError(t, os.WriteFile(filePath, nil, 0644))
+
+	_, err := snapshot.OpenArchive(filePath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "manifest.json not found")
+}
@@ -0,0 +1,278 @@
+package snapshot
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
+	"github.com/desmos-labs/athena/v2/database"
+)
+
+const (
+	// importBatchSize represents the maximum number of rows that are inserted using a single statement
+	importBatchSize = 500
+
+	flagFormat = "format"
+)
+
+// NewSnapshotCmd returns the Cobra command allowing to export and import snapshots of the database
+func NewSnapshotCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "snapshot",
+		Short:             "Export and import snapshots of the database",
+		PersistentPreRunE: cmdutils.RunPersistentPreRuns(parsecmdtypes.ReadConfigPreRunE(parseCfg)),
+	}
+
+	cmd.AddCommand(
+		exportCmd(parseCfg),
+		importCmd(parseCfg),
+	)
+
+	return cmd
+}
+
+// exportCmd returns the Cobra command allowing to export a snapshot of the database
+func exportCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export all the tables of the database to a snapshot archive",
+		Long: `Export the content of all the tables of the database to the given file. The snapshot is a tar archive
+containing a gzipped NDJSON or CSV file for each table, along with a manifest.json file containing the version of the
+archive format, the version of the database schema and the heights of the blocks stored inside the database.
+
+The manifest height is the highest height up to which all the blocks following the first stored one are included, so
+that parsing can be resumed from the next height without skipping any block even if the database has some gaps.
+
+The tables are read inside a single transaction, so the snapshot can be exported while Athena is running.`,
+		Example: `athena snapshot export athena-snapshot.tar --format csv`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := cmd.Flags().GetString(flagFormat)
+			if err != nil {
+				return err
+			}
+
+			db, err := cmdutils.GetDatabase(parseCfg)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			export, err := db.BeginSnapshotExport()
+			if err != nil {
+				return err
+			}
+			defer export.Close()
+
+			manifest, err := getManifest(export)
+			if err != nil {
+				return err
+			}
+
+			tables, err := export.Tables()
+			if err != nil {
+				return err
+			}
+
+			file, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			writer, err := NewArchiveWriter(file, format)
+			if err != nil {
+				return err
+			}
+
+			for _, table := range tables {
+				log.Info().Str("table", table).Msg("exporting table")
+				err = exportTable(export, writer, format, table)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = writer.Close(manifest)
+			if err != nil {
+				return err
+			}
+
+			err = file.Close()
+			if err != nil {
+				return err
+			}
+
+			if manifest.Height != manifest.LatestHeight {
+				cmd.Printf("the database is missing some blocks after height %d: parsing will resume from "+
+					"that height once the snapshot is imported\n", manifest.Height)
+			}
+
+			cmd.Printf("snapshot of height %d exported to %s (schema version %s)\n",
+				manifest.Height, args[0], manifest.SchemaVersion)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagFormat, FormatNDJSON, fmt.Sprintf("format of the table files (%s or %s)", FormatNDJSON, FormatCSV))
+
+	return cmd
+}
+
+// getManifest returns the manifest containing the schema version and the heights of the given snapshot
+func getManifest(export *database.SnapshotExport) (Manifest, error) {
+	schemaVersion, err := export.SchemaVersion()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	startHeight, err := export.StartHeight()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	height, err := export.Height()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	latestHeight, err := export.LatestHeight()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return Manifest{
+		SchemaVersion: schemaVersion,
+		StartHeight:   startHeight,
+		Height:        height,
+		LatestHeight:  latestHeight,
+	}, nil
+}
+
+// exportTable writes the rows of the given table to the archive using the given format
+func exportTable(export *database.SnapshotExport, writer *ArchiveWriter, format string, table string) error {
+	if format == FormatCSV {
+		columns, err := export.Columns(table)
+		if err != nil {
+			return err
+		}
+
+		return writer.WriteCSVTable(table, columns, func(write func(values []sql.NullString) error) (int64, error) {
+			return export.ExportTableText(table, columns, write)
+		})
+	}
+
+	return writer.WriteTable(table, func(write func(row []byte) error) (int64, error) {
+		return export.ExportTable(table, write)
+	})
+}
+
+// importCmd returns the Cobra command allowing to import a snapshot inside the database
+func importCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import a snapshot archive inside an empty database",
+		Long: `Import the content of the given snapshot archive inside the database, using a single transaction.
+
+The database must be empty and migrated to the same schema version of the snapshot. If the snapshot has been exported
+using an older schema version, migrate the database to that version using the --to flag of the database migrate
+command, import the snapshot and then migrate the database again.
+
+Since the blocks are imported as well, Athena resumes parsing from the latest imported block once started. If the
+snapshot is missing some blocks after its height, the parsing.start_height field of the configuration must be set to a
+value between the first height of the snapshot and the one following its height, so that the missing blocks are
+parsed as well.`,
+		Example: `athena snapshot import athena-snapshot.tar`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archive, err := OpenArchive(args[0])
+			if err != nil {
+				return err
+			}
+			defer archive.Close()
+
+			manifest := archive.Manifest()
+
+			err = manifest.CheckStartHeight(config.Cfg.Parser.StartHeight)
+			if err != nil {
+				return err
+			}
+
+			db, err := cmdutils.GetDatabase(parseCfg)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			snapshotImport, err := db.BeginSnapshotImport()
+			if err != nil {
+				return err
+			}
+			defer snapshotImport.Rollback()
+
+			schemaVersion, err := snapshotImport.SchemaVersion()
+			if err != nil {
+				return err
+			}
+
+			if schemaVersion != manifest.SchemaVersion {
+				return fmt.Errorf("snapshot schema version %s does not match the database schema version %s: "+
+					"please run athena database migrate --to %s before importing it",
+					manifest.SchemaVersion, schemaVersion, manifest.SchemaVersion)
+			}
+
+			err = checkTables(snapshotImport, manifest)
+			if err != nil {
+				return err
+			}
+
+			if manifest.Format == FormatCSV {
+				err = archive.ReadCSVTables(importBatchSize, snapshotImport.ImportTextRows)
+			} else {
+				err = archive.ReadTables(importBatchSize, func(table string, rows []json.RawMessage) error {
+					return snapshotImport.ImportRows(table, rows)
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			err = snapshotImport.Commit()
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("snapshot of height %d imported: parsing will resume from height %d\n",
+				manifest.Height, manifest.Height+1)
+			return nil
+		},
+	}
+}
+
+// checkTables makes sure all the tables of the given snapshot exist inside the database and are empty
+func checkTables(snapshotImport *database.SnapshotImport, manifest Manifest) error {
+	tables, err := snapshotImport.Tables()
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		existing[table] = true
+	}
+
+	for _, table := range manifest.Tables {
+		if !existing[table.Name] {
+			return fmt.Errorf("table %s of the snapshot does not exist inside the database", table.Name)
+		}
+	}
+
+	return snapshotImport.CheckEmpty(tables)
+}
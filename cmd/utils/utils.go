@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	junodb "github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/database"
+)
+
+// RunPersistentPreRuns returns a function that runs the persistent pre-run of the root command before the
+// given one, since Cobra only runs the persistent pre-run of the closest command defining it
+func RunPersistentPreRuns(preRun func(_ *cobra.Command, _ []string) error) func(_ *cobra.Command, _ []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if root := cmd.Root(); root != nil {
+			if root.PersistentPreRunE != nil {
+				err := root.PersistentPreRunE(root, args)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return preRun(cmd, args)
+	}
+}
+
+// GetDatabase builds the database using the current configuration.
+// NOTE: Modules are not built, so that the database can be used even if its schema is not the latest one.
+func GetDatabase(parseCfg *parsecmdtypes.Config) (*database.Db, error) {
+	encodingConfig := parseCfg.GetEncodingConfigBuilder()()
+	databaseCtx := junodb.NewContext(config.Cfg.Database, encodingConfig, parseCfg.GetLogger())
+	db, err := parseCfg.GetDBBuilder()(databaseCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	athenaDb, ok := db.(*database.Db)
+	if !ok {
+		return nil, fmt.Errorf("database is not a Athena database instance")
+	}
+
+	return athenaDb, nil
+}
+
+// CheckSchemaPreRunE returns a function that makes sure the database schema is up to date, so that the commands
+// building the modules refuse to run against a database having some pending migrations.
+// It must be run after the config has been read.
+func CheckSchemaPreRunE(parseCfg *parsecmdtypes.Config) func(_ *cobra.Command, _ []string) error {
+	return func(_ *cobra.Command, _ []string) error {
+		db, err := GetDatabase(parseCfg)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		return db.CheckSchema()
+	}
+}
@@ -0,0 +1,181 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+	parsecmdtypes "github.com/forbole/juno/v5/cmd/parse/types"
+	junotypes "github.com/forbole/juno/v5/types"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/desmos-labs/athena/v2/cmd/parse/container"
+	cmdutils "github.com/desmos-labs/athena/v2/cmd/utils"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+const (
+	flagFix = "fix"
+)
+
+// NewVerifyCmd returns the Cobra command allowing to verify the database against the chain state
+func NewVerifyCmd(parseCfg *parsecmdtypes.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [module] [[subspace-id]]",
+		Short: "Compare the data stored inside the database with the chain state",
+		Long: `Compare the data of the given module stored inside the database with the chain state at the last parsed 
+height, printing a JSON report of the missing, extra and mismatched entities.
+Supported modules are subspaces, posts, reactions, relationships, reports and profiles. The subspace id is required 
+for the posts, reactions, relationships and reports modules, optional for the subspaces module (all the subspaces are 
+verified if it is not provided) and not allowed for the profiles module. 
+
+When the --fix flag is provided, only the drifted entities are repaired. The entities that cannot be repaired are 
+reported along with the error that occurred.`,
+		Example: `athena verify posts 1 --fix`,
+		Args:    cobra.RangeArgs(1, 2),
+		PersistentPreRunE: cmdutils.RunPersistentPreRuns(junotypes.ConcatCobraCmdFuncs(
+			parsecmdtypes.ReadConfigPreRunE(parseCfg),
+			cmdutils.CheckSchemaPreRunE(parseCfg),
+		)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fix, err := cmd.Flags().GetBool(flagFix)
+			if err != nil {
+				return err
+			}
+
+			var subspaceID uint64
+			if len(args) > 1 {
+				subspaceID, err = subspacestypes.ParseSubspaceID(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			modules, err := container.NewContainer(config.Cfg, parseCfg)
+			if err != nil {
+				return err
+			}
+
+			// Compare against the last parsed height, so that the entities changed by the blocks that have not
+			// been parsed yet are not reported as drifted
+			height, err := modules.LastParsedHeight()
+			if err != nil {
+				return err
+			}
+
+			var report *types.VerificationReport
+			switch module := args[0]; module {
+			case "subspaces":
+				subspacesModule, err := modules.Subspaces()
+				if err != nil {
+					return err
+				}
+				report, err = subspacesModule.Verify(height, subspaceID)
+				if err != nil {
+					return err
+				}
+
+			case "posts":
+				if subspaceID == 0 {
+					return fmt.Errorf("the subspace id is required when verifying the posts")
+				}
+
+				postsModule, err := modules.Posts()
+				if err != nil {
+					return err
+				}
+				report, err = postsModule.Verify(height, subspaceID)
+				if err != nil {
+					return err
+				}
+
+			case "reactions", "relationships", "reports":
+				if subspaceID == 0 {
+					return fmt.Errorf("the subspace id is required when verifying the %s", module)
+				}
+
+				report, err = verifySubspaceModule(modules, module, height, subspaceID)
+				if err != nil {
+					return err
+				}
+
+			case "profiles":
+				if subspaceID != 0 {
+					return fmt.Errorf("profiles are not verified per subspace")
+				}
+
+				profilesModule, err := modules.Profiles()
+				if err != nil {
+					return err
+				}
+				report, err = profilesModule.Verify(height)
+				if err != nil {
+					return err
+				}
+
+			default:
+				return fmt.Errorf("unsupported module: %s", module)
+			}
+
+			if fix && report.HasDrift() {
+				log.Info().Int64("height", height).Str("module", report.Module).Msg("fixing drifted entities")
+				fixErr := report.Fix()
+				if fixErr != nil {
+					// Print the report anyway, so that the entities that could not be fixed are known
+					err = printReport(cmd, report)
+					if err != nil {
+						return err
+					}
+					return fixErr
+				}
+			}
+
+			return printReport(cmd, report)
+		},
+	}
+
+	cmd.Flags().Bool(flagFix, false, "Repair the entities that differ from the chain state")
+
+	return cmd
+}
+
+// verifySubspaceModule verifies the data of the given subspace stored by the module having the given name
+func verifySubspaceModule(
+	modules *container.Container, module string, height int64, subspaceID uint64,
+) (*types.VerificationReport, error) {
+	switch module {
+	case "reactions":
+		reactionsModule, err := modules.Reactions()
+		if err != nil {
+			return nil, err
+		}
+		return reactionsModule.Verify(height, subspaceID)
+
+	case "relationships":
+		relationshipsModule, err := modules.Relationships()
+		if err != nil {
+			return nil, err
+		}
+		return relationshipsModule.Verify(height, subspaceID)
+
+	default:
+		reportsModule, err := modules.Reports()
+		if err != nil {
+			return nil, err
+		}
+		return reportsModule.Verify(height, subspaceID)
+	}
+}
+
+// printReport prints the given report as indented JSON
+func printReport(cmd *cobra.Command, report *types.VerificationReport) error {
+	bz, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+	return err
+}
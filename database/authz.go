@@ -26,20 +26,19 @@ ON CONFLICT ON CONSTRAINT unique_msg_type_authorization DO UPDATE
         expiration = excluded.expiration,
         height = excluded.height
 WHERE authz_grant.height <= excluded.height`
-	_, err = db.SQL.Exec(stmt, grant.Granter, grant.Grantee, grant.Authorization.MsgTypeURL(), string(authzBz), grant.Expiration, grant.Height)
+	_, err = db.conn().Exec(stmt, grant.Granter, grant.Grantee, grant.Authorization.MsgTypeURL(), string(authzBz), grant.Expiration, grant.Height)
 	return err
 }
 
 // DeleteAuthzGrant deletes the authz grant related to the given data
 func (db *Db) DeleteAuthzGrant(granter string, grantee string, msgTypeURL string, height int64) error {
 	stmt := `DELETE FROM authz_grant WHERE granter_address = $1 AND grantee_address = $2 AND msg_type_url = $3 AND height <= $4`
-	_, err := db.SQL.Exec(stmt, granter, grantee, msgTypeURL, height)
-	return err
+	return db.execDelete(height, stmt, granter, grantee, msgTypeURL, height)
 }
 
 // DeleteExpiredGrants deletes all the authz grants that are expired before or on the provided date
 func (db *Db) DeleteExpiredGrants(time time.Time) error {
 	stmt := `DELETE FROM authz_grant WHERE expiration <= $1`
-	_, err := db.SQL.Exec(stmt, time)
+	_, err := db.conn().Exec(stmt, time)
 	return err
 }
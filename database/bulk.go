@@ -0,0 +1,76 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// bulkChunkSize represents the maximum number of entities that are written inside a single transaction
+	// when storing them in bulk
+	bulkChunkSize = 500
+
+	// maxStatementParams represents the maximum number of parameters that PostgreSQL accepts for each statement
+	maxStatementParams = 65535
+)
+
+// valuesList returns the placeholders of a multi-row VALUES list having the given number of rows.
+// Each placeholder is cast to the given type, so that the values can be used inside sub-queries as well.
+func valuesList(rowsCount int, columnTypes []string) string {
+	var sb strings.Builder
+	for i := 0; i < rowsCount; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString("(")
+		for j, columnType := range columnTypes {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(fmt.Sprintf("$%d::%s", i*len(columnTypes)+j+1, columnType))
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// bulkExec executes the given statement for the given rows, replacing the %s verb of the statement with the
+// VALUES list of the rows. The rows are split into more statements if they exceed the parameters limit.
+func bulkExec(tx sqlTx, stmt string, columnTypes []string, rows [][]interface{}) error {
+	rowsPerStmt := maxStatementParams / len(columnTypes)
+	for start := 0; start < len(rows); start += rowsPerStmt {
+		end := start + rowsPerStmt
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		var vars []interface{}
+		for _, row := range rows[start:end] {
+			vars = append(vars, row...)
+		}
+
+		_, err := tx.Exec(fmt.Sprintf(stmt, valuesList(end-start, columnTypes)), vars...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inChunks calls the given function for each chunk of at most bulkChunkSize items out of the given count
+func inChunks(count int, fn func(start, end int) error) error {
+	for start := 0; start < count; start += bulkChunkSize {
+		end := start + bulkChunkSize
+		if end > count {
+			end = count
+		}
+
+		err := fn(start, end)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
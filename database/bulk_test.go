@@ -0,0 +1,230 @@
+package database_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/desmos-labs/desmos/v7/app"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+	junodb "github.com/forbole/juno/v5/database"
+	junodbcfg "github.com/forbole/juno/v5/database/config"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/types/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/database"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestSavePosts() {
+	posts := setupBulkData(suite.T(), suite.database, 1200)
+
+	// Make the first post quote the last one, which is stored inside a later chunk
+	posts[0].ReferencedPosts = []poststypes.PostReference{
+		poststypes.NewPostReference(poststypes.POST_REFERENCE_TYPE_QUOTE, uint64(len(posts)), 0),
+	}
+
+	err := suite.database.SavePosts(posts)
+	suite.Require().NoError(err)
+
+	var count int
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts), count)
+
+	// Comments stored inside a later chunk than their conversation should reference it as well
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post WHERE conversation_row_id IS NOT NULL`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts)/2, count)
+
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post_hashtag`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts), count)
+
+	err = suite.database.SQL.Get(&count, `SELECT comments_count FROM post_counters JOIN post ON post.row_id = post_counters.post_row_id WHERE post.id = 1`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts)/2, count)
+
+	// References to posts stored inside later chunks should be kept
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post_reference`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+
+	err = suite.database.SQL.Get(&count, `SELECT quotes_count FROM post_counters JOIN post ON post.row_id = post_counters.post_row_id WHERE post.id = $1`, len(posts))
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+
+	// Storing the same posts again should not duplicate their entities
+	err = suite.database.SavePosts(posts)
+	suite.Require().NoError(err)
+
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post_hashtag`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts), count)
+
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post_reference`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+}
+
+func (suite *DbTestSuite) TestSavePostsRelatedData() {
+	posts := setupBulkData(suite.T(), suite.database, 600)
+	err := suite.database.SavePosts(posts)
+	suite.Require().NoError(err)
+
+	var txs []types.PostTransaction
+	var attachments []types.PostAttachment
+	var answers []types.PollAnswer
+	for _, post := range posts {
+		txs = append(txs, types.NewPostTransaction(post.SubspaceID, post.ID, fmt.Sprintf("%064d", post.ID)))
+		attachments = append(attachments, types.NewPostAttachment(poststypes.NewAttachment(
+			post.SubspaceID,
+			post.ID,
+			1,
+			poststypes.NewPoll(
+				"What animal is best?",
+				[]poststypes.Poll_ProvidedAnswer{
+					poststypes.NewProvidedAnswer("Cat", nil),
+					poststypes.NewProvidedAnswer("Dog", nil),
+				},
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				false,
+				false,
+				nil,
+			),
+		), 10))
+		answers = append(answers,
+			types.NewPollAnswer(poststypes.NewUserAnswer(post.SubspaceID, post.ID, 1, []uint32{0}, post.Author), 10),
+			types.NewPollAnswer(poststypes.NewUserAnswer(post.SubspaceID, post.ID, 1, []uint32{1}, post.Author), 11),
+		)
+	}
+
+	suite.Require().NoError(suite.database.SavePostTxs(txs))
+	suite.Require().NoError(suite.database.SavePostAttachments(attachments))
+	suite.Require().NoError(suite.database.SavePollAnswers(answers))
+
+	var count int
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post_transaction`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts), count)
+
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post_attachment`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts), count)
+
+	// Only the most recent answer of each user should be stored
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM poll_answer WHERE answers_indexes = '{1}'`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts), count)
+
+	err = suite.database.SQL.Get(&count, `SELECT SUM(poll_answers_count) FROM post_counters`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(len(posts), count)
+}
+
+// setupBulkData stores a subspace and returns the given number of synthetic posts for it.
+// Each post having an even id is a comment to the first post.
+func setupBulkData(t testing.TB, db *database.Db, count int) []types.Post {
+	author := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+
+	err := db.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		author,
+		author,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1))
+	require.NoError(t, err)
+
+	err = db.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+	require.NoError(t, err)
+
+	posts := make([]types.Post, count)
+	for i := range posts {
+		id := uint64(i + 1)
+
+		var conversationID uint64
+		if id%2 == 0 {
+			conversationID = 1
+		}
+
+		posts[i] = types.NewPost(poststypes.NewPost(
+			1,
+			0,
+			id,
+			"",
+			fmt.Sprintf("Post number %d #desmos", id),
+			author,
+			conversationID,
+			poststypes.NewEntities([]poststypes.TextTag{poststypes.NewTextTag(15, 21, "desmos")}, nil, nil),
+			[]string{"benchmark"},
+			nil,
+			poststypes.REPLY_SETTING_EVERYONE,
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			nil,
+			author,
+		), 10)
+	}
+
+	return posts
+}
+
+// BenchmarkSavePosts compares storing the posts one by one with storing them in bulk.
+// The database to be used must be set using the ATHENA_BENCHMARK_DATABASE_URL environment variable, and all its
+// subspaces are removed while running the benchmark.
+func BenchmarkSavePosts(b *testing.B) {
+	databaseURL := os.Getenv("ATHENA_BENCHMARK_DATABASE_URL")
+	if databaseURL == "" {
+		b.Skip("ATHENA_BENCHMARK_DATABASE_URL is not set")
+	}
+
+	encodingConfig := app.MakeEncodingConfig()
+	databaseConfig := junodbcfg.DefaultDatabaseConfig().WithURL(databaseURL)
+
+	junoDb, err := database.Builder(junodb.NewContext(databaseConfig, params.EncodingConfig(encodingConfig), logging.DefaultLogger()))
+	require.NoError(b, err)
+
+	db := junoDb.(*database.Db)
+	_, err = db.MigrateUp("")
+	require.NoError(b, err)
+
+	reset := func() {
+		_, err := db.SQL.Exec(`TRUNCATE TABLE subspace CASCADE`)
+		require.NoError(b, err)
+	}
+
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			reset()
+			posts := setupBulkData(b, db, 2000)
+			b.StartTimer()
+
+			for _, post := range posts {
+				require.NoError(b, db.SavePost(post))
+			}
+		}
+		b.ReportMetric(float64(2000*b.N)/b.Elapsed().Seconds(), "posts/s")
+	})
+
+	b.Run("bulk", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			reset()
+			posts := setupBulkData(b, db, 2000)
+			b.StartTimer()
+
+			require.NoError(b, db.SavePosts(posts))
+		}
+		b.ReportMetric(float64(2000*b.N)/b.Elapsed().Seconds(), "posts/s")
+	})
+
+	reset()
+}
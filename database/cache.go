@@ -0,0 +1,207 @@
+package database
+
+import (
+	"time"
+
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/x/apis/cache"
+	"github.com/desmos-labs/athena/v2/x/posts"
+	"github.com/desmos-labs/athena/v2/x/profiles"
+	"github.com/desmos-labs/athena/v2/x/subspaces"
+)
+
+var (
+	_ Database = &CacheInvalidatingDb{}
+)
+
+// CacheInvalidatingDb wraps a Database invalidating the cached API responses that depend on the
+// posts, profiles, relationships, blocks and reports rows as soon as they are written
+type CacheInvalidatingDb struct {
+	Database
+	cache cache.Invalidator
+}
+
+// NewCacheInvalidatingDb returns a new CacheInvalidatingDb instance
+func NewCacheInvalidatingDb(db Database, cache cache.Invalidator) *CacheInvalidatingDb {
+	return &CacheInvalidatingDb{
+		Database: db,
+		cache:    cache,
+	}
+}
+
+// invalidate invalidates the given tags if the given write error is nil, and returns the error
+func (db *CacheInvalidatingDb) invalidate(err error, tags ...string) error {
+	if err == nil {
+		db.cache.Invalidate(tags...)
+	}
+	return err
+}
+
+// InTransaction implements Database.
+// The tags written inside the transaction are invalidated only once it has been committed.
+func (db *CacheInvalidatingDb) InTransaction(fn func(txDb Database) error) error {
+	collector := &tagsCollector{}
+	err := db.Database.InTransaction(func(txDb Database) error {
+		return fn(NewCacheInvalidatingDb(txDb, collector))
+	})
+	return db.invalidate(err, collector.tags...)
+}
+
+// InPostsTransaction implements posts.Database
+func (db *CacheInvalidatingDb) InPostsTransaction(fn func(txDb posts.Database) error) error {
+	return db.InTransaction(func(txDb Database) error {
+		return fn(txDb)
+	})
+}
+
+// InProfilesTransaction implements profiles.Database
+func (db *CacheInvalidatingDb) InProfilesTransaction(fn func(txDb profiles.Database) error) error {
+	return db.InTransaction(func(txDb Database) error {
+		return fn(txDb)
+	})
+}
+
+// InSubspacesTransaction implements subspaces.Database
+func (db *CacheInvalidatingDb) InSubspacesTransaction(fn func(txDb subspaces.Database) error) error {
+	return db.InTransaction(func(txDb Database) error {
+		return fn(txDb)
+	})
+}
+
+// tagsCollector collects the tags to be invalidated once a transaction has been committed
+type tagsCollector struct {
+	tags []string
+}
+
+// Invalidate implements cache.Invalidator
+func (c *tagsCollector) Invalidate(tags ...string) {
+	c.tags = append(c.tags, tags...)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// SavePost implements posts.Database
+func (db *CacheInvalidatingDb) SavePost(post types.Post) error {
+	return db.invalidate(db.Database.SavePost(post),
+		cache.SubspacePostsTag(post.SubspaceID))
+}
+
+// SavePosts implements posts.Database
+func (db *CacheInvalidatingDb) SavePosts(posts []types.Post) error {
+	var tags []string
+	for _, post := range posts {
+		tags = append(tags, cache.SubspacePostsTag(post.SubspaceID))
+	}
+	return db.invalidate(db.Database.SavePosts(posts), tags...)
+}
+
+// DeletePost implements posts.Database
+func (db *CacheInvalidatingDb) DeletePost(height int64, subspaceID uint64, postID uint64) error {
+	return db.invalidate(db.Database.DeletePost(height, subspaceID, postID),
+		cache.SubspacePostsTag(subspaceID))
+}
+
+// DeleteAllPosts implements posts.Database
+func (db *CacheInvalidatingDb) DeleteAllPosts(height int64, subspaceID uint64) error {
+	return db.invalidate(db.Database.DeleteAllPosts(height, subspaceID), cache.SubspacePostsTag(subspaceID))
+}
+
+// TombstonePost implements posts.Database
+func (db *CacheInvalidatingDb) TombstonePost(height int64, subspaceID uint64, postID uint64, txHash string, timestamp time.Time) error {
+	return db.invalidate(db.Database.TombstonePost(height, subspaceID, postID, txHash, timestamp),
+		cache.SubspacePostsTag(subspaceID))
+}
+
+// TombstoneAllPosts implements posts.Database
+func (db *CacheInvalidatingDb) TombstoneAllPosts(height int64, subspaceID uint64, timestamp time.Time) error {
+	return db.invalidate(db.Database.TombstoneAllPosts(height, subspaceID, timestamp), cache.SubspacePostsTag(subspaceID))
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// SaveProfile implements profiles.Database
+func (db *CacheInvalidatingDb) SaveProfile(profile *types.Profile) error {
+	return db.invalidate(db.Database.SaveProfile(profile),
+		cache.ProfilesListTag)
+}
+
+// SaveProfiles implements profiles.Database
+func (db *CacheInvalidatingDb) SaveProfiles(profiles []*types.Profile) error {
+	return db.invalidate(db.Database.SaveProfiles(profiles), cache.ProfilesListTag)
+}
+
+// DeleteProfile implements profiles.Database
+func (db *CacheInvalidatingDb) DeleteProfile(address string, height int64) error {
+	return db.invalidate(db.Database.DeleteProfile(address, height), cache.ProfilesListTag)
+}
+
+// TombstoneProfile implements profiles.Database
+func (db *CacheInvalidatingDb) TombstoneProfile(address string, height int64, txHash string, timestamp time.Time) error {
+	return db.invalidate(db.Database.TombstoneProfile(address, height, txHash, timestamp),
+		cache.ProfilesListTag)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// SaveRelationship implements relationships.Database
+func (db *CacheInvalidatingDb) SaveRelationship(relationship types.Relationship) error {
+	return db.invalidate(db.Database.SaveRelationship(relationship),
+		cache.FeedTag(relationship.SubspaceID, relationship.Creator))
+}
+
+// DeleteRelationship implements relationships.Database
+func (db *CacheInvalidatingDb) DeleteRelationship(relationship types.Relationship) error {
+	return db.invalidate(db.Database.DeleteRelationship(relationship),
+		cache.FeedTag(relationship.SubspaceID, relationship.Creator))
+}
+
+// DeleteAllRelationships implements relationships.Database
+func (db *CacheInvalidatingDb) DeleteAllRelationships(height int64, subspaceID uint64) error {
+	return db.invalidate(db.Database.DeleteAllRelationships(height, subspaceID), cache.SubspaceFeedsTag(subspaceID))
+}
+
+// DeleteUserRelationships implements relationships.Database
+func (db *CacheInvalidatingDb) DeleteUserRelationships(height int64, subspaceID uint64, user string) error {
+	return db.invalidate(db.Database.DeleteUserRelationships(height, subspaceID, user), cache.FeedTag(subspaceID, user))
+}
+
+// SaveUserBlock implements relationships.Database
+func (db *CacheInvalidatingDb) SaveUserBlock(block types.Blockage) error {
+	return db.invalidate(db.Database.SaveUserBlock(block),
+		cache.FeedTag(block.SubspaceID, block.Blocker), cache.FeedTag(block.SubspaceID, block.Blocked))
+}
+
+// DeleteBlockage implements relationships.Database
+func (db *CacheInvalidatingDb) DeleteBlockage(block types.Blockage) error {
+	return db.invalidate(db.Database.DeleteBlockage(block),
+		cache.FeedTag(block.SubspaceID, block.Blocker), cache.FeedTag(block.SubspaceID, block.Blocked))
+}
+
+// DeleteAllUserBlocks implements relationships.Database
+func (db *CacheInvalidatingDb) DeleteAllUserBlocks(height int64, subspaceID uint64) error {
+	return db.invalidate(db.Database.DeleteAllUserBlocks(height, subspaceID), cache.SubspaceFeedsTag(subspaceID))
+}
+
+// DeleteUserBlocks implements relationships.Database.
+// The feeds of the whole subspace are invalidated since the blocked users are not known.
+func (db *CacheInvalidatingDb) DeleteUserBlocks(height int64, subspaceID uint64, blocker string) error {
+	return db.invalidate(db.Database.DeleteUserBlocks(height, subspaceID, blocker), cache.SubspaceFeedsTag(subspaceID))
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// SaveReport implements reports.Database
+func (db *CacheInvalidatingDb) SaveReport(report types.Report) error {
+	return db.invalidate(db.Database.SaveReport(report), cache.FeedTag(report.SubspaceID, report.Reporter))
+}
+
+// DeleteReport implements reports.Database.
+// The feeds of the whole subspace are invalidated since the reporter is not known.
+func (db *CacheInvalidatingDb) DeleteReport(height int64, subspaceID uint64, reportID uint64) error {
+	return db.invalidate(db.Database.DeleteReport(height, subspaceID, reportID), cache.SubspaceFeedsTag(subspaceID))
+}
+
+// DeleteAllReports implements reports.Database
+func (db *CacheInvalidatingDb) DeleteAllReports(height int64, subspaceID uint64) error {
+	return db.invalidate(db.Database.DeleteAllReports(height, subspaceID), cache.SubspaceFeedsTag(subspaceID))
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"strconv"
+)
+
+// setChangeHeight sets the height at which the rows deleted by the given transaction are recorded inside
+// the change journal, so that they can be restored when rolling back to a lower height
+func setChangeHeight(tx sqlTx, height int64) error {
+	_, err := tx.Exec(`SELECT set_config('athena.change_height', $1, true)`, strconv.FormatInt(height, 10))
+	return err
+}
+
+// execDelete runs the given DELETE statement inside a transaction, recording the deleted rows inside the
+// change journal at the given height
+func (db *Db) execDelete(height int64, stmt string, args ...interface{}) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PruneChangeJournal removes the change journal entries recorded more than the given number of heights before the
+// latest stored block, after which the database can no longer be rolled back below such heights
+func (db *Db) PruneChangeJournal(retention int64) error {
+	stmt := `
+DELETE FROM change_journal
+WHERE height <= (SELECT COALESCE(MAX(height), 0) FROM block) - $1`
+	_, err := db.conn().Exec(stmt, retention)
+	return err
+}
+
+// RollbackToHeight removes all the data stored above the given height, restoring the rows that have been
+// changed or deleted above it using the change journal. The blocks above the given height are removed as well,
+// so that they can be parsed again.
+func (db *Db) RollbackToHeight(height int64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`SELECT rollback_to_height($1)`, height)
+	if err != nil {
+		return err
+	}
+
+	// The subspace history is append-only, so it is rolled back separately
+	_, err = tx.Exec(`SELECT rollback_subspace_history($1)`, height)
+	if err != nil {
+		return err
+	}
+
+	// The counters are not journaled, so they need to be computed again
+	err = updatePostsCounters(tx, allPostsCondition)
+	if err != nil {
+		return err
+	}
+
+	err = updateProfilesCounters(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// updateProfilesCounters computes again the counters of all the profiles
+func updateProfilesCounters(tx sqlTx) error {
+	stmt := `
+INSERT INTO profile_counters (profile_address, relationships_count, blocks_count, chain_links_count, application_links_count)
+SELECT address,
+       (SELECT COUNT(*) FROM user_relationship WHERE creator_address = address),
+       (SELECT COUNT(*) FROM user_block WHERE blocker_address = address),
+       (SELECT COUNT(*) FROM chain_link WHERE user_address = address),
+       (SELECT COUNT(*) FROM application_link WHERE user_address = address)
+FROM (SELECT profile_address AS address FROM profile_counters
+      UNION SELECT creator_address FROM user_relationship
+      UNION SELECT blocker_address FROM user_block
+      UNION SELECT user_address FROM chain_link
+      UNION SELECT user_address FROM application_link) AS addresses
+WHERE address IS NOT NULL
+ON CONFLICT (profile_address) DO UPDATE
+    SET relationships_count = excluded.relationships_count,
+        blocks_count = excluded.blocks_count,
+        chain_links_count = excluded.chain_links_count,
+        application_links_count = excluded.application_links_count`
+	_, err := tx.Exec(stmt)
+	return err
+}
@@ -0,0 +1,112 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestRollbackToHeight() {
+	owner := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	buildSubspace := func(id uint64, name string, height int64) types.Subspace {
+		return types.NewSubspace(subspacestypes.NewSubspace(
+			id,
+			name,
+			"",
+			"",
+			owner,
+			owner,
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+		), height)
+	}
+
+	// Store a subspace, then update it and delete it above the rollback height
+	err := suite.database.SaveSubspace(buildSubspace(1, "Original name", 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSubspace(buildSubspace(1, "Edited name", 20))
+	suite.Require().NoError(err)
+
+	err = suite.database.DeleteSubspace(30, 1)
+	suite.Require().NoError(err)
+
+	// Store a subspace above the rollback height
+	err = suite.database.SaveSubspace(buildSubspace(2, "New subspace", 25))
+	suite.Require().NoError(err)
+
+	err = suite.database.RollbackToHeight(15)
+	suite.Require().NoError(err)
+
+	var rows []struct {
+		ID     uint64 `db:"id"`
+		Name   string `db:"name"`
+		Height int64  `db:"height"`
+	}
+	err = suite.database.SQL.Select(&rows, `SELECT id, name, height FROM subspace ORDER BY id`)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 1)
+	suite.Require().Equal(uint64(1), rows[0].ID)
+	suite.Require().Equal("Original name", rows[0].Name)
+	suite.Require().Equal(int64(10), rows[0].Height)
+
+	var journalEntries int
+	err = suite.database.SQL.Get(&journalEntries, `SELECT COUNT(*) FROM change_journal`)
+	suite.Require().NoError(err)
+	suite.Require().Zero(journalEntries)
+}
+
+func (suite *DbTestSuite) TestRollbackToHeight_KeepsBookkeepingTables() {
+	_, err := suite.database.SQL.Exec(`INSERT INTO failed_height (height, module, error) VALUES (20, 'posts', 'error')`)
+	suite.Require().NoError(err)
+
+	_, err = suite.database.SQL.Exec(`
+INSERT INTO failed_message (height, tx_hash, msg_index, module, msg_type, error) 
+VALUES (20, 'TX_HASH', 0, 'posts', 'MsgCreatePost', 'error')`)
+	suite.Require().NoError(err)
+
+	_, err = suite.database.SQL.Exec(`INSERT INTO refresh_checkpoint (module, height) VALUES ('posts', 20)`)
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveGapsCheckpoint(20)
+	suite.Require().NoError(err)
+
+	err = suite.database.RollbackToHeight(10)
+	suite.Require().NoError(err)
+
+	for _, table := range []string{"failed_height", "failed_message", "refresh_checkpoint"} {
+		var count int
+		err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM `+table)
+		suite.Require().NoError(err)
+		suite.Require().Equal(1, count, table)
+	}
+
+	// The gaps checkpoint should be lowered to the rollback height
+	checkpoint, err := suite.database.GetGapsCheckpoint()
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(10), checkpoint)
+}
+
+func (suite *DbTestSuite) TestPruneChangeJournal() {
+	_, err := suite.database.SQL.Exec(`INSERT INTO block (height, hash, timestamp) VALUES (100, 'hash', NOW())`)
+	suite.Require().NoError(err)
+
+	_, err = suite.database.SQL.Exec(`
+INSERT INTO change_journal (table_name, operation, height, row_key, row_data) 
+VALUES ('subspace', 'DELETE', 50, '{}', '{}'),
+       ('subspace', 'DELETE', 90, '{}', '{}'),
+       ('subspace', 'DELETE', 95, '{}', '{}')`)
+	suite.Require().NoError(err)
+
+	// Only the entries recorded before the latest 10 heights should be removed
+	err = suite.database.PruneChangeJournal(10)
+	suite.Require().NoError(err)
+
+	var heights []int64
+	err = suite.database.SQL.Select(&heights, `SELECT height FROM change_journal ORDER BY height`)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]int64{95}, heights)
+}
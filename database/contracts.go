@@ -22,7 +22,7 @@ ON CONFLICT (address) DO UPDATE
         config = excluded.config,
         height = excluded.height
 WHERE contract.height <= excluded.height`
-	_, err := db.SQL.Exec(stmt, contract.Address, contract.Type, string(contract.ConfigBz), contract.Height)
+	_, err := db.conn().Exec(stmt, contract.Address, contract.Type, string(contract.ConfigBz), contract.Height)
 	return err
 }
 
@@ -37,7 +37,7 @@ type contractRow struct {
 func (db *Db) GetContract(address string) (*types.Contract, error) {
 	var rows []contractRow
 	stmt := `SELECT * FROM contract WHERE address = $1`
-	err := db.SQL.Select(&rows, stmt, address)
+	err := db.conn().Select(&rows, stmt, address)
 	if err != nil {
 		return nil, err
 	}
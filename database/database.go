@@ -2,16 +2,24 @@ package database
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	junodb "github.com/forbole/juno/v5/database"
 	"github.com/forbole/juno/v5/database/postgresql"
 	juno "github.com/forbole/juno/v5/types"
+	"github.com/jmoiron/sqlx"
 
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/x/apis/endpoints/feed"
+	"github.com/desmos-labs/athena/v2/x/apis/endpoints/search"
 	"github.com/desmos-labs/athena/v2/x/authz"
 	contracts "github.com/desmos-labs/athena/v2/x/contracts/base"
 	"github.com/desmos-labs/athena/v2/x/contracts/tips"
+	"github.com/desmos-labs/athena/v2/x/failures"
 	"github.com/desmos-labs/athena/v2/x/feegrant"
+	"github.com/desmos-labs/athena/v2/x/gaps"
+	"github.com/desmos-labs/athena/v2/x/journal"
 	"github.com/desmos-labs/athena/v2/x/notifications"
 	"github.com/desmos-labs/athena/v2/x/posts"
 	"github.com/desmos-labs/athena/v2/x/profiles"
@@ -20,6 +28,7 @@ import (
 	"github.com/desmos-labs/athena/v2/x/relationships"
 	"github.com/desmos-labs/athena/v2/x/reports"
 	"github.com/desmos-labs/athena/v2/x/subspaces"
+	"github.com/desmos-labs/athena/v2/x/tombstones"
 )
 
 type Database interface {
@@ -28,7 +37,11 @@ type Database interface {
 	authz.Database
 	contracts.Database
 	tips.Database
+	feed.Database
+	failures.Database
 	feegrant.Database
+	gaps.Database
+	journal.Database
 	notifications.Database
 	posts.Database
 	profiles.Database
@@ -36,7 +49,18 @@ type Database interface {
 	reactions.Database
 	relationships.Database
 	reports.Database
+	search.Database
 	subspaces.Database
+	tombstones.Database
+
+	// InTransaction runs the given function passing it a copy of the database whose writes are all performed
+	// inside a single transaction. The transaction is committed if the function returns no error, and rolled
+	// back otherwise. Units of work started from a transaction-scoped database are part of its transaction.
+	InTransaction(fn func(txDb Database) error) error
+
+	SaveRefreshCheckpoint(checkpoint types.RefreshCheckpoint) error
+	GetRefreshCheckpoints(modules []string) ([]types.RefreshCheckpoint, error)
+	DeleteRefreshCheckpoints(modules []string) error
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -50,6 +74,13 @@ var (
 type Db struct {
 	cdc codec.Codec
 	*postgresql.Database
+
+	closeHooksMu sync.Mutex
+	closeHooks   []func()
+
+	// tx is the transaction of the unit of work this database is scoped to, if any
+	tx         *sqlx.Tx
+	savepoints *int
 }
 
 // Builder allows to create a new Db instance implementing the database.Builder type
@@ -79,6 +110,28 @@ func Cast(database junodb.Database) Database {
 	return desmosDb
 }
 
+// RegisterCloseHook registers a function that will be called before closing the database connection.
+// This allows the services that depend on the database to be stopped before it is closed.
+func (db *Db) RegisterCloseHook(hook func()) {
+	db.closeHooksMu.Lock()
+	defer db.closeHooksMu.Unlock()
+	db.closeHooks = append(db.closeHooks, hook)
+}
+
+// Close overrides postgresql.Database to run all the registered close hooks before closing the connection
+func (db *Db) Close() {
+	db.closeHooksMu.Lock()
+	hooks := db.closeHooks
+	db.closeHooks = nil
+	db.closeHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+
+	db.Database.Close()
+}
+
 // --------------------------------------------------------------------------------------------------------------------
 
 // SaveTx overrides postgresql.Database to perform a no-op
@@ -2,10 +2,6 @@ package database_test
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
 	"testing"
 	"time"
 
@@ -55,23 +51,9 @@ func (suite *DbTestSuite) SetupSuite() {
 	_, err = desmosDb.SQL.Exec(fmt.Sprintf(`CREATE SCHEMA %s;`, databaseConfig.GetSchema()))
 	suite.Require().NoError(err)
 
-	dirPath := "schema"
-	dir, err := os.ReadDir(dirPath)
-	for _, fileInfo := range dir {
-		if !strings.HasSuffix(fileInfo.Name(), ".sql") {
-			continue
-		}
-
-		file, err := os.ReadFile(filepath.Join(dirPath, fileInfo.Name()))
-		suite.Require().NoError(err)
-
-		commentsRegExp := regexp.MustCompile(`/\*.*\*/`)
-		requests := strings.Split(string(file), ";")
-		for _, request := range requests {
-			_, err := desmosDb.SQL.Exec(commentsRegExp.ReplaceAllString(request, ""))
-			suite.Require().NoError(err)
-		}
-	}
+	// Apply all the migrations
+	_, err = desmosDb.MigrateUp("")
+	suite.Require().NoError(err)
 
 	// Create the truncate function
 	stmt := fmt.Sprintf(`
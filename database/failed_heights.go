@@ -0,0 +1,68 @@
+package database
+
+import (
+	"time"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// SaveFailedHeight stores the given failed height, updating its error if it already exists
+func (db *Db) SaveFailedHeight(failedHeight types.FailedHeight) error {
+	stmt := `
+INSERT INTO failed_height (height, module, error, failed_at) 
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (height, module) DO UPDATE 
+    SET error = excluded.error,
+        failed_at = excluded.failed_at`
+	_, err := db.conn().Exec(stmt,
+		failedHeight.Height, failedHeight.Module, failedHeight.Error, failedHeight.FailedAt)
+	return err
+}
+
+// GetFailedHeights returns all the stored failed heights, sorted by height
+func (db *Db) GetFailedHeights() ([]types.FailedHeight, error) {
+	stmt := `SELECT height, module, error, failed_at FROM failed_height ORDER BY height, module`
+
+	var rows []struct {
+		Height   int64     `db:"height"`
+		Module   string    `db:"module"`
+		Error    string    `db:"error"`
+		FailedAt time.Time `db:"failed_at"`
+	}
+	err := db.conn().Select(&rows, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	failedHeights := make([]types.FailedHeight, len(rows))
+	for i, row := range rows {
+		failedHeights[i] = types.NewFailedHeight(row.Height, row.Module, row.Error, row.FailedAt.UTC())
+	}
+
+	return failedHeights, nil
+}
+
+// DeleteFailedHeight removes the failed height having the given height and module
+func (db *Db) DeleteFailedHeight(height int64, module string) error {
+	_, err := db.conn().Exec(`DELETE FROM failed_height WHERE height = $1 AND module = $2`, height, module)
+	return err
+}
+
+// SaveGapsCheckpoint stores the height up to which the missing blocks have been searched
+func (db *Db) SaveGapsCheckpoint(height int64) error {
+	stmt := `
+INSERT INTO gaps_checkpoint (height) 
+VALUES ($1)
+ON CONFLICT (one_row_id) DO UPDATE 
+    SET height = excluded.height`
+	_, err := db.conn().Exec(stmt, height)
+	return err
+}
+
+// GetGapsCheckpoint returns the height up to which the missing blocks have been searched, or 0 if they have
+// never been searched
+func (db *Db) GetGapsCheckpoint() (int64, error) {
+	var height int64
+	err := db.conn().Get(&height, `SELECT COALESCE(MAX(height), 0) FROM gaps_checkpoint`)
+	return height, err
+}
@@ -0,0 +1,36 @@
+package database_test
+
+import (
+	"time"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestFailedHeights() {
+	failedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := suite.database.SaveFailedHeight(types.NewFailedHeight(20, "posts", "first error", failedAt))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveFailedHeight(types.NewFailedHeight(10, types.MissingBlockModule, "missing block", failedAt))
+	suite.Require().NoError(err)
+
+	// Store the same height again, making sure its error is updated
+	err = suite.database.SaveFailedHeight(types.NewFailedHeight(20, "posts", "second error", failedAt.Add(time.Hour)))
+	suite.Require().NoError(err)
+
+	failedHeights, err := suite.database.GetFailedHeights()
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.FailedHeight{
+		types.NewFailedHeight(10, types.MissingBlockModule, "missing block", failedAt),
+		types.NewFailedHeight(20, "posts", "second error", failedAt.Add(time.Hour)),
+	}, failedHeights)
+
+	err = suite.database.DeleteFailedHeight(10, types.MissingBlockModule)
+	suite.Require().NoError(err)
+
+	failedHeights, err = suite.database.GetFailedHeights()
+	suite.Require().NoError(err)
+	suite.Require().Len(failedHeights, 1)
+	suite.Require().Equal(int64(20), failedHeights[0].Height)
+}
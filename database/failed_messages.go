@@ -0,0 +1,67 @@
+package database
+
+import (
+	"time"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// SaveFailedMessage stores the given failed message. If the message has already failed before,
+// its error is updated and its retry count is incremented.
+func (db *Db) SaveFailedMessage(message types.FailedMessage) error {
+	stmt := `
+INSERT INTO failed_message (height, tx_hash, msg_index, authz_index, module, msg_type, error, retry_count, failed_at) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, 0, $8)
+ON CONFLICT (height, tx_hash, msg_index, authz_index, module) DO UPDATE 
+    SET error = excluded.error,
+        retry_count = failed_message.retry_count + 1,
+        failed_at = excluded.failed_at`
+	_, err := db.conn().Exec(stmt,
+		message.Height, message.TxHash, message.MsgIndex, message.AuthzIndex, message.Module, message.MsgType,
+		message.Error, message.FailedAt,
+	)
+	return err
+}
+
+// GetFailedMessages returns all the stored failed messages, sorted by height and position inside the block
+func (db *Db) GetFailedMessages() ([]types.FailedMessage, error) {
+	stmt := `
+SELECT height, tx_hash, msg_index, authz_index, module, msg_type, error, retry_count, failed_at 
+FROM failed_message 
+ORDER BY height, tx_hash, msg_index, authz_index, module`
+
+	var rows []struct {
+		Height     int64     `db:"height"`
+		TxHash     string    `db:"tx_hash"`
+		MsgIndex   int       `db:"msg_index"`
+		AuthzIndex int       `db:"authz_index"`
+		Module     string    `db:"module"`
+		MsgType    string    `db:"msg_type"`
+		Error      string    `db:"error"`
+		RetryCount int       `db:"retry_count"`
+		FailedAt   time.Time `db:"failed_at"`
+	}
+	err := db.conn().Select(&rows, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]types.FailedMessage, len(rows))
+	for i, row := range rows {
+		messages[i] = types.NewFailedMessage(
+			row.Height, row.TxHash, row.MsgIndex, row.AuthzIndex, row.Module, row.MsgType,
+			row.Error, row.RetryCount, row.FailedAt.UTC(),
+		)
+	}
+
+	return messages, nil
+}
+
+// DeleteFailedMessage removes the given failed message
+func (db *Db) DeleteFailedMessage(message types.FailedMessage) error {
+	stmt := `
+DELETE FROM failed_message 
+WHERE height = $1 AND tx_hash = $2 AND msg_index = $3 AND authz_index = $4 AND module = $5`
+	_, err := db.conn().Exec(stmt, message.Height, message.TxHash, message.MsgIndex, message.AuthzIndex, message.Module)
+	return err
+}
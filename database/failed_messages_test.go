@@ -0,0 +1,39 @@
+package database_test
+
+import (
+	"time"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestFailedMessages() {
+	failedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	message := types.NewFailedMessage(
+		10, "TX_HASH", 1, types.NoAuthzIndex, "posts", "/desmos.posts.v3.MsgEditPost", "post not found", 0, failedAt,
+	)
+
+	err := suite.database.SaveFailedMessage(message)
+	suite.Require().NoError(err)
+
+	// Store the same message again, making sure the retry count is incremented
+	message.Error = "grpc error"
+	message.FailedAt = failedAt.Add(time.Hour)
+	err = suite.database.SaveFailedMessage(message)
+	suite.Require().NoError(err)
+
+	messages, err := suite.database.GetFailedMessages()
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.FailedMessage{
+		types.NewFailedMessage(
+			10, "TX_HASH", 1, types.NoAuthzIndex, "posts", "/desmos.posts.v3.MsgEditPost", "grpc error", 1,
+			failedAt.Add(time.Hour),
+		),
+	}, messages)
+
+	err = suite.database.DeleteFailedMessage(message)
+	suite.Require().NoError(err)
+
+	messages, err = suite.database.GetFailedMessages()
+	suite.Require().NoError(err)
+	suite.Require().Empty(messages)
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"github.com/lib/pq"
+
+	dbtypes "github.com/desmos-labs/athena/v2/database/types"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// hiddenPostCondition contains the SQL condition that tells whether the post aliased as "hidden" should not be
+// shown to the user having the address given as $2, either because it has been deleted, because its author
+// is blocked or because the post or its author have been reported by the user
+const hiddenPostCondition = `
+(
+    hidden.deletion_height IS NOT NULL OR EXISTS(
+        SELECT 1 FROM user_block
+        WHERE user_block.subspace_id = hidden.subspace_id
+          AND ((user_block.blocker_address = $2 AND user_block.blocked_address = hidden.author_address)
+            OR (user_block.blocker_address = hidden.author_address AND user_block.blocked_address = $2))
+    ) OR EXISTS(
+        SELECT 1 FROM report
+        WHERE report.subspace_id = hidden.subspace_id
+          AND report.reporter_address = $2
+          AND ((report.target ->> '@type' LIKE '%PostTarget' AND report.target ->> 'post_id' = hidden.id::TEXT)
+            OR (report.target ->> '@type' LIKE '%UserTarget' AND report.target ->> 'user' = hidden.author_address))
+    )
+)`
+
+// GetHomeFeed returns the posts that should be displayed inside the home feed of the user having the given address
+// within the given subspace. The feed contains the top-level posts, reposts and quotes created by the users
+// that are followed by the user, excluding blocked users and reported content.
+// The posts are sorted by creation date in descending order, and returned starting after the given cursor, if any.
+func (db *Db) GetHomeFeed(subspaceID uint64, userAddress string, cursor *types.FeedCursor, limit uint64) ([]types.FeedPost, error) {
+	stmt := `
+SELECT post.row_id, post.subspace_id, post.id, post.author_address, post.text, post.creation_date, post.last_edited_date
+FROM post
+         JOIN user_relationship
+              ON user_relationship.subspace_id = post.subspace_id
+                  AND user_relationship.creator_address = $2
+                  AND user_relationship.counterparty_address = post.author_address
+WHERE post.subspace_id = $1
+  AND post.conversation_row_id IS NULL
+  AND NOT EXISTS(
+        SELECT 1 FROM post_reference
+        WHERE post_reference.post_row_id = post.row_id
+          AND post_reference.type = 'POST_REFERENCE_TYPE_REPLY'
+    )
+  AND NOT EXISTS(SELECT 1 FROM post AS hidden WHERE hidden.row_id = post.row_id AND ` + hiddenPostCondition + `)
+  AND NOT EXISTS(
+        SELECT 1 FROM post_reference
+                 JOIN post AS hidden ON hidden.row_id = post_reference.reference_row_id
+        WHERE post_reference.post_row_id = post.row_id AND ` + hiddenPostCondition + `
+    )
+  AND ($3::TIMESTAMP IS NULL OR (post.creation_date, post.row_id) < ($3::TIMESTAMP, $4::BIGINT))
+ORDER BY post.creation_date DESC, post.row_id DESC
+LIMIT $5`
+
+	var cursorDate, cursorRowID interface{}
+	if cursor != nil {
+		cursorDate, cursorRowID = cursor.CreationDate, cursor.RowID
+	}
+
+	var rows []dbtypes.FeedPostRow
+	err := db.conn().Select(&rows, stmt, subspaceID, userAddress, cursorDate, cursorRowID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	rowIDs := make([]int64, len(rows))
+	for i, row := range rows {
+		rowIDs[i] = int64(row.RowID)
+	}
+
+	references, err := db.getFeedPostsReferences(rowIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]types.FeedPost, len(rows))
+	for i, row := range rows {
+		post := types.FeedPost{
+			SubspaceID:   row.SubspaceID,
+			ID:           row.ID,
+			Author:       row.AuthorAddress,
+			Text:         row.Text.String,
+			CreationDate: row.CreationDate,
+			References:   references[row.RowID],
+			Cursor:       types.NewFeedCursor(row.CreationDate, row.RowID),
+		}
+		if row.LastEditedDate.Valid {
+			post.LastEditedDate = &row.LastEditedDate.Time
+		}
+		posts[i] = post
+	}
+
+	return posts, nil
+}
+
+// getFeedPostsReferences returns the reposted and quoted posts of the posts having the given row ids,
+// indexed by the row id of the referencing post
+func (db *Db) getFeedPostsReferences(postRowIDs []int64) (map[uint64][]types.FeedPostReference, error) {
+	stmt := `
+SELECT post_reference.post_row_id,
+       post_reference.type,
+       post.subspace_id,
+       post.id,
+       post.author_address,
+       post.text,
+       post.creation_date
+FROM post_reference
+         JOIN post ON post.row_id = post_reference.reference_row_id
+WHERE post_reference.post_row_id = ANY ($1)
+  AND post_reference.type IN ('POST_REFERENCE_TYPE_REPOST', 'POST_REFERENCE_TYPE_QUOTE')
+ORDER BY post_reference.post_row_id, post_reference.position_index NULLS LAST, post_reference.row_id`
+
+	var rows []dbtypes.FeedPostReferenceRow
+	err := db.conn().Select(&rows, stmt, pq.Array(postRowIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	references := map[uint64][]types.FeedPostReference{}
+	for _, row := range rows {
+		references[row.PostRowID] = append(references[row.PostRowID], types.FeedPostReference{
+			Type:         row.Type,
+			SubspaceID:   row.SubspaceID,
+			PostID:       row.ID,
+			Author:       row.AuthorAddress,
+			Text:         row.Text.String,
+			CreationDate: row.CreationDate,
+		})
+	}
+
+	return references, nil
+}
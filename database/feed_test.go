@@ -0,0 +1,115 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	relationshipstypes "github.com/desmos-labs/desmos/v7/x/relationships/types"
+	reportstypes "github.com/desmos-labs/desmos/v7/x/reports/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+const (
+	feedUser     = "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	feedFollowed = "cosmos1u0gz4g865yjadxm2hsst388c462agdz7araedr"
+	feedBlocked  = "cosmos1xw69y2z3yf00rgfnly99628gn5c0x7fryyfv5e"
+)
+
+func (suite *DbTestSuite) setupFeed() {
+	err := suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		feedUser,
+		feedUser,
+		time.Now(),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+	suite.Require().NoError(err)
+
+	for _, followed := range []string{feedFollowed, feedBlocked} {
+		err = suite.database.SaveRelationship(types.NewRelationship(
+			relationshipstypes.NewRelationship(feedUser, followed, 1),
+			1,
+		))
+		suite.Require().NoError(err)
+	}
+
+	err = suite.database.SaveUserBlock(types.NewBlockage(
+		relationshipstypes.NewUserBlock(feedBlocked, feedUser, "", 1),
+		1,
+	))
+	suite.Require().NoError(err)
+
+	baseTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	posts := []poststypes.Post{
+		// Post from a blocked user
+		poststypes.NewPost(1, 0, 1, "", "Blocked post", feedBlocked, 0, nil, nil, nil,
+			poststypes.REPLY_SETTING_EVERYONE, baseTime, nil, feedBlocked),
+
+		// Post from a followed user
+		poststypes.NewPost(1, 0, 2, "", "First post", feedFollowed, 0, nil, nil, nil,
+			poststypes.REPLY_SETTING_EVERYONE, baseTime.Add(time.Minute), nil, feedFollowed),
+
+		// Reply from a followed user
+		poststypes.NewPost(1, 0, 3, "", "Reply", feedFollowed, 2, nil, nil,
+			[]poststypes.PostReference{poststypes.NewPostReference(poststypes.POST_REFERENCE_TYPE_REPLY, 2, 0)},
+			poststypes.REPLY_SETTING_EVERYONE, baseTime.Add(2*time.Minute), nil, feedFollowed),
+
+		// Quote of a blocked user post
+		poststypes.NewPost(1, 0, 4, "", "Quote of blocked", feedFollowed, 0, nil, nil,
+			[]poststypes.PostReference{poststypes.NewPostReference(poststypes.POST_REFERENCE_TYPE_QUOTE, 1, 0)},
+			poststypes.REPLY_SETTING_EVERYONE, baseTime.Add(3*time.Minute), nil, feedFollowed),
+
+		// Reported post
+		poststypes.NewPost(1, 0, 5, "", "Reported post", feedFollowed, 0, nil, nil, nil,
+			poststypes.REPLY_SETTING_EVERYONE, baseTime.Add(4*time.Minute), nil, feedFollowed),
+
+		// Repost of a followed user post
+		poststypes.NewPost(1, 0, 6, "", "", feedFollowed, 0, nil, nil,
+			[]poststypes.PostReference{poststypes.NewPostReference(poststypes.POST_REFERENCE_TYPE_REPOST, 2, 0)},
+			poststypes.REPLY_SETTING_EVERYONE, baseTime.Add(5*time.Minute), nil, feedFollowed),
+	}
+	for _, post := range posts {
+		err = suite.database.SavePost(types.NewPost(post, 1))
+		suite.Require().NoError(err)
+	}
+
+	err = suite.database.SaveReport(types.NewReport(reportstypes.NewReport(
+		1,
+		1,
+		nil,
+		"",
+		reportstypes.NewPostTarget(5),
+		feedUser,
+		baseTime,
+	), 1))
+	suite.Require().NoError(err)
+}
+
+func (suite *DbTestSuite) TestGetHomeFeed() {
+	suite.setupFeed()
+
+	// Get the first page
+	posts, err := suite.database.GetHomeFeed(1, feedUser, nil, 1)
+	suite.Require().NoError(err)
+	suite.Require().Len(posts, 1)
+	suite.Require().Equal(uint64(6), posts[0].ID)
+	suite.Require().Len(posts[0].References, 1)
+	suite.Require().Equal("POST_REFERENCE_TYPE_REPOST", posts[0].References[0].Type)
+	suite.Require().Equal(uint64(2), posts[0].References[0].PostID)
+
+	// Get the next page
+	posts, err = suite.database.GetHomeFeed(1, feedUser, posts[0].Cursor, 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(posts, 1)
+	suite.Require().Equal(uint64(2), posts[0].ID)
+	suite.Require().Empty(posts[0].References)
+}
@@ -47,7 +47,7 @@ ON CONFLICT ON CONSTRAINT unique_fee_grant DO UPDATE
         height = excluded.height
 WHERE fee_grant.height <= excluded.height`
 
-	_, err = db.SQL.Exec(stmt,
+	_, err = db.conn().Exec(stmt,
 		grant.Granter,
 		grant.Grantee,
 		pq.Array(dbtypes.NewDbCoins(spendLimit)),
@@ -61,13 +61,12 @@ WHERE fee_grant.height <= excluded.height`
 // DeleteFeeGrant removes the fee grant for the given data from the database
 func (db *Db) DeleteFeeGrant(granter string, grantee string, height int64) error {
 	stmt := `DELETE FROM fee_grant WHERE granter_address = $1 AND grantee_address = $2 AND height <= $3`
-	_, err := db.SQL.Exec(stmt, granter, grantee, height)
-	return err
+	return db.execDelete(height, stmt, granter, grantee, height)
 }
 
 // DeleteExpiredFeeGrants removes the fee grants that expire before or on the given time
 func (db *Db) DeleteExpiredFeeGrants(time time.Time) error {
 	stmt := `DELETE FROM fee_grant WHERE expiration_date <= $1`
-	_, err := db.SQL.Exec(stmt, time)
+	_, err := db.conn().Exec(stmt, time)
 	return err
 }
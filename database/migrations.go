@@ -0,0 +1,275 @@
+package database
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed schema/*.sql
+var schemaFS embed.FS
+
+const (
+	upMigrationSuffix   = ".up.sql"
+	downMigrationSuffix = ".down.sql"
+)
+
+// ErrSchemaBehind is returned when the database schema does not include all the migrations known by this binary
+var ErrSchemaBehind = errors.New("database schema is behind, please run the database migrate command")
+
+// Migration represents a single versioned schema migration
+type Migration struct {
+	// Version identifies the migration. Migrations are applied in the lexicographical order of their versions.
+	Version string
+
+	Up   string
+	Down string
+}
+
+// AppliedMigration contains the details of a migration that has been applied to the database
+type AppliedMigration struct {
+	Version   string    `db:"version"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+// Migrations returns all the schema migrations embedded inside the binary, sorted by version
+func Migrations() ([]Migration, error) {
+	return loadMigrations(schemaFS, "schema")
+}
+
+// loadMigrations reads all the migrations contained inside the given directory.
+// Each migration is made of a <version>.up.sql file and a <version>.down.sql file.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := map[string]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+
+		var version string
+		var isUp bool
+		switch {
+		case strings.HasSuffix(name, upMigrationSuffix):
+			version, isUp = strings.TrimSuffix(name, upMigrationSuffix), true
+		case strings.HasSuffix(name, downMigrationSuffix):
+			version = strings.TrimSuffix(name, downMigrationSuffix)
+		default:
+			return nil, fmt.Errorf("invalid migration file name: %s", name)
+		}
+
+		bz, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, found := migrations[version]
+		if !found {
+			migration = &Migration{Version: version}
+			migrations[version] = migration
+		}
+
+		if isUp {
+			migration.Up = string(bz)
+		} else {
+			migration.Down = string(bz)
+		}
+	}
+
+	sorted := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %s must have both the up and down files", migration.Version)
+		}
+		sorted = append(sorted, *migration)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return sorted, nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// createMigrationsTable creates the table used to track the applied migrations, if it does not exist yet
+func (db *Db) createMigrationsTable() error {
+	stmt := `
+CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version    TEXT                        NOT NULL PRIMARY KEY,
+    applied_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+)`
+	_, err := db.conn().Exec(stmt)
+	return err
+}
+
+// GetAppliedMigrations returns all the migrations that have been applied to the database, sorted by version
+func (db *Db) GetAppliedMigrations() ([]AppliedMigration, error) {
+	err := db.createMigrationsTable()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []AppliedMigration
+	err = db.conn().Select(&applied, `SELECT version, applied_at FROM schema_migrations ORDER BY version`)
+	return applied, err
+}
+
+// getAppliedVersions returns the set of the versions of the applied migrations
+func (db *Db) getAppliedVersions() (map[string]bool, error) {
+	applied, err := db.GetAppliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]bool, len(applied))
+	for _, migration := range applied {
+		versions[migration.Version] = true
+	}
+	return versions, nil
+}
+
+// GetPendingMigrations returns the embedded migrations that have not been applied to the database yet
+func (db *Db) GetPendingMigrations() ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := db.getAppliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// CheckSchema returns ErrSchemaBehind if the database has some pending migrations
+func (db *Db) CheckSchema() error {
+	pending, err := db.GetPendingMigrations()
+	if err != nil {
+		return fmt.Errorf("error while checking database schema: %s", err)
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migrations, starting from %s", ErrSchemaBehind, len(pending), pending[0].Version)
+	}
+
+	return nil
+}
+
+// MigrateUp applies all the pending migrations having a version lower or equal to the given one, or all the
+// pending migrations if the given version is empty. Each migration is applied inside its own transaction.
+// It returns the versions of the applied migrations.
+func (db *Db) MigrateUp(toVersion string) ([]string, error) {
+	pending, err := db.GetPendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []string
+	for _, migration := range pending {
+		if toVersion != "" && migration.Version > toVersion {
+			break
+		}
+
+		err = db.runMigration(migration.Version, migration.Up, `INSERT INTO schema_migrations (version) VALUES ($1)`)
+		if err != nil {
+			return applied, fmt.Errorf("error while applying migration %s: %s", migration.Version, err)
+		}
+		applied = append(applied, migration.Version)
+	}
+
+	return applied, nil
+}
+
+// MigrateDown reverts all the applied migrations having a version greater than the given one, starting from the
+// most recent one. Each migration is reverted inside its own transaction.
+// It returns the versions of the reverted migrations.
+func (db *Db) MigrateDown(toVersion string) ([]string, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := db.getAppliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []string
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if migration.Version <= toVersion || !applied[migration.Version] {
+			continue
+		}
+
+		err = db.runMigration(migration.Version, migration.Down, `DELETE FROM schema_migrations WHERE version = $1`)
+		if err != nil {
+			return reverted, fmt.Errorf("error while reverting migration %s: %s", migration.Version, err)
+		}
+		reverted = append(reverted, migration.Version)
+	}
+
+	return reverted, nil
+}
+
+// Baseline marks all the migrations having a version lower or equal to the given one as applied, without running them.
+// This should be used on databases whose schema has been created manually before migrations were introduced.
+func (db *Db) Baseline(toVersion string) ([]string, error) {
+	pending, err := db.GetPendingMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var marked []string
+	for _, migration := range pending {
+		if migration.Version > toVersion {
+			break
+		}
+
+		_, err = db.conn().Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, migration.Version)
+		if err != nil {
+			return marked, err
+		}
+		marked = append(marked, migration.Version)
+	}
+
+	return marked, nil
+}
+
+// runMigration runs the given migration SQL and the given tracking statement inside a single transaction
+func (db *Db) runMigration(version string, migrationSQL string, trackStmt string) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(migrationSQL)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(trackStmt, version)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
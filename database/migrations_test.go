@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/database"
+)
+
+func (suite *DbTestSuite) TestMigrations() {
+	migrations, err := database.Migrations()
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(migrations)
+
+	latest := migrations[len(migrations)-1].Version
+
+	// Mark the existing schema as migrated
+	marked, err := suite.database.Baseline(latest)
+	suite.Require().NoError(err)
+	suite.Require().Len(marked, len(migrations))
+	suite.Require().NoError(suite.database.CheckSchema())
+
+	// Revert all the migrations
+	reverted, err := suite.database.MigrateDown("")
+	suite.Require().NoError(err)
+	suite.Require().Len(reverted, len(migrations))
+	suite.Require().Equal(latest, reverted[0])
+	suite.Require().ErrorIs(suite.database.CheckSchema(), database.ErrSchemaBehind)
+
+	var count int
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM information_schema.tables WHERE table_name = 'post'`)
+	suite.Require().NoError(err)
+	suite.Require().Zero(count)
+
+	// Apply them again
+	applied, err := suite.database.MigrateUp("")
+	suite.Require().NoError(err)
+	suite.Require().Len(applied, len(migrations))
+	suite.Require().NoError(suite.database.CheckSchema())
+
+	pending, err := suite.database.GetPendingMigrations()
+	suite.Require().NoError(err)
+	suite.Require().Empty(pending)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := database.Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		require.NotEmpty(t, migration.Up, migration.Version)
+		require.NotEmpty(t, migration.Down, migration.Version)
+		if i > 0 {
+			require.Less(t, migrations[i-1].Version, migration.Version)
+		}
+	}
+}
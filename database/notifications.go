@@ -19,16 +19,55 @@ func (db *Db) SaveNotification(recipient types.NotificationRecipient, data types
 		return err
 	}
 
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The unique constraint of the partitioned table must include the timestamp, so the same notification is
+	// deduplicated regardless of its timestamp by checking for it while holding a lock on the user and data
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtextextended($1 || md5($2::JSONB::TEXT), 0))`,
+		recipient.String(), string(dataBz))
+	if err != nil {
+		return err
+	}
+
 	stmt := `
 INSERT INTO notification (user_address, type, data, timestamp) 
-VALUES ($1, $2, $3, $4) 
-ON CONFLICT ON CONSTRAINT unique_user_notification DO UPDATE 
-    SET user_address = excluded.user_address,
-        data = excluded.data,
-        timestamp = excluded.timestamp
-WHERE notification.timestamp <= excluded.timestamp`
-	_, err = db.SQL.Exec(stmt, recipient.String(), notification.Type, string(dataBz), notification.Timestamp)
-	return err
+SELECT $1, $2, $3, $4
+WHERE NOT EXISTS (
+    SELECT 1 FROM notification WHERE user_address = $1 AND data_hash = md5($3::JSONB::TEXT)
+)
+ON CONFLICT (user_address, data_hash, timestamp) DO NOTHING`
+	_, err = tx.Exec(stmt, recipient.String(), notification.Type, string(dataBz), notification.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CreateNotificationPartitions creates the monthly partitions of the notification table for the given number
+// of months starting from the month of the given date, if they do not exist yet
+func (db *Db) CreateNotificationPartitions(from time.Time, months int) error {
+	// Start from the first day of the month, since adding months to the end of a month might skip the next one
+	monthStart := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+	for i := 0; i < months; i++ {
+		_, err := db.conn().Exec(`SELECT create_notification_partition($1)`, monthStart.AddDate(0, i, 0))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropNotificationPartitions drops the monthly partitions of the notification table containing only
+// notifications older than the given date, and returns their names
+func (db *Db) DropNotificationPartitions(before time.Time) ([]string, error) {
+	var partitions []string
+	err := db.conn().Select(&partitions, `SELECT drop_notification_partitions($1)`, before)
+	return partitions, err
 }
 
 // SaveToken stores the given notification token inside the database
@@ -41,7 +80,7 @@ ON CONFLICT ON CONSTRAINT unique_notification_token DO UPDATE
         device_token = excluded.device_token,
         timestamp = excluded.timestamp
 WHERE notification_token.timestamp <= excluded.timestamp`
-	_, err := db.SQL.Exec(stmt, token.UserAddress, token.Token, token.Timestamp)
+	_, err := db.conn().Exec(stmt, token.UserAddress, token.Token, token.Timestamp)
 	return err
 }
 
@@ -56,7 +95,7 @@ func (db *Db) GetUserTokens(userAddress string) ([]types.NotificationToken, erro
 	stmt := `SELECT * FROM notification_token WHERE user_address = $1`
 
 	var rows []notificationTokenRow
-	err := db.SQL.Select(&rows, stmt, userAddress)
+	err := db.conn().Select(&rows, stmt, userAddress)
 	if err != nil {
 		return nil, err
 	}
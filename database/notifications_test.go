@@ -0,0 +1,63 @@
+package database_test
+
+import (
+	"time"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestNotificationPartitions() {
+	countPartitions := func(name string) int {
+		var count int
+		err := suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM pg_class WHERE relname = $1`, name)
+		suite.Require().NoError(err)
+		return count
+	}
+
+	// Store a notification before creating its partition, so that it is stored inside the default one
+	_, err := suite.database.SQL.Exec(`
+INSERT INTO notification (user_address, type, data, timestamp) 
+VALUES ('cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s', 'comment', '{"post_id": "1"}', '2020-01-15')`)
+	suite.Require().NoError(err)
+
+	// Starting from the end of a month should not skip the next one
+	err = suite.database.CreateNotificationPartitions(time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC), 2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, countPartitions("notification_2020_01"))
+	suite.Require().Equal(1, countPartitions("notification_2020_02"))
+
+	// The notification should have been moved to its partition
+	var count int
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM notification_2020_01`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+
+	// Only the partitions older than the given date should be dropped
+	dropped, err := suite.database.DropNotificationPartitions(time.Date(2020, 2, 15, 0, 0, 0, 0, time.UTC))
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{"notification_2020_01"}, dropped)
+	suite.Require().Zero(countPartitions("notification_2020_01"))
+	suite.Require().Equal(1, countPartitions("notification_2020_02"))
+}
+
+func (suite *DbTestSuite) TestSaveNotification() {
+	recipient := types.NewNotificationUserRecipient("cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s")
+	notification := types.StdNotificationDataWithConfig{
+		Type:      "comment",
+		Data:      map[string]string{"post_id": "1"},
+		Timestamp: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	err := suite.database.SaveNotification(recipient, notification)
+	suite.Require().NoError(err)
+
+	// The same notification should not be stored twice, even with a different timestamp
+	notification.Timestamp = time.Date(2020, 2, 15, 0, 0, 0, 0, time.UTC)
+	err = suite.database.SaveNotification(recipient, notification)
+	suite.Require().NoError(err)
+
+	var count int
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM notification`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"github.com/lib/pq"
+)
+
+const (
+	// postRowIDsCondition selects the posts having the row ids given as $1
+	postRowIDsCondition = `post.row_id = ANY ($1)`
+
+	// postIDCondition selects the post having the subspace id given as $1 and the id given as $2
+	postIDCondition = `post.subspace_id = $1 AND post.id = $2`
+
+	// postKeysCondition selects the posts having the subspace ids given as $1 and the ids given as $2
+	postKeysCondition = `(post.subspace_id, post.id) IN (SELECT * FROM UNNEST ($1::BIGINT[], $2::BIGINT[]))`
+
+	// subspacePostsCondition selects the posts of the subspace given as $1
+	subspacePostsCondition = `post.subspace_id = $1`
+
+	// allPostsCondition selects all the posts
+	allPostsCondition = `TRUE`
+)
+
+// updatePostsCounters recomputes the counters of all the posts matching the given condition, using the given
+// transaction so that they are updated along with the data they are computed from
+func updatePostsCounters(tx sqlTx, condition string, args ...interface{}) error {
+	stmt := `
+INSERT INTO post_counters (post_row_id, reactions_count, comments_count, replies_count, reposts_count, quotes_count, 
+                           poll_answers_count, tips_count, tips_amount)
+SELECT post.row_id,
+       (SELECT COUNT(*) FROM reaction WHERE reaction.post_row_id = post.row_id AND reaction.deletion_height IS NULL),
+       (SELECT COUNT(*) FROM post AS comment WHERE comment.conversation_row_id = post.row_id AND comment.deletion_height IS NULL),
+       (SELECT COUNT(*) FROM referencing_post WHERE referencing_post.reference_row_id = post.row_id AND referencing_post.type = 'POST_REFERENCE_TYPE_REPLY'),
+       (SELECT COUNT(*) FROM referencing_post WHERE referencing_post.reference_row_id = post.row_id AND referencing_post.type = 'POST_REFERENCE_TYPE_REPOST'),
+       (SELECT COUNT(*) FROM referencing_post WHERE referencing_post.reference_row_id = post.row_id AND referencing_post.type = 'POST_REFERENCE_TYPE_QUOTE'),
+       (SELECT COUNT(*) FROM poll_answer JOIN post_attachment ON post_attachment.row_id = poll_answer.attachment_row_id WHERE post_attachment.post_row_id = post.row_id),
+       (SELECT COUNT(*) FROM tip_post WHERE tip_post.post_row_id = post.row_id),
+       ARRAY(SELECT ROW (coin.denom, SUM(coin.amount::NUMERIC)::TEXT)::COIN
+             FROM tip_post, UNNEST(tip_post.amount) AS coin
+             WHERE tip_post.post_row_id = post.row_id
+             GROUP BY coin.denom
+             ORDER BY coin.denom)
+FROM post
+WHERE ` + condition + `
+ON CONFLICT ON CONSTRAINT unique_post_counters DO UPDATE 
+    SET reactions_count = excluded.reactions_count,
+        comments_count = excluded.comments_count,
+        replies_count = excluded.replies_count,
+        reposts_count = excluded.reposts_count,
+        quotes_count = excluded.quotes_count,
+        poll_answers_count = excluded.poll_answers_count,
+        tips_count = excluded.tips_count,
+        tips_amount = excluded.tips_amount`
+
+	// Only count the references made by posts that have not been deleted
+	referencingPosts := `
+WITH referencing_post AS (
+    SELECT post_reference.reference_row_id, post_reference.type
+    FROM post_reference
+             JOIN post ON post.row_id = post_reference.post_row_id
+    WHERE post.deletion_height IS NULL
+)`
+	_, err := tx.Exec(referencingPosts+stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	// Recompute the registered reactions counters
+	stmt = `DELETE FROM post_reaction_counters USING post WHERE post.row_id = post_reaction_counters.post_row_id AND ` + condition
+	_, err = tx.Exec(stmt, args...)
+	if err != nil {
+		return err
+	}
+
+	stmt = `
+INSERT INTO post_reaction_counters (post_row_id, registered_reaction_id, reactions_count)
+SELECT reaction.post_row_id, (reaction.value ->> 'registered_reaction_id')::BIGINT, COUNT(*)
+FROM reaction
+         JOIN post ON post.row_id = reaction.post_row_id
+WHERE ` + condition + `
+  AND reaction.deletion_height IS NULL
+  AND reaction.value ->> '@type' LIKE '%RegisteredReactionValue'
+GROUP BY reaction.post_row_id, reaction.value ->> 'registered_reaction_id'`
+	_, err = tx.Exec(stmt, args...)
+	return err
+}
+
+// getRelatedPostsRowIDs returns the row ids of the given posts, of the posts starting their conversations and of
+// all the posts they reference. These are the posts whose counters might change when the given posts change.
+func getRelatedPostsRowIDs(tx sqlTx, postRowIDs ...int64) (pq.Int64Array, error) {
+	stmt := `
+SELECT ARRAY(
+    SELECT row_id FROM post WHERE row_id = ANY ($1)
+    UNION
+    SELECT conversation_row_id FROM post WHERE row_id = ANY ($1) AND conversation_row_id IS NOT NULL
+    UNION
+    SELECT reference_row_id FROM post_reference WHERE post_row_id = ANY ($1)
+)`
+
+	var rowIDs pq.Int64Array
+	err := tx.QueryRow(stmt, pq.Int64Array(postRowIDs)).Scan(&rowIDs)
+	return rowIDs, err
+}
+
+// RebuildPostsCounters recomputes the counters of all the posts of the given subspace from scratch.
+// If no subspace is given, the counters of all the posts are recomputed.
+func (db *Db) RebuildPostsCounters(subspaceID *uint64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if subspaceID != nil {
+		err = updatePostsCounters(tx, subspacePostsCondition, *subspaceID)
+	} else {
+		err = updatePostsCounters(tx, allPostsCondition)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,120 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	reactionstypes "github.com/desmos-labs/desmos/v7/x/reactions/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestPostCounters() {
+	author := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+
+	err := suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		author,
+		author,
+		time.Now(),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+	suite.Require().NoError(err)
+
+	buildPost := func(id uint64, conversationID uint64, references []poststypes.PostReference) poststypes.Post {
+		return poststypes.NewPost(
+			1,
+			0,
+			id,
+			"",
+			"Hello world",
+			author,
+			conversationID,
+			nil,
+			nil,
+			references,
+			poststypes.REPLY_SETTING_EVERYONE,
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			nil,
+			author,
+		)
+	}
+
+	// Store a post along with a comment and a quote
+	err = suite.database.SavePost(types.NewPost(buildPost(1, 0, nil), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SavePost(types.NewPost(buildPost(2, 1, nil), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SavePost(types.NewPost(buildPost(3, 0, []poststypes.PostReference{
+		poststypes.NewPostReference(poststypes.POST_REFERENCE_TYPE_QUOTE, 1, 0),
+	}), 10))
+	suite.Require().NoError(err)
+
+	// Add a registered reaction
+	err = suite.database.SaveReaction(types.NewReaction(reactionstypes.NewReaction(
+		1,
+		1,
+		1,
+		reactionstypes.NewRegisteredReactionValue(1),
+		author,
+	), 10))
+	suite.Require().NoError(err)
+
+	type postCounters struct {
+		ReactionsCount int64 `db:"reactions_count"`
+		CommentsCount  int64 `db:"comments_count"`
+		QuotesCount    int64 `db:"quotes_count"`
+	}
+	getCounters := func() postCounters {
+		var counters postCounters
+		err := suite.database.SQL.Get(&counters, `
+SELECT reactions_count, comments_count, quotes_count 
+FROM post_counters JOIN post ON post.row_id = post_counters.post_row_id 
+WHERE post.subspace_id = 1 AND post.id = 1`)
+		suite.Require().NoError(err)
+		return counters
+	}
+	getRegisteredReactionsCount := func() int64 {
+		var count int64
+		err := suite.database.SQL.Get(&count, `
+SELECT COALESCE(SUM(reactions_count), 0) 
+FROM post_reaction_counters JOIN post ON post.row_id = post_reaction_counters.post_row_id 
+WHERE post.subspace_id = 1 AND post.id = 1 AND registered_reaction_id = 1`)
+		suite.Require().NoError(err)
+		return count
+	}
+
+	suite.Require().Equal(postCounters{ReactionsCount: 1, CommentsCount: 1, QuotesCount: 1}, getCounters())
+	suite.Require().Equal(int64(1), getRegisteredReactionsCount())
+
+	// Deleting the comment, the quote and the reaction should update the counters
+	err = suite.database.TombstonePost(20, 1, 2, "TX_HASH", time.Now().UTC())
+	suite.Require().NoError(err)
+
+	err = suite.database.DeletePost(20, 1, 3)
+	suite.Require().NoError(err)
+
+	err = suite.database.DeleteReaction(20, 1, 1, 1)
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(postCounters{}, getCounters())
+	suite.Require().Zero(getRegisteredReactionsCount())
+
+	// Rebuilding the counters should give the same result
+	_, err = suite.database.SQL.Exec(`DELETE FROM post_counters`)
+	suite.Require().NoError(err)
+
+	err = suite.database.RebuildPostsCounters(nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(postCounters{}, getCounters())
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	"github.com/lib/pq"
 
 	dbtypes "github.com/desmos-labs/athena/v2/database/types"
 	"github.com/desmos-labs/athena/v2/types"
@@ -17,7 +19,7 @@ func (db *Db) getPostRowID(subspaceID uint64, postID uint64) (sql.NullInt64, err
 	stmt := `SELECT row_id FROM post WHERE subspace_id = $1 and id = $2`
 
 	var rowID int64
-	err := db.SQL.QueryRow(stmt, subspaceID, postID).Scan(&rowID)
+	err := db.conn().QueryRow(stmt, subspaceID, postID).Scan(&rowID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return sql.NullInt64{Int64: 0, Valid: false}, nil
 	}
@@ -39,6 +41,12 @@ func (db *Db) SavePost(post types.Post) error {
 		return err
 	}
 
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	// Insert the post
 	stmt := `
 INSERT INTO post (subspace_id, section_row_id, id, external_id, text, author_address, owner_address, conversation_row_id, reply_settings, creation_date, last_edited_date, height) 
@@ -55,12 +63,16 @@ ON CONFLICT ON CONSTRAINT unique_subspace_post DO UPDATE
         reply_settings = excluded.reply_settings,
         creation_date = excluded.creation_date,
         last_edited_date = excluded.last_edited_date,
-        height = excluded.height
+        height = excluded.height,
+        deletion_height = NULL,
+        deletion_tx_hash = NULL,
+        deletion_time = NULL
 WHERE post.height <= excluded.height
+  AND (post.deletion_height IS NULL OR post.deletion_height <= excluded.height)
 RETURNING row_id`
 
 	var rowID uint64
-	err = db.SQL.QueryRow(stmt,
+	err = tx.QueryRow(stmt,
 		post.SubspaceID,
 		sectionRowID,
 		post.ID,
@@ -79,42 +91,340 @@ RETURNING row_id`
 	}
 
 	// Insert the entities
-	err = db.savePostEntities(rowID, post.Entities)
+	err = db.savePostEntities(tx, rowID, post.Entities)
 	if err != nil {
 		return err
 	}
 
 	// Insert the tags
-	err = db.savePostTags(rowID, post.Tags)
+	err = db.savePostTags(tx, rowID, post.Tags)
 	if err != nil {
 		return err
 	}
 
 	// Insert the reference
-	err = db.savePostReferences(post.SubspaceID, rowID, post.ReferencedPosts)
+	err = db.savePostReferences(tx, post.SubspaceID, rowID, post.ReferencedPosts)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	// Update the counters of the post and of the posts it comments or references
+	relatedRowIDs, err := getRelatedPostsRowIDs(tx, int64(rowID))
+	if err != nil {
+		return err
+	}
+
+	err = updatePostsCounters(tx, postRowIDsCondition, relatedRowIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// postKey identifies a post inside the database
+type postKey struct {
+	subspaceID uint64
+	postID     uint64
+}
+
+// SavePosts stores the given posts inside the database in bulk, using a single transaction for each chunk.
+// Posts should be sorted so that each one comes after its conversation. The references are stored once all the
+// posts have been stored, so that the posts referencing the ones stored inside later chunks are not skipped.
+func (db *Db) SavePosts(posts []types.Post) error {
+	var writtenPosts []types.Post
+	var writtenRowIDs pq.Int64Array
+	err := inChunks(len(posts), func(start, end int) error {
+		tx, err := db.begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		chunkPosts, chunkRowIDs, err := savePostsChunk(tx, posts[start:end])
+		if err != nil {
+			return err
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+
+		writtenPosts = append(writtenPosts, chunkPosts...)
+		writtenRowIDs = append(writtenRowIDs, chunkRowIDs...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return inChunks(len(writtenPosts), func(start, end int) error {
+		tx, err := db.begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		err = savePostsReferences(tx, writtenPosts[start:end], writtenRowIDs[start:end])
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
 }
 
-func (db *Db) savePostEntities(postRowID uint64, entities *poststypes.Entities) error {
+// savePostsChunk stores the given posts using one statement for each table, except for the references.
+// It returns the posts that have been written, along with their row ids.
+func savePostsChunk(tx sqlTx, posts []types.Post) ([]types.Post, pq.Int64Array, error) {
+	// Remove the duplicated posts, keeping the last version of each one
+	indexes := map[postKey]int{}
+	var uniquePosts []types.Post
+	for _, post := range posts {
+		key := postKey{post.SubspaceID, post.ID}
+		if index, found := indexes[key]; found {
+			uniquePosts[index] = post
+			continue
+		}
+		indexes[key] = len(uniquePosts)
+		uniquePosts = append(uniquePosts, post)
+	}
+
+	var vars []interface{}
+	for _, post := range uniquePosts {
+		vars = append(vars,
+			post.SubspaceID,
+			post.SectionID,
+			post.ID,
+			dbtypes.ToNullString(post.ExternalID),
+			dbtypes.ToNullString(post.Text),
+			post.Author,
+			dbtypes.ToNullString(post.Owner),
+			post.ConversationID,
+			post.ReplySettings.String(),
+			post.CreationDate,
+			dbtypes.ToNullTime(post.LastEditedDate),
+			post.Height,
+		)
+	}
+
+	// Insert the posts, resolving the section and conversation row ids inside the same statement.
+	// WHERE TRUE is required to avoid the ON CONFLICT clause being parsed as part of the join.
+	stmt := fmt.Sprintf(`
+INSERT INTO post (subspace_id, section_row_id, id, external_id, text, author_address, owner_address, conversation_row_id, reply_settings, creation_date, last_edited_date, height) 
+SELECT value.subspace_id, section.row_id, value.id, value.external_id, value.text, value.author_address, value.owner_address, 
+       conversation.row_id, value.reply_settings, value.creation_date, value.last_edited_date, value.height
+FROM (VALUES %s) AS value (subspace_id, section_id, id, external_id, text, author_address, owner_address, conversation_id, reply_settings, creation_date, last_edited_date, height)
+         LEFT JOIN subspace_section section ON section.subspace_id = value.subspace_id AND section.id = value.section_id
+         LEFT JOIN post conversation ON conversation.subspace_id = value.subspace_id AND conversation.id = value.conversation_id
+WHERE TRUE
+ON CONFLICT ON CONSTRAINT unique_subspace_post DO UPDATE 
+    SET subspace_id = excluded.subspace_id,
+        section_row_id = excluded.section_row_id,
+        id = excluded.id,
+        external_id = excluded.external_id,
+        text = excluded.text,
+        author_address = excluded.author_address,
+        owner_address = excluded.owner_address,
+        conversation_row_id = excluded.conversation_row_id,
+        reply_settings = excluded.reply_settings,
+        creation_date = excluded.creation_date,
+        last_edited_date = excluded.last_edited_date,
+        height = excluded.height,
+        deletion_height = NULL,
+        deletion_tx_hash = NULL,
+        deletion_time = NULL
+WHERE post.height <= excluded.height
+  AND (post.deletion_height IS NULL OR post.deletion_height <= excluded.height)
+RETURNING row_id, subspace_id, id`, valuesList(len(uniquePosts), []string{
+		"BIGINT", "BIGINT", "BIGINT", "TEXT", "TEXT", "TEXT", "TEXT", "BIGINT", "TEXT", "TIMESTAMP", "TIMESTAMP", "BIGINT",
+	}))
+
+	rows, err := tx.Query(stmt, vars...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rowIDs := map[postKey]int64{}
+	for rows.Next() {
+		var rowID int64
+		var key postKey
+		err = rows.Scan(&rowID, &key.subspaceID, &key.postID)
+		if err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		rowIDs[key] = rowID
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// Posts that have not been written are older than the stored ones
+	var writtenPosts []types.Post
+	var writtenRowIDs pq.Int64Array
+	for _, post := range uniquePosts {
+		if rowID, written := rowIDs[postKey{post.SubspaceID, post.ID}]; written {
+			writtenPosts = append(writtenPosts, post)
+			writtenRowIDs = append(writtenRowIDs, rowID)
+		}
+	}
+
+	if len(writtenPosts) == 0 {
+		return nil, nil, nil
+	}
+
+	err = savePostsConversations(tx, writtenPosts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = savePostsChildren(tx, writtenPosts, writtenRowIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Update the counters of the posts and of the posts they comment
+	relatedRowIDs, err := getRelatedPostsRowIDs(tx, writtenRowIDs...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = updatePostsCounters(tx, postRowIDsCondition, relatedRowIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return writtenPosts, writtenRowIDs, nil
+}
+
+// savePostsConversations sets the conversation row id of the given posts whose conversation has been stored
+// along with them, and that could not be resolved while inserting them
+func savePostsConversations(tx sqlTx, posts []types.Post) error {
+	var rows [][]interface{}
+	for _, post := range posts {
+		if post.ConversationID != 0 {
+			rows = append(rows, []interface{}{post.SubspaceID, post.ID, post.ConversationID})
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	stmt := `
+UPDATE post SET conversation_row_id = conversation.row_id
+FROM (VALUES %s) AS value (subspace_id, id, conversation_id)
+         JOIN post conversation ON conversation.subspace_id = value.subspace_id AND conversation.id = value.conversation_id
+WHERE post.subspace_id = value.subspace_id 
+  AND post.id = value.id 
+  AND post.conversation_row_id IS NULL`
+	return bulkExec(tx, stmt, []string{"BIGINT", "BIGINT", "BIGINT"}, rows)
+}
+
+// savePostsChildren replaces the entities and tags of the posts having the given row ids
+func savePostsChildren(tx sqlTx, posts []types.Post, rowIDs pq.Int64Array) error {
+	for _, table := range []string{"post_hashtag", "post_mention", "post_url", "post_tag"} {
+		_, err := tx.Exec(`DELETE FROM `+table+` WHERE post_row_id = ANY ($1)`, rowIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var hashtags, mentions, urls, tags [][]interface{}
+	for i, post := range posts {
+		rowID := rowIDs[i]
+
+		if post.Entities != nil {
+			for _, hashtag := range post.Entities.Hashtags {
+				hashtags = append(hashtags, []interface{}{rowID, hashtag.Start, hashtag.End, hashtag.Tag})
+			}
+			for _, mention := range post.Entities.Mentions {
+				mentions = append(mentions, []interface{}{rowID, mention.Start, mention.End, mention.Tag})
+			}
+			for _, url := range post.Entities.Urls {
+				urls = append(urls, []interface{}{rowID, url.Start, url.End, url.Url, url.DisplayUrl})
+			}
+		}
+
+		for _, tag := range post.Tags {
+			tags = append(tags, []interface{}{rowID, tag})
+		}
+	}
+
+	err := bulkExec(tx, `INSERT INTO post_hashtag (post_row_id, start_index, end_index, tag) VALUES %s`,
+		[]string{"BIGINT", "BIGINT", "BIGINT", "TEXT"}, hashtags)
+	if err != nil {
+		return err
+	}
+
+	err = bulkExec(tx, `INSERT INTO post_mention (post_row_id, start_index, end_index, mention_address) VALUES %s`,
+		[]string{"BIGINT", "BIGINT", "BIGINT", "TEXT"}, mentions)
+	if err != nil {
+		return err
+	}
+
+	err = bulkExec(tx, `INSERT INTO post_url (post_row_id, start_index, end_index, url, display_value) VALUES %s`,
+		[]string{"BIGINT", "BIGINT", "BIGINT", "TEXT", "TEXT"}, urls)
+	if err != nil {
+		return err
+	}
+
+	return bulkExec(tx, `INSERT INTO post_tag (post_row_id, tag) VALUES %s`, []string{"BIGINT", "TEXT"}, tags)
+}
+
+// savePostsReferences replaces the references of the posts having the given row ids, updating the counters
+// of the referenced posts
+func savePostsReferences(tx sqlTx, posts []types.Post, rowIDs pq.Int64Array) error {
+	_, err := tx.Exec(`DELETE FROM post_reference WHERE post_row_id = ANY ($1)`, rowIDs)
+	if err != nil {
+		return err
+	}
+
+	var references [][]interface{}
+	for i, post := range posts {
+		for _, ref := range post.ReferencedPosts {
+			references = append(references, []interface{}{rowIDs[i], post.SubspaceID, ref.Type.String(), ref.PostID, ref.Position})
+		}
+	}
+
+	err = bulkExec(tx, `
+INSERT INTO post_reference (post_row_id, type, reference_row_id, position_index) 
+SELECT value.post_row_id, value.type, reference.row_id, value.position_index
+FROM (VALUES %s) AS value (post_row_id, subspace_id, type, reference_id, position_index)
+         JOIN post reference ON reference.subspace_id = value.subspace_id AND reference.id = value.reference_id`,
+		[]string{"BIGINT", "BIGINT", "TEXT", "BIGINT", "BIGINT"}, references)
+	if err != nil {
+		return err
+	}
+
+	// Update the counters of the referenced posts
+	relatedRowIDs, err := getRelatedPostsRowIDs(tx, rowIDs...)
+	if err != nil {
+		return err
+	}
+
+	return updatePostsCounters(tx, postRowIDsCondition, relatedRowIDs)
+}
+
+func (db *Db) savePostEntities(tx sqlTx, postRowID uint64, entities *poststypes.Entities) error {
 	if entities == nil {
 		return nil
 	}
 
-	err := db.savePostHashtags(postRowID, entities.Hashtags)
+	err := db.savePostHashtags(tx, postRowID, entities.Hashtags)
 	if err != nil {
 		return err
 	}
 
-	err = db.savePostMentions(postRowID, entities.Mentions)
+	err = db.savePostMentions(tx, postRowID, entities.Mentions)
 	if err != nil {
 		return err
 	}
 
-	err = db.savePostURLs(postRowID, entities.Urls)
+	err = db.savePostURLs(tx, postRowID, entities.Urls)
 	if err != nil {
 		return err
 	}
@@ -122,10 +432,10 @@ func (db *Db) savePostEntities(postRowID uint64, entities *poststypes.Entities)
 	return nil
 }
 
-func (db *Db) savePostHashtags(postRowID uint64, hashtags []poststypes.TextTag) error {
+func (db *Db) savePostHashtags(tx sqlTx, postRowID uint64, hashtags []poststypes.TextTag) error {
 	// Delete all hashtags first
 	stmt := `DELETE FROM post_hashtag WHERE post_row_id = $1`
-	_, err := db.SQL.Exec(stmt, postRowID)
+	_, err := tx.Exec(stmt, postRowID)
 	if err != nil {
 		return err
 	}
@@ -146,14 +456,14 @@ func (db *Db) savePostHashtags(postRowID uint64, hashtags []poststypes.TextTag)
 	stmt = stmt[:len(stmt)-1] // Trim trailing ,
 	stmt += `ON CONFLICT DO NOTHING`
 
-	_, err = db.SQL.Exec(stmt, vars...)
+	_, err = tx.Exec(stmt, vars...)
 	return err
 }
 
-func (db *Db) savePostMentions(postRowID uint64, mentions []poststypes.TextTag) error {
+func (db *Db) savePostMentions(tx sqlTx, postRowID uint64, mentions []poststypes.TextTag) error {
 	// Delete all mentions first
 	stmt := `DELETE FROM post_mention WHERE post_row_id = $1`
-	_, err := db.SQL.Exec(stmt, postRowID)
+	_, err := tx.Exec(stmt, postRowID)
 	if err != nil {
 		return err
 	}
@@ -174,14 +484,14 @@ func (db *Db) savePostMentions(postRowID uint64, mentions []poststypes.TextTag)
 	stmt = stmt[:len(stmt)-1] // Trim trailing ,
 	stmt += `ON CONFLICT DO NOTHING`
 
-	_, err = db.SQL.Exec(stmt, vars...)
+	_, err = tx.Exec(stmt, vars...)
 	return err
 }
 
-func (db *Db) savePostURLs(postRowID uint64, urls []poststypes.Url) error {
+func (db *Db) savePostURLs(tx sqlTx, postRowID uint64, urls []poststypes.Url) error {
 	// Delete all urls first
 	stmt := `DELETE FROM post_url WHERE post_row_id = $1`
-	_, err := db.SQL.Exec(stmt, postRowID)
+	_, err := tx.Exec(stmt, postRowID)
 	if err != nil {
 		return err
 	}
@@ -203,14 +513,14 @@ func (db *Db) savePostURLs(postRowID uint64, urls []poststypes.Url) error {
 	stmt = stmt[:len(stmt)-1] // Trim trailing ,
 	stmt += `ON CONFLICT DO NOTHING`
 
-	_, err = db.SQL.Exec(stmt, vars...)
+	_, err = tx.Exec(stmt, vars...)
 	return err
 }
 
-func (db *Db) savePostTags(postRowID uint64, tags []string) error {
+func (db *Db) savePostTags(tx sqlTx, postRowID uint64, tags []string) error {
 	// Delete all tags first
 	stmt := `DELETE FROM post_tag WHERE post_row_id = $1`
-	_, err := db.SQL.Exec(stmt, postRowID)
+	_, err := tx.Exec(stmt, postRowID)
 	if err != nil {
 		return err
 	}
@@ -232,14 +542,14 @@ func (db *Db) savePostTags(postRowID uint64, tags []string) error {
 	stmt = stmt[:len(stmt)-1] // Trim trailing ,
 	stmt += `ON CONFLICT DO NOTHING`
 
-	_, err = db.SQL.Exec(stmt, vars...)
+	_, err = tx.Exec(stmt, vars...)
 	return err
 }
 
-func (db *Db) savePostReferences(subspaceID uint64, postRowID uint64, references []poststypes.PostReference) error {
+func (db *Db) savePostReferences(tx sqlTx, subspaceID uint64, postRowID uint64, references []poststypes.PostReference) error {
 	// Delete all references first
 	stmt := `DELETE FROM post_reference WHERE post_row_id = $1`
-	_, err := db.SQL.Exec(stmt, postRowID)
+	_, err := tx.Exec(stmt, postRowID)
 	if err != nil {
 		return err
 	}
@@ -265,30 +575,127 @@ func (db *Db) savePostReferences(subspaceID uint64, postRowID uint64, references
 	stmt = stmt[:len(stmt)-1] // Trim trailing ,
 	stmt += `ON CONFLICT DO NOTHING`
 
-	_, err = db.SQL.Exec(stmt, vars...)
+	_, err = tx.Exec(stmt, vars...)
 	return err
 }
 
-// HasPost returns true if the post with the given id exists inside the database
+// HasPost returns true if the post with the given id exists inside the database and has not been marked as deleted
 func (db *Db) HasPost(height int64, subspaceID uint64, postID uint64) (bool, error) {
-	stmt := `SELECT EXISTS(SELECT 1 FROM post WHERE subspace_id = $1 AND id = $2 AND height <= $3)`
+	stmt := `
+SELECT EXISTS(
+    SELECT 1 FROM post WHERE subspace_id = $1 AND id = $2 AND height <= $3 AND deletion_height IS NULL
+)`
 	var exists bool
-	err := db.SQL.QueryRow(stmt, subspaceID, postID, height).Scan(&exists)
+	err := db.conn().QueryRow(stmt, subspaceID, postID, height).Scan(&exists)
 	return exists, err
 }
 
+// GetSubspacePostIDs returns the ids of all the non-deleted posts of the given subspace stored inside the database
+func (db *Db) GetSubspacePostIDs(subspaceID uint64) ([]uint64, error) {
+	stmt := `SELECT id FROM post WHERE subspace_id = $1 AND deletion_height IS NULL ORDER BY id`
+
+	var ids []uint64
+	err := db.conn().Select(&ids, stmt, subspaceID)
+	return ids, err
+}
+
 // DeletePost removes the post with the given details from the database
 func (db *Db) DeletePost(height int64, subspaceID uint64, postID uint64) error {
-	stmt := `DELETE FROM post WHERE subspace_id = $1 AND id = $2 AND height <= $3`
-	_, err := db.SQL.Exec(stmt, subspaceID, postID, height)
-	return err
+	return db.removePost(height, subspaceID, postID,
+		`DELETE FROM post WHERE row_id = $1 AND height <= $2`)
 }
 
 // DeleteAllPosts removes all the posts for the given subspace from the database
 func (db *Db) DeleteAllPosts(height int64, subspaceID uint64) error {
-	stmt := `DELETE FROM post WHERE height <= $1 AND subspace_id = $2`
-	_, err := db.SQL.Exec(stmt, height, subspaceID)
-	return err
+	return db.removeAllPosts(height, subspaceID,
+		`DELETE FROM post WHERE height <= $1 AND subspace_id = $2`)
+}
+
+// TombstonePost marks the post with the given details as deleted at the given height and block time by the
+// transaction having the given hash, without removing it from the database
+func (db *Db) TombstonePost(height int64, subspaceID uint64, postID uint64, txHash string, timestamp time.Time) error {
+	return db.removePost(height, subspaceID, postID, `
+UPDATE post SET deletion_height = $2, deletion_tx_hash = $3, deletion_time = $4
+WHERE row_id = $1 AND height <= $2 AND deletion_height IS NULL`, txHash, timestamp)
+}
+
+// TombstoneAllPosts marks all the posts for the given subspace as deleted at the given height and block time,
+// without removing them from the database. Posts that are stored again later at the same height are restored.
+func (db *Db) TombstoneAllPosts(height int64, subspaceID uint64, timestamp time.Time) error {
+	return db.removeAllPosts(height, subspaceID, `
+UPDATE post SET deletion_height = $1, deletion_time = $3
+WHERE height <= $1 AND subspace_id = $2 AND deletion_height IS NULL`, timestamp)
+}
+
+// removePost runs the given statement that removes the post having the given id, updating the counters of the
+// posts it comments or references. The statement receives the post row id and the given height as its
+// first parameters, followed by the given additional arguments.
+func (db *Db) removePost(height int64, subspaceID uint64, postID uint64, stmt string, args ...interface{}) error {
+	postRowID, err := db.getPostRowID(subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	if !postRowID.Valid {
+		return nil
+	}
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	// Get the related posts before the references are removed along with the post
+	relatedRowIDs, err := getRelatedPostsRowIDs(tx, postRowID.Int64)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(stmt, append([]interface{}{postRowID.Int64, height}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	err = updatePostsCounters(tx, postRowIDsCondition, relatedRowIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// removeAllPosts runs the given statement that removes all the posts of the given subspace, recomputing the
+// counters of the posts left. The statement receives the given height and subspace id as its first parameters,
+// followed by the given additional arguments.
+func (db *Db) removeAllPosts(height int64, subspaceID uint64, stmt string, args ...interface{}) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(stmt, append([]interface{}{height, subspaceID}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	err = updatePostsCounters(tx, subspacePostsCondition, subspaceID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -301,10 +708,38 @@ func (db *Db) SavePostTx(tx types.PostTransaction) error {
 	}
 
 	stmt := `INSERT INTO post_transaction (post_row_id, hash) VALUES ($1, $2) ON CONFLICT DO NOTHING`
-	_, err = db.SQL.Exec(stmt, postRowID, tx.Hash)
+	_, err = db.conn().Exec(stmt, postRowID, tx.Hash)
 	return err
 }
 
+// SavePostTxs stores the given transactions inside the database in bulk, using a single transaction for each chunk
+func (db *Db) SavePostTxs(txs []types.PostTransaction) error {
+	return inChunks(len(txs), func(start, end int) error {
+		tx, err := db.begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		var rows [][]interface{}
+		for _, postTx := range txs[start:end] {
+			rows = append(rows, []interface{}{postTx.SubspaceID, postTx.PostID, postTx.Hash})
+		}
+
+		err = bulkExec(tx, `
+INSERT INTO post_transaction (post_row_id, hash) 
+SELECT post.row_id, value.hash
+FROM (VALUES %s) AS value (subspace_id, post_id, hash)
+         JOIN post ON post.subspace_id = value.subspace_id AND post.id = value.post_id
+ON CONFLICT DO NOTHING`, []string{"BIGINT", "BIGINT", "TEXT"}, rows)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
 // --------------------------------------------------------------------------------------------------------------------
 
 func (db *Db) getAttachmentRowID(subspaceID uint64, postID uint64, attachmentID uint32) (int64, error) {
@@ -314,7 +749,7 @@ SELECT row_id FROM post_attachment WHERE post_row_id = (
 ) and id = $3`
 
 	var rowID int64
-	err := db.SQL.QueryRow(stmt, subspaceID, postID, attachmentID).Scan(&rowID)
+	err := db.conn().QueryRow(stmt, subspaceID, postID, attachmentID).Scan(&rowID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return rowID, nil
 	}
@@ -342,7 +777,7 @@ WHERE post_attachment.height <= excluded.height`
 		return fmt.Errorf("failed to json encode attachment content: %s", err)
 	}
 
-	_, err = db.SQL.Exec(stmt,
+	_, err = db.conn().Exec(stmt,
 		postRowID,
 		attachment.ID,
 		string(contentBz),
@@ -351,14 +786,126 @@ WHERE post_attachment.height <= excluded.height`
 	return err
 }
 
+// SavePostAttachments stores the given attachments inside the database in bulk, using a single transaction for
+// each chunk
+func (db *Db) SavePostAttachments(attachments []types.PostAttachment) error {
+	// Keep only the most recent version of each attachment, since the same row cannot be updated twice
+	// by a single statement
+	type attachmentKey struct {
+		SubspaceID   uint64
+		PostID       uint64
+		AttachmentID uint32
+	}
+	indexes := make(map[attachmentKey]int)
+	var unique []types.PostAttachment
+	for _, attachment := range attachments {
+		key := attachmentKey{attachment.SubspaceID, attachment.PostID, attachment.ID}
+		index, found := indexes[key]
+		if !found {
+			indexes[key] = len(unique)
+			unique = append(unique, attachment)
+		} else if unique[index].Height <= attachment.Height {
+			unique[index] = attachment
+		}
+	}
+
+	return inChunks(len(unique), func(start, end int) error {
+		tx, err := db.begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		var rows [][]interface{}
+		for _, attachment := range unique[start:end] {
+			contentBz, err := db.cdc.MarshalJSON(attachment.Content)
+			if err != nil {
+				return fmt.Errorf("failed to json encode attachment content: %s", err)
+			}
+
+			rows = append(rows, []interface{}{
+				attachment.SubspaceID, attachment.PostID, attachment.ID, string(contentBz), attachment.Height,
+			})
+		}
+
+		err = bulkExec(tx, `
+INSERT INTO post_attachment (post_row_id, id, content, height) 
+SELECT post.row_id, value.id, value.content, value.height
+FROM (VALUES %s) AS value (subspace_id, post_id, id, content, height)
+         JOIN post ON post.subspace_id = value.subspace_id AND post.id = value.post_id
+ON CONFLICT ON CONSTRAINT unique_post_attachment DO UPDATE 
+    SET content = excluded.content,
+        height = excluded.height
+WHERE post_attachment.height <= excluded.height`,
+			[]string{"BIGINT", "BIGINT", "BIGINT", "JSONB", "BIGINT"}, rows)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
 // DeletePostAttachment removes the given post attachment from the database
 func (db *Db) DeletePostAttachment(height int64, subspaceID uint64, postID uint64, attachmentID uint32) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	stmt := `
 DELETE FROM post_attachment WHERE post_row_id = (
 	SELECT row_id FROM post WHERE subspace_id = $1 AND id = $2
 ) AND id = $3 AND height <= $4`
-	_, err := db.SQL.Exec(stmt, subspaceID, postID, attachmentID, height)
-	return err
+	_, err = tx.Exec(stmt, subspaceID, postID, attachmentID, height)
+	if err != nil {
+		return err
+	}
+
+	// Update the poll answers count of the post
+	err = updatePostsCounters(tx, postIDCondition, subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeletePostAttachments removes all the attachments of the given post, along with their poll answers
+func (db *Db) DeletePostAttachments(height int64, subspaceID uint64, postID uint64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	stmt := `
+DELETE FROM post_attachment WHERE post_row_id = (
+	SELECT row_id FROM post WHERE subspace_id = $1 AND id = $2
+) AND height <= $3`
+	_, err = tx.Exec(stmt, subspaceID, postID, height)
+	if err != nil {
+		return err
+	}
+
+	// Update the poll answers count of the post
+	err = updatePostsCounters(tx, postIDCondition, subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -378,12 +925,193 @@ ON CONFLICT ON CONSTRAINT unique_user_answer DO UPDATE
         user_address = excluded.user_address,
         height = excluded.height
 WHERE poll_answer.height <= excluded.height`
-	_, err = db.SQL.Exec(stmt,
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(stmt,
 		attachmentRowID,
 		answer.AnswersIndexes,
 		answer.User,
 		answer.Height,
 	)
+	if err != nil {
+		return err
+	}
+
+	// Update the poll answers count of the post
+	err = updatePostsCounters(tx, postIDCondition, answer.SubspaceID, answer.PostID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SavePollAnswers stores the given answers inside the database in bulk, using a single transaction for each chunk
+func (db *Db) SavePollAnswers(answers []types.PollAnswer) error {
+	// Keep only the most recent answer of each user, since the same row cannot be updated twice
+	// by a single statement
+	type answerKey struct {
+		SubspaceID uint64
+		PostID     uint64
+		PollID     uint32
+		User       string
+	}
+	indexes := make(map[answerKey]int)
+	var unique []types.PollAnswer
+	for _, answer := range answers {
+		key := answerKey{answer.SubspaceID, answer.PostID, answer.PollID, answer.User}
+		index, found := indexes[key]
+		if !found {
+			indexes[key] = len(unique)
+			unique = append(unique, answer)
+		} else if unique[index].Height <= answer.Height {
+			unique[index] = answer
+		}
+	}
+
+	return inChunks(len(unique), func(start, end int) error {
+		tx, err := db.begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		var rows [][]interface{}
+		var subspacesIDs, postsIDs pq.Int64Array
+		for _, answer := range unique[start:end] {
+			answersIndexes := make(pq.Int64Array, len(answer.AnswersIndexes))
+			for i, index := range answer.AnswersIndexes {
+				answersIndexes[i] = int64(index)
+			}
+
+			rows = append(rows, []interface{}{
+				answer.SubspaceID, answer.PostID, answer.PollID, answersIndexes, answer.User, answer.Height,
+			})
+			subspacesIDs = append(subspacesIDs, int64(answer.SubspaceID))
+			postsIDs = append(postsIDs, int64(answer.PostID))
+		}
+
+		err = bulkExec(tx, `
+INSERT INTO poll_answer (attachment_row_id, answers_indexes, user_address, height)
+SELECT post_attachment.row_id, value.answers_indexes, value.user_address, value.height
+FROM (VALUES %s) AS value (subspace_id, post_id, poll_id, answers_indexes, user_address, height)
+         JOIN post ON post.subspace_id = value.subspace_id AND post.id = value.post_id
+         JOIN post_attachment ON post_attachment.post_row_id = post.row_id AND post_attachment.id = value.poll_id
+ON CONFLICT ON CONSTRAINT unique_user_answer DO UPDATE 
+    SET answers_indexes = excluded.answers_indexes,
+        user_address = excluded.user_address,
+        height = excluded.height
+WHERE poll_answer.height <= excluded.height`,
+			[]string{"BIGINT", "BIGINT", "BIGINT", "BIGINT[]", "TEXT", "BIGINT"}, rows)
+		if err != nil {
+			return err
+		}
+
+		// Update the poll answers count of the posts
+		err = updatePostsCounters(tx, postKeysCondition, subspacesIDs, postsIDs)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
+// GetPostContent returns the editable content of the post having the given id as currently stored inside the
+// database, or nil if the post is not stored
+func (db *Db) GetPostContent(subspaceID uint64, postID uint64) (*types.PostContent, error) {
+	var row dbtypes.PostContentRow
+	err := db.conn().Get(&row, `SELECT row_id, text, height FROM post WHERE subspace_id = $1 AND id = $2`, subspaceID, postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var hashtagRows []dbtypes.PostTextTagRow
+	stmt := `SELECT start_index, end_index, tag FROM post_hashtag WHERE post_row_id = $1 ORDER BY start_index, row_id`
+	err = db.conn().Select(&hashtagRows, stmt, row.RowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var mentionRows []dbtypes.PostTextTagRow
+	stmt = `SELECT start_index, end_index, mention_address AS tag FROM post_mention WHERE post_row_id = $1 ORDER BY start_index, row_id`
+	err = db.conn().Select(&mentionRows, stmt, row.RowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var urlRows []dbtypes.PostURLRow
+	stmt = `SELECT start_index, end_index, url, display_value FROM post_url WHERE post_row_id = $1 ORDER BY start_index, row_id`
+	err = db.conn().Select(&urlRows, stmt, row.RowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	err = db.conn().Select(&tags, `SELECT tag FROM post_tag WHERE post_row_id = $1 ORDER BY row_id`, row.RowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entities *poststypes.Entities
+	if len(hashtagRows) > 0 || len(mentionRows) > 0 || len(urlRows) > 0 {
+		entities = poststypes.NewEntities(
+			dbtypes.ToTextTags(hashtagRows),
+			dbtypes.ToTextTags(mentionRows),
+			dbtypes.ToURLs(urlRows),
+		)
+	}
+
+	content := types.NewPostContent(row.Text.String, entities, tags, row.Height)
+	return &content, nil
+}
+
+// SavePostRevision stores the given post revision inside the database
+func (db *Db) SavePostRevision(revision types.PostRevision) error {
+	postRowID, err := db.getPostRowID(revision.SubspaceID, revision.PostID)
+	if err != nil {
+		return err
+	}
+
+	if !postRowID.Valid {
+		return nil
+	}
+
+	var entitiesJSON sql.NullString
+	if revision.Entities != nil {
+		entitiesBz, err := db.cdc.MarshalJSON(revision.Entities)
+		if err != nil {
+			return fmt.Errorf("error while serializing post entities: %s", err)
+		}
+		entitiesJSON = sql.NullString{String: string(entitiesBz), Valid: true}
+	}
+
+	tags := revision.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	stmt := `
+INSERT INTO post_revision (post_row_id, text, entities, tags, height, tx_hash) 
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT ON CONSTRAINT unique_post_revision DO NOTHING`
+
+	_, err = db.conn().Exec(stmt,
+		postRowID,
+		dbtypes.ToNullString(revision.Text),
+		entitiesJSON,
+		pq.Array(tags),
+		revision.Height,
+		revision.TxHash,
+	)
 	return err
 }
 
@@ -404,7 +1132,7 @@ ON CONFLICT (one_row_id) DO UPDATE
         height = excluded.height
 WHERE posts_params.height <= excluded.height`
 
-	_, err = db.SQL.Exec(stmt, string(paramsBz), params.Height)
+	_, err = db.conn().Exec(stmt, string(paramsBz), params.Height)
 	if err != nil {
 		return fmt.Errorf("error while storing reports params: %s", err)
 	}
@@ -0,0 +1,89 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+	"github.com/lib/pq"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestSavePostRevision() {
+	author := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+
+	err := suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		author,
+		author,
+		time.Now(),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+	suite.Require().NoError(err)
+
+	original := poststypes.NewPost(
+		1,
+		0,
+		1,
+		"",
+		"Hello #desmos",
+		author,
+		0,
+		poststypes.NewEntities([]poststypes.TextTag{poststypes.NewTextTag(6, 12, "desmos")}, nil, nil),
+		[]string{"general"},
+		nil,
+		poststypes.REPLY_SETTING_EVERYONE,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+		author,
+	)
+	err = suite.database.SavePost(types.NewPost(original, 10))
+	suite.Require().NoError(err)
+
+	// Make sure the stored content is read correctly
+	content, err := suite.database.GetPostContent(1, 1)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(content)
+	suite.Require().True(content.Equal(original))
+	suite.Require().Equal(int64(10), content.Height)
+
+	edited := original
+	edited.Text = "Hello world"
+	edited.Entities = nil
+	suite.Require().False(content.Equal(edited))
+
+	// Store the revision
+	err = suite.database.SavePostRevision(types.NewPostRevision(1, 1, *content, 20, "TX_HASH"))
+	suite.Require().NoError(err)
+
+	// Storing the same revision twice should not create duplicates
+	err = suite.database.SavePostRevision(types.NewPostRevision(1, 1, *content, 20, "TX_HASH"))
+	suite.Require().NoError(err)
+
+	var rows []struct {
+		Text   string         `db:"text"`
+		Tags   pq.StringArray `db:"tags"`
+		Height int64          `db:"height"`
+		TxHash string         `db:"tx_hash"`
+	}
+	err = suite.database.SQL.Select(&rows, `SELECT text, tags, height, tx_hash FROM post_revision`)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 1)
+	suite.Require().Equal("Hello #desmos", rows[0].Text)
+	suite.Require().Equal(pq.StringArray{"general"}, rows[0].Tags)
+	suite.Require().Equal(int64(20), rows[0].Height)
+	suite.Require().Equal("TX_HASH", rows[0].TxHash)
+
+	// Non-existing posts have no content
+	content, err = suite.database.GetPostContent(1, 2)
+	suite.Require().NoError(err)
+	suite.Require().Nil(content)
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	profilestypes "github.com/desmos-labs/desmos/v7/x/profiles/types"
+	"github.com/lib/pq"
 
 	"github.com/desmos-labs/athena/v2/types"
 
@@ -29,7 +31,7 @@ ON CONFLICT (one_row_id) DO UPDATE
         height = excluded.height
 WHERE profiles_params.height <= excluded.height`
 
-	_, err = db.SQL.Exec(stmt, string(paramsBz), params.Height)
+	_, err = db.conn().Exec(stmt, string(paramsBz), params.Height)
 	if err != nil {
 		return fmt.Errorf("error while storing profiles params: %s", err)
 	}
@@ -44,7 +46,7 @@ WHERE profiles_params.height <= excluded.height`
 // If any error is raised during the process, returns that.
 func (db *Db) SaveUserIfNotExisting(address string, height int64) error {
 	stmt := `INSERT INTO profile (address, height) VALUES ($1, $2) ON CONFLICT DO NOTHING`
-	_, err := db.SQL.Exec(stmt, address, height)
+	_, err := db.conn().Exec(stmt, address, height)
 	return err
 }
 
@@ -52,7 +54,10 @@ func (db *Db) SaveUserIfNotExisting(address string, height int64) error {
 // If the user does not exist yet, returns nil instead.
 func (db *Db) GetUserByAddress(address string) (*profilestypes.Profile, error) {
 	var rows []dbtypes.ProfileRow
-	err := db.SQL.Select(&rows, `SELECT * FROM profile WHERE address = $1`, address)
+	stmt := `
+SELECT address, dtag, nickname, bio, profile_pic, cover_pic, creation_time, height 
+FROM profile WHERE address = $1 AND deletion_height IS NULL`
+	err := db.conn().Select(&rows, stmt, address)
 	if err != nil {
 		return nil, err
 	}
@@ -81,10 +86,14 @@ ON CONFLICT (address) DO UPDATE
 		profile_pic = excluded.profile_pic,
 		cover_pic = excluded.cover_pic,
 		creation_time = excluded.creation_time,
-		height = excluded.height
-WHERE profile.height <= excluded.height`
-
-	_, err := db.SQL.Exec(
+		height = excluded.height,
+		deletion_height = NULL,
+		deletion_tx_hash = NULL,
+		deletion_time = NULL
+WHERE profile.height <= excluded.height
+  AND (profile.deletion_height IS NULL OR profile.deletion_height <= excluded.height)`
+
+	_, err := db.conn().Exec(
 		stmt,
 		profile.GetAddress().String(), profile.Nickname, profile.DTag, profile.Bio,
 		profile.Pictures.Profile, profile.Pictures.Cover, profile.CreationDate,
@@ -93,17 +102,79 @@ WHERE profile.height <= excluded.height`
 	return err
 }
 
+// SaveProfiles stores the given profiles inside the database in bulk, using a single transaction for each chunk
+func (db *Db) SaveProfiles(profiles []*types.Profile) error {
+	return inChunks(len(profiles), func(start, end int) error {
+		// Remove the duplicated profiles, keeping the last version of each one
+		indexes := map[string]int{}
+		var rows [][]interface{}
+		for _, profile := range profiles[start:end] {
+			row := []interface{}{
+				profile.GetAddress().String(), profile.Nickname, profile.DTag, profile.Bio,
+				profile.Pictures.Profile, profile.Pictures.Cover, profile.CreationDate,
+				profile.Height,
+			}
+
+			if index, found := indexes[profile.GetAddress().String()]; found {
+				rows[index] = row
+				continue
+			}
+			indexes[profile.GetAddress().String()] = len(rows)
+			rows = append(rows, row)
+		}
+
+		tx, err := db.begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		stmt := `
+INSERT INTO profile (address, nickname, dtag, bio, profile_pic, cover_pic, creation_time, height) 
+VALUES %s
+ON CONFLICT (address) DO UPDATE 
+	SET address = excluded.address, 
+		nickname = excluded.nickname, 
+		dtag = excluded.dtag,
+		bio = excluded.bio,
+		profile_pic = excluded.profile_pic,
+		cover_pic = excluded.cover_pic,
+		creation_time = excluded.creation_time,
+		height = excluded.height,
+		deletion_height = NULL,
+		deletion_tx_hash = NULL,
+		deletion_time = NULL
+WHERE profile.height <= excluded.height
+  AND (profile.deletion_height IS NULL OR profile.deletion_height <= excluded.height)`
+		err = bulkExec(tx, stmt, []string{"TEXT", "TEXT", "TEXT", "TEXT", "TEXT", "TEXT", "TIMESTAMP", "BIGINT"}, rows)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	})
+}
+
 // DeleteProfile allows to delete the profile of the user having the given address
 func (db *Db) DeleteProfile(address string, height int64) error {
 	stmt := `DELETE FROM profile WHERE address = $1 AND height <= $2`
-	_, err := db.SQL.Exec(stmt, address, height)
+	return db.execDelete(height, stmt, address, height)
+}
+
+// TombstoneProfile marks the profile of the user having the given address as deleted at the given height and
+// block time by the transaction having the given hash, without removing it from the database
+func (db *Db) TombstoneProfile(address string, height int64, txHash string, timestamp time.Time) error {
+	stmt := `
+UPDATE profile SET deletion_height = $2, deletion_tx_hash = $3, deletion_time = $4
+WHERE address = $1 AND height <= $2 AND deletion_height IS NULL`
+	_, err := db.conn().Exec(stmt, address, height, txHash, timestamp)
 	return err
 }
 
 // GetProfilesAddresses returns all the addresses of the various profiles accounts
 func (db *Db) GetProfilesAddresses() ([]string, error) {
 	var rows []string
-	err := db.SQL.Select(&rows, `SELECT address FROM profile`)
+	err := db.conn().Select(&rows, `SELECT address FROM profile WHERE deletion_height IS NULL`)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +193,7 @@ ON CONFLICT ON CONSTRAINT unique_request DO UPDATE
     	receiver_address = excluded.receiver_address
 WHERE dtag_transfer_requests.height <= excluded.height`
 
-	_, err := db.SQL.Exec(stmt, request.Sender, request.Receiver, request.Height)
+	_, err := db.conn().Exec(stmt, request.Sender, request.Receiver, request.Height)
 	return err
 }
 
@@ -131,8 +202,7 @@ func (db *Db) DeleteDTagTransferRequest(request types.DTagTransferRequest) error
 	stmt := `
 DELETE FROM dtag_transfer_requests 
 WHERE sender_address = $1 AND receiver_address = $2 AND height <= $3`
-	_, err := db.SQL.Exec(stmt, request.Sender, request.Receiver, request.Height)
-	return err
+	return db.execDelete(request.Height, stmt, request.Sender, request.Receiver, request.Height)
 }
 
 // ---------------------------------------------------------------------------------------------------
@@ -140,7 +210,7 @@ WHERE sender_address = $1 AND receiver_address = $2 AND height <= $3`
 // SaveChainLink allows to store inside the db the provided chain link
 func (db *Db) SaveChainLink(link types.ChainLink) error {
 	// Use a single transaction for the whole process
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
@@ -203,12 +273,12 @@ func (db *Db) getChainLinkID(userAddress string, chainConfigID int64, externalAd
 	stmt := `SELECT id from chain_link WHERE user_address = $1 AND chain_config_id = $2 AND external_address = $3`
 
 	var id int64
-	err := db.SQL.QueryRow(stmt, userAddress, chainConfigID, externalAddress).Scan(&id)
+	err := db.conn().QueryRow(stmt, userAddress, chainConfigID, externalAddress).Scan(&id)
 	return id, err
 }
 
 // saveChainLinkProof stores the given proof as associated with the chain link having the given id
-func (db *Db) saveChainLinkProof(tx *sql.Tx, chainLinkID int64, proof profilestypes.Proof, height int64) error {
+func (db *Db) saveChainLinkProof(tx sqlTx, chainLinkID int64, proof profilestypes.Proof, height int64) error {
 	publicKeyBz, err := db.cdc.MarshalJSON(proof.PubKey)
 	if err != nil {
 		return fmt.Errorf("error serializing chain link proof public key: %s", err)
@@ -242,7 +312,7 @@ WHERE chain_link_proof.height <= excluded.height`
 }
 
 // saveChainLinkChainConfig stores the given chain config and returns the row id
-func (db *Db) saveChainLinkChainConfig(tx *sql.Tx, config profilestypes.ChainConfig) (int64, error) {
+func (db *Db) saveChainLinkChainConfig(tx sqlTx, config profilestypes.ChainConfig) (int64, error) {
 	stmt := `
 INSERT INTO chain_link_chain_config (name) 
 VALUES ($1)
@@ -260,19 +330,24 @@ func (db *Db) getChainLinkConfigID(name string) (int64, error) {
 	stmt := `SELECT id FROM chain_link_chain_config WHERE name = $1`
 
 	var id int64
-	err := db.SQL.QueryRow(stmt, name).Scan(&id)
+	err := db.conn().QueryRow(stmt, name).Scan(&id)
 	return id, err
 }
 
 // DeleteChainLink removes from the database the chain link made for the given user and having the provided
 // external address and linked to the chain with the given name
 func (db *Db) DeleteChainLink(user string, externalAddress string, chainName string, height int64) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	// Delete the chain link
 	stmt := `
 DELETE FROM chain_link 
@@ -302,12 +377,17 @@ DO UPDATE SET chain_links_count = profile_counters.chain_links_count - 1;
 
 // DeleteAllChainLinks deletes all the chain links having a height lower than the given one
 func (db *Db) DeleteAllChainLinks(height int64) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	// Delete the chain links
 	stmt := `DELETE FROM chain_link WHERE height <= $1`
 	_, err = tx.Exec(stmt, height)
@@ -325,6 +405,40 @@ func (db *Db) DeleteAllChainLinks(height int64) error {
 	return tx.Commit()
 }
 
+// DeleteUserChainLinks deletes all the chain links of the given user having a height lower than the given one.
+// The default chain links of the user are deleted along with them.
+func (db *Db) DeleteUserChainLinks(user string, height int64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	// Delete the chain links
+	stmt := `DELETE FROM chain_link WHERE user_address = $1 AND height <= $2`
+	_, err = tx.Exec(stmt, user, height)
+	if err != nil {
+		return err
+	}
+
+	// Update the chain links count of the user
+	stmt = `
+UPDATE profile_counters 
+SET chain_links_count = (SELECT COUNT(*) FROM chain_link WHERE user_address = $1) 
+WHERE profile_address = $1`
+	_, err = tx.Exec(stmt, user)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // SaveDefaultChainLink saves the given chain link as a default chain link
 func (db *Db) SaveDefaultChainLink(chainLink types.ChainLink) error {
 	stmt := `
@@ -352,15 +466,14 @@ WHERE default_chain_link.height <= excluded.height`
 		return err
 	}
 
-	_, err = db.SQL.Exec(stmt, chainLink.User, chainLinkID, chainLinkConfigID, chainLink.Height)
+	_, err = db.conn().Exec(stmt, chainLink.User, chainLinkID, chainLinkConfigID, chainLink.Height)
 	return err
 }
 
 // DeleteAllDefaultChainLinks removes all default chain links having a height lower than the one specified
 func (db *Db) DeleteAllDefaultChainLinks(height int64) error {
 	stmt := `DELETE FROM default_chain_link WHERE height <= $1`
-	_, err := db.SQL.Exec(stmt, height)
-	return err
+	return db.execDelete(height, stmt, height)
 }
 
 // ---------------------------------------------------------------------------------------------------
@@ -371,7 +484,7 @@ func (db *Db) getApplicationLinkRowID(address string, application string, userna
 	stmt := `SELECT id FROM application_link WHERE user_address = $1 AND application ILIKE $2 AND username ILIKE $3`
 
 	var rowID int64
-	err := db.SQL.QueryRow(stmt, address, application, username).Scan(&rowID)
+	err := db.conn().QueryRow(stmt, address, application, username).Scan(&rowID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return sql.NullInt64{Int64: 0, Valid: false}, nil
 	}
@@ -382,7 +495,7 @@ func (db *Db) getApplicationLinkRowID(address string, application string, userna
 // SaveApplicationLink stores the given application link inside the database
 func (db *Db) SaveApplicationLink(link types.ApplicationLink) error {
 	// Use a single transaction for the whole process
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
@@ -450,7 +563,7 @@ DO UPDATE SET application_links_count = profile_counters.application_links_count
 }
 
 // saveOracleRequest stores the given oracle request associating it with the link having the provided id
-func (db *Db) saveOracleRequest(tx *sql.Tx, linkID int64, request profilestypes.OracleRequest, height int64) error {
+func (db *Db) saveOracleRequest(tx sqlTx, linkID int64, request profilestypes.OracleRequest, height int64) error {
 	stmt := `
 INSERT INTO application_link_oracle_request (application_link_id, request_id, script_id, call_data, client_id, height) 
 VALUES ($1, $2, $3, $4, $5, $6)
@@ -490,7 +603,7 @@ func (db *Db) GetApplicationLinkInfos() ([]types.ApplicationLinkInfo, error) {
 	stmt := `SELECT user_address, application, username FROM application_link`
 
 	var rows []applicationLinkInfo
-	err := db.SQL.Select(&rows, stmt)
+	err := db.conn().Select(&rows, stmt)
 	if err != nil {
 		return nil, err
 	}
@@ -505,12 +618,17 @@ func (db *Db) GetApplicationLinkInfos() ([]types.ApplicationLinkInfo, error) {
 // DeleteApplicationLink allows to delete the application link associated to the given user,
 // having the given application and username values
 func (db *Db) DeleteApplicationLink(user, application, username string, height int64) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	// Delete the link
 	stmt := `
 DELETE FROM application_link 
@@ -540,12 +658,17 @@ DO UPDATE SET application_links_count = profile_counters.application_links_count
 
 // DeleteAllApplicationLinks deletes all the application links that have a height equal or lower to the one given
 func (db *Db) DeleteAllApplicationLinks(height int64) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	// Delete the application links
 	stmt := `DELETE FROM application_link WHERE height <= $1`
 	_, err = tx.Exec(stmt, height)
@@ -562,3 +685,48 @@ func (db *Db) DeleteAllApplicationLinks(height int64) error {
 
 	return tx.Commit()
 }
+
+// DeleteUserApplicationLinks deletes all the application links of the given user having a height lower than the
+// given one, except the given ones. This allows to keep the scores of the links that still exist.
+func (db *Db) DeleteUserApplicationLinks(user string, kept []types.ApplicationLink, height int64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	applications := make([]string, len(kept))
+	usernames := make([]string, len(kept))
+	for i, link := range kept {
+		applications[i] = link.Data.Application
+		usernames[i] = link.Data.Username
+	}
+
+	// Delete the application links
+	stmt := `
+DELETE FROM application_link 
+WHERE user_address = $1 
+  AND height <= $2 
+  AND (application, username) NOT IN (SELECT * FROM unnest($3::TEXT[], $4::TEXT[]))`
+	_, err = tx.Exec(stmt, user, height, pq.Array(applications), pq.Array(usernames))
+	if err != nil {
+		return err
+	}
+
+	// Update the application links count of the user
+	stmt = `
+UPDATE profile_counters 
+SET application_links_count = (SELECT COUNT(*) FROM application_link WHERE user_address = $1) 
+WHERE profile_address = $1`
+	_, err = tx.Exec(stmt, user)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/desmos-labs/athena/v2/types"
 )
@@ -39,6 +40,120 @@ ON CONFLICT (application_link_row_id) DO UPDATE
         score = excluded.score,
         timestamp = excluded.timestamp
 WHERE application_link_score.timestamp <= excluded.timestamp`
-	_, err = db.SQL.Exec(stmt, applicationLinkRowID, string(detailsBz), scoreValue, score.Timestamp)
+	_, err = db.conn().Exec(stmt, applicationLinkRowID, string(detailsBz), scoreValue, score.Timestamp)
+	return err
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+type profileScoreDataRow struct {
+	Address             string     `db:"address"`
+	CreationTime        time.Time  `db:"creation_time"`
+	ChainLinksCount     uint64     `db:"chain_links_count"`
+	OldestChainLinkTime *time.Time `db:"oldest_chain_link_time"`
+	ActivityCount       uint64     `db:"activity_count"`
+}
+
+type applicationScoreRow struct {
+	Address     string `db:"user_address"`
+	Application string `db:"application"`
+	Score       uint64 `db:"score"`
+}
+
+// GetProfilesScoreData returns the data used to compute the aggregated score of all the profiles
+// that have not been deleted
+func (db *Db) GetProfilesScoreData() ([]types.ProfileScoreData, error) {
+	stmt := `
+SELECT profile.address,
+       profile.creation_time,
+       COALESCE(chain_links.count, 0)                          AS chain_links_count,
+       chain_links.oldest_creation_time                        AS oldest_chain_link_time,
+       COALESCE(posts.count, 0) + COALESCE(reactions.count, 0) AS activity_count
+FROM profile
+         LEFT JOIN (SELECT user_address, COUNT(*) AS count, MIN(creation_time) AS oldest_creation_time
+                    FROM chain_link
+                    GROUP BY user_address) chain_links ON chain_links.user_address = profile.address
+         LEFT JOIN (SELECT author_address, COUNT(*) AS count
+                    FROM post
+                    WHERE deletion_height IS NULL
+                    GROUP BY author_address) posts ON posts.author_address = profile.address
+         LEFT JOIN (SELECT author_address, COUNT(*) AS count
+                    FROM reaction
+                    WHERE deletion_height IS NULL
+                    GROUP BY author_address) reactions ON reactions.author_address = profile.address
+WHERE profile.deletion_height IS NULL
+ORDER BY profile.address`
+
+	var rows []profileScoreDataRow
+	err := db.conn().Select(&rows, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the highest score of each application for all the profiles, considering only the links that have been
+	// verified successfully and are not expired
+	stmt = `
+SELECT application_link.user_address,
+       LOWER(application_link.application) AS application,
+       MAX(application_link_score.score)   AS score
+FROM application_link_score
+         JOIN application_link ON application_link.id = application_link_score.application_link_row_id
+WHERE application_link.state = 'APPLICATION_LINK_STATE_VERIFICATION_SUCCESS'
+  AND application_link.expiration_time > NOW() AT TIME ZONE 'UTC'
+GROUP BY application_link.user_address, LOWER(application_link.application)`
+
+	var scoreRows []applicationScoreRow
+	err = db.conn().Select(&scoreRows, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	applicationsScores := map[string]map[string]uint64{}
+	for _, row := range scoreRows {
+		if applicationsScores[row.Address] == nil {
+			applicationsScores[row.Address] = map[string]uint64{}
+		}
+		applicationsScores[row.Address][row.Application] = row.Score
+	}
+
+	data := make([]types.ProfileScoreData, len(rows))
+	for i, row := range rows {
+		data[i] = types.NewProfileScoreData(
+			row.Address,
+			row.CreationTime,
+			applicationsScores[row.Address],
+			row.ChainLinksCount,
+			row.OldestChainLinkTime,
+			row.ActivityCount,
+		)
+	}
+	return data, nil
+}
+
+// SaveProfileScore stores the given aggregated profile score inside the database
+func (db *Db) SaveProfileScore(score types.AggregatedProfileScore) error {
+	stmt := `
+INSERT INTO profile_score (profile_address, score, applications_score, chain_links_score, chain_links_age_score,
+                           profile_age_score, activity_score, timestamp)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (profile_address) DO UPDATE 
+    SET score = excluded.score,
+        applications_score = excluded.applications_score,
+        chain_links_score = excluded.chain_links_score,
+        chain_links_age_score = excluded.chain_links_age_score,
+        profile_age_score = excluded.profile_age_score,
+        activity_score = excluded.activity_score,
+        timestamp = excluded.timestamp
+WHERE profile_score.timestamp <= excluded.timestamp`
+	_, err := db.conn().Exec(stmt,
+		score.DesmosAddress,
+		score.Score,
+		score.ApplicationsScore,
+		score.ChainLinksScore,
+		score.ChainLinksAgeScore,
+		score.ProfileAgeScore,
+		score.ActivityScore,
+		score.Timestamp,
+	)
 	return err
 }
@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"time"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestGetProfilesScoreData() {
+	user := "cosmos1y54exmx84cqtasvjnskf9f63djuuj68p7hqf47"
+	deletedUser := "cosmos10clxpupsmddtj7wu7g0wdysajqwp890mva046f"
+
+	_, err := suite.database.SQL.Exec(`INSERT INTO chain_link_chain_config (id, name) VALUES (1, 'cosmos')`)
+	suite.Require().NoError(err)
+
+	statements := []string{
+		`INSERT INTO profile (address, creation_time, height, deletion_height)
+VALUES ($1, '2023-01-01', 1, NULL),
+       ($2, '2023-01-01', 1, 2)`,
+		`INSERT INTO chain_link (user_address, external_address, chain_config_id, creation_time, height)
+VALUES ($1, 'cosmos1external1', 1, '2023-02-01', 1),
+       ($1, 'cosmos1external2', 1, '2023-03-01', 1),
+       ($2, 'cosmos1external3', 1, '2023-03-01', 1)`,
+		`INSERT INTO application_link (id, user_address, application, username, state, creation_time, expiration_time, height)
+VALUES (1, $1, 'GitHub', 'first', 'APPLICATION_LINK_STATE_VERIFICATION_SUCCESS', NOW(), NOW() + INTERVAL '1 day', 1),
+       (2, $1, 'github', 'second', 'APPLICATION_LINK_STATE_VERIFICATION_SUCCESS', NOW(), NOW() + INTERVAL '1 day', 1),
+       (3, $1, 'twitter', 'user', 'APPLICATION_LINK_STATE_VERIFICATION_SUCCESS', NOW(), NOW() + INTERVAL '1 day', 1),
+       (4, $2, 'twitter', 'deleted', 'APPLICATION_LINK_STATE_VERIFICATION_SUCCESS', NOW(), NOW() + INTERVAL '1 day', 1),
+       (5, $1, 'youtube', 'expired', 'APPLICATION_LINK_STATE_VERIFICATION_SUCCESS', NOW(), NOW() - INTERVAL '1 day', 1),
+       (6, $1, 'twitch', 'failed', 'APPLICATION_LINK_STATE_VERIFICATION_ERROR', NOW(), NOW() + INTERVAL '1 day', 1)`,
+	}
+	for _, stmt := range statements {
+		_, err = suite.database.SQL.Exec(stmt, user, deletedUser)
+		suite.Require().NoError(err)
+	}
+
+	_, err = suite.database.SQL.Exec(`
+INSERT INTO application_link_score (application_link_row_id, details, score, timestamp)
+VALUES (1, '{}', 40, NOW()),
+       (2, '{}', 60, NOW()),
+       (3, '{}', 30, NOW()),
+       (4, '{}', 90, NOW()),
+       (5, '{}', 80, NOW()),
+       (6, '{}', 70, NOW())`)
+	suite.Require().NoError(err)
+
+	data, err := suite.database.GetProfilesScoreData()
+	suite.Require().NoError(err)
+	suite.Require().Len(data, 1)
+
+	// Make sure deleted profiles, expired links and links that have not been verified are excluded,
+	// and the highest score of each application is returned
+	suite.Require().Equal(user, data[0].DesmosAddress)
+	suite.Require().True(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).Equal(data[0].CreationTime))
+	suite.Require().Equal(map[string]uint64{"github": 60, "twitter": 30}, data[0].ApplicationsScores)
+	suite.Require().Equal(uint64(2), data[0].ChainLinksCount)
+	suite.Require().NotNil(data[0].OldestChainLinkTime)
+	suite.Require().True(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC).Equal(*data[0].OldestChainLinkTime))
+	suite.Require().Equal(uint64(0), data[0].ActivityCount)
+}
+
+func (suite *DbTestSuite) TestSaveProfileScore() {
+	user := "cosmos1y54exmx84cqtasvjnskf9f63djuuj68p7hqf47"
+	err := suite.database.SaveUserIfNotExisting(user, 1)
+	suite.Require().NoError(err)
+
+	score := types.AggregatedProfileScore{
+		DesmosAddress:      user,
+		Score:              50,
+		ApplicationsScore:  10,
+		ChainLinksScore:    20,
+		ChainLinksAgeScore: 30,
+		ProfileAgeScore:    40,
+		ActivityScore:      100,
+		Timestamp:          time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	suite.Require().NoError(suite.database.SaveProfileScore(score))
+
+	// Make sure older scores do not replace the newer ones
+	olderScore := score
+	olderScore.Score = 10
+	olderScore.Timestamp = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.database.SaveProfileScore(olderScore))
+
+	var rows []struct {
+		Score         uint64    `db:"score"`
+		ActivityScore uint64    `db:"activity_score"`
+		Timestamp     time.Time `db:"timestamp"`
+	}
+	err = suite.database.SQL.Select(&rows, `SELECT score, activity_score, timestamp FROM profile_score`)
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 1)
+	suite.Require().Equal(uint64(50), rows[0].Score)
+	suite.Require().Equal(uint64(100), rows[0].ActivityScore)
+	suite.Require().True(score.Timestamp.Equal(rows[0].Timestamp))
+}
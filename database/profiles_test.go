@@ -522,3 +522,69 @@ func (suite *DbTestSuite) TestDeleteApplicationLink() {
 		})
 	}
 }
+
+func (suite *DbTestSuite) TestDeleteUserApplicationLinks() {
+	user := "cosmos1y54exmx84cqtasvjnskf9f63djuuj68p7hqf47"
+	err := suite.database.SaveUserIfNotExisting(user, 1)
+	suite.Require().NoError(err)
+
+	var links []types.ApplicationLink
+	for _, username := range []string{"twitteruser", "otheruser"} {
+		link := types.NewApplicationLink(
+			profilestypes.NewApplicationLink(
+				user,
+				profilestypes.NewData("twitter", username),
+				profilestypes.ApplicationLinkStateInitialized,
+				profilestypes.NewOracleRequest(
+					0,
+					1,
+					profilestypes.NewOracleRequestCallData("twitter", "call_data"),
+					"client_id",
+				),
+				nil,
+				time.Date(2020, 1, 1, 00, 00, 00, 000, time.UTC),
+				time.Date(2021, 1, 1, 00, 00, 00, 000, time.UTC),
+			),
+			100,
+		)
+
+		err = suite.database.SaveApplicationLink(link)
+		suite.Require().NoError(err)
+		links = append(links, link)
+	}
+
+	_, err = suite.database.SQL.Exec(`
+INSERT INTO application_link_score (application_link_row_id, details, score, timestamp)
+SELECT id, '{}', 10, NOW() FROM application_link WHERE username = 'twitteruser'`)
+	suite.Require().NoError(err)
+
+	// Delete all the links except the one having a score
+	err = suite.database.DeleteUserApplicationLinks(user, links[:1], 100)
+	suite.Require().NoError(err)
+
+	var usernames []string
+	err = suite.database.SQL.Select(&usernames, `SELECT username FROM application_link`)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{"twitteruser"}, usernames)
+
+	// Make sure the score of the kept link has not been deleted
+	var scoresCount int
+	err = suite.database.SQL.Get(&scoresCount, `SELECT COUNT(*) FROM application_link_score`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, scoresCount)
+
+	// Make sure the application links count has been updated
+	var applicationLinkCount int
+	err = suite.database.SQL.Get(&applicationLinkCount, "SELECT application_links_count FROM profile_counters WHERE profile_address = $1", user)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, applicationLinkCount)
+
+	// Make sure all the links are deleted when none is kept
+	err = suite.database.DeleteUserApplicationLinks(user, nil, 100)
+	suite.Require().NoError(err)
+
+	var linksCount int
+	err = suite.database.SQL.Get(&linksCount, `SELECT COUNT(*) FROM application_link`)
+	suite.Require().NoError(err)
+	suite.Require().Zero(linksCount)
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/desmos-labs/athena/v2/types"
 )
@@ -21,42 +22,162 @@ ON CONFLICT ON CONSTRAINT unique_post_reaction DO UPDATE
         id = excluded.id, 
         value = excluded.value,
         author_address = excluded.author_address,
-        height = excluded.height
-WHERE reaction.height <= excluded.height`
+        height = excluded.height,
+        deletion_height = NULL,
+        deletion_tx_hash = NULL,
+        deletion_time = NULL
+WHERE reaction.height <= excluded.height
+  AND (reaction.deletion_height IS NULL OR reaction.deletion_height <= excluded.height)`
 
 	valueBz, err := db.cdc.MarshalJSON(reaction.Value)
 	if err != nil {
 		return fmt.Errorf("failed to json encode reaction value: %s", err)
 	}
 
-	_, err = db.SQL.Exec(stmt,
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(stmt,
 		postRowID,
 		reaction.ID,
 		string(valueBz),
 		reaction.Author,
 		reaction.Height,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Update the reactions counters of the post
+	err = updatePostsCounters(tx, postIDCondition, reaction.SubspaceID, reaction.PostID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // DeleteReaction removes the given reaction from the database
 func (db *Db) DeleteReaction(height int64, subspaceID uint64, postID uint64, reactionID uint32) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	stmt := `
 DELETE FROM reaction WHERE post_row_id = (
 	SELECT row_id FROM post WHERE subspace_id = $1 AND id = $2
 ) AND id = $3 AND height <= $4`
-	_, err := db.SQL.Exec(stmt, subspaceID, postID, reactionID, height)
-	return err
+	_, err = tx.Exec(stmt, subspaceID, postID, reactionID, height)
+	if err != nil {
+		return err
+	}
+
+	// Update the reactions counters of the post
+	err = updatePostsCounters(tx, postIDCondition, subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // DeleteAllReactions removes all the reactions from the database
 func (db *Db) DeleteAllReactions(height int64, subspaceID uint64, postID uint64) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	stmt := `
 DELETE FROM reaction WHERE post_row_id = (
 	SELECT row_id FROM post WHERE subspace_id = $1 AND id = $2    
 ) AND height <= $3`
-	_, err := db.SQL.Exec(stmt, subspaceID, postID, height)
-	return err
+	_, err = tx.Exec(stmt, subspaceID, postID, height)
+	if err != nil {
+		return err
+	}
+
+	// Update the reactions counters of the post
+	err = updatePostsCounters(tx, postIDCondition, subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TombstoneReaction marks the given reaction as deleted at the given height and block time by the transaction
+// having the given hash, without removing it from the database
+func (db *Db) TombstoneReaction(
+	height int64, subspaceID uint64, postID uint64, reactionID uint32, txHash string, timestamp time.Time,
+) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `
+UPDATE reaction SET deletion_height = $4, deletion_tx_hash = $5, deletion_time = $6
+WHERE post_row_id = (
+	SELECT row_id FROM post WHERE subspace_id = $1 AND id = $2
+) AND id = $3 AND height <= $4 AND deletion_height IS NULL`
+	_, err = tx.Exec(stmt, subspaceID, postID, reactionID, height, txHash, timestamp)
+	if err != nil {
+		return err
+	}
+
+	// Update the reactions counters of the post
+	err = updatePostsCounters(tx, postIDCondition, subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// TombstoneAllReactions marks all the reactions of the given post as deleted at the given height and block time,
+// without removing them from the database. Reactions that are stored again later at the same height are restored.
+func (db *Db) TombstoneAllReactions(height int64, subspaceID uint64, postID uint64, timestamp time.Time) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `
+UPDATE reaction SET deletion_height = $3, deletion_time = $4
+WHERE post_row_id = (
+	SELECT row_id FROM post WHERE subspace_id = $1 AND id = $2
+) AND height <= $3 AND deletion_height IS NULL`
+	_, err = tx.Exec(stmt, subspaceID, postID, height, timestamp)
+	if err != nil {
+		return err
+	}
+
+	// Update the reactions counters of the post
+	err = updatePostsCounters(tx, postIDCondition, subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -72,7 +193,7 @@ ON CONFLICT ON CONSTRAINT unique_subspace_registered_reaction DO UPDATE
         height = excluded.height
 WHERE subspace_registered_reaction.height <= excluded.height`
 
-	_, err := db.SQL.Exec(stmt,
+	_, err := db.conn().Exec(stmt,
 		reaction.SubspaceID,
 		reaction.ID,
 		reaction.ShorthandCode,
@@ -85,14 +206,12 @@ WHERE subspace_registered_reaction.height <= excluded.height`
 // DeleteRegisteredReaction removes the given registered reaction from the database
 func (db *Db) DeleteRegisteredReaction(height int64, subspaceID uint64, reactionID uint32) error {
 	stmt := `DELETE FROM subspace_registered_reaction WHERE subspace_id = $1 AND id = $2 AND height <= $3`
-	_, err := db.SQL.Exec(stmt, subspaceID, reactionID, height)
-	return err
+	return db.execDelete(height, stmt, subspaceID, reactionID, height)
 }
 
 func (db *Db) DeleteAllRegisteredReactions(height int64, subspaceID uint64) error {
 	stmt := `DELETE FROM subspace_registered_reaction WHERE subspace_id = $1 AND height <= $2`
-	_, err := db.SQL.Exec(stmt, subspaceID, height)
-	return err
+	return db.execDelete(height, stmt, subspaceID, height)
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -108,7 +227,7 @@ ON CONFLICT (subspace_id) DO UPDATE
         height = excluded.height
 WHERE subspace_registered_reaction_params.height <= excluded.height`
 
-	_, err := db.SQL.Exec(stmt, params.SubspaceID, params.RegisteredReaction.Enabled, params.Height)
+	_, err := db.conn().Exec(stmt, params.SubspaceID, params.RegisteredReaction.Enabled, params.Height)
 	if err != nil {
 		return err
 	}
@@ -124,7 +243,7 @@ ON CONFLICT (subspace_id) DO UPDATE
         height = excluded.height
 WHERE subspace_free_text_params.height <= excluded.height`
 
-	_, err = db.SQL.Exec(stmt,
+	_, err = db.conn().Exec(stmt,
 		params.SubspaceID,
 		params.FreeText.Enabled,
 		params.FreeText.MaxLength,
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/lib/pq"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// SaveRefreshCheckpoint stores the given checkpoint, marking its unit as completed
+func (db *Db) SaveRefreshCheckpoint(checkpoint types.RefreshCheckpoint) error {
+	stmt := `
+INSERT INTO refresh_checkpoint (module, subspace_id, post_id, height, completed_at) 
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (module, subspace_id, post_id) DO UPDATE 
+    SET height = excluded.height,
+        completed_at = excluded.completed_at`
+	_, err := db.conn().Exec(stmt, checkpoint.Module, checkpoint.SubspaceID, checkpoint.PostID, checkpoint.Height)
+	return err
+}
+
+// GetRefreshCheckpoints returns all the checkpoints stored for the given modules
+func (db *Db) GetRefreshCheckpoints(modules []string) ([]types.RefreshCheckpoint, error) {
+	stmt := `SELECT module, subspace_id, post_id, height FROM refresh_checkpoint WHERE module = ANY($1)`
+
+	var rows []struct {
+		Module     string `db:"module"`
+		SubspaceID uint64 `db:"subspace_id"`
+		PostID     uint64 `db:"post_id"`
+		Height     int64  `db:"height"`
+	}
+	err := db.conn().Select(&rows, stmt, pq.Array(modules))
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoints := make([]types.RefreshCheckpoint, len(rows))
+	for i, row := range rows {
+		checkpoints[i] = types.NewRefreshCheckpoint(row.Module, row.SubspaceID, row.PostID, row.Height)
+	}
+
+	return checkpoints, nil
+}
+
+// DeleteRefreshCheckpoints removes all the checkpoints stored for the given modules
+func (db *Db) DeleteRefreshCheckpoints(modules []string) error {
+	_, err := db.conn().Exec(`DELETE FROM refresh_checkpoint WHERE module = ANY($1)`, pq.Array(modules))
+	return err
+}
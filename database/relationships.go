@@ -6,7 +6,7 @@ import (
 
 // SaveRelationship allows to save a relationship between the sender and receiver on the given subspace
 func (db *Db) SaveRelationship(relationship types.Relationship) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
@@ -44,12 +44,17 @@ DO UPDATE SET relationships_count = profile_counters.relationships_count + 1;
 
 // DeleteRelationship allows to delete the relationship between the given sender and receiver on the specified subspace
 func (db *Db) DeleteRelationship(relationship types.Relationship) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, relationship.Height)
+	if err != nil {
+		return err
+	}
+
 	// Delete the relationship
 	stmt := `
 DELETE FROM user_relationship 
@@ -76,12 +81,17 @@ DO UPDATE SET relationships_count = profile_counters.relationships_count - 1;
 
 // DeleteAllRelationships allows to delete all the relationships associated with the given subspace from the database
 func (db *Db) DeleteAllRelationships(height int64, subspaceID uint64) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	// Delete all the relationships
 	stmt := `DELETE FROM user_relationship WHERE subspace_id = $1 AND height <= $2`
 	_, err = tx.Exec(stmt, subspaceID, height)
@@ -99,11 +109,44 @@ func (db *Db) DeleteAllRelationships(height int64, subspaceID uint64) error {
 	return tx.Commit()
 }
 
+// DeleteUserRelationships deletes all the relationships created by the given user inside the given subspace
+func (db *Db) DeleteUserRelationships(height int64, subspaceID uint64, user string) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	// Delete the relationships
+	stmt := `DELETE FROM user_relationship WHERE creator_address = $1 AND subspace_id = $2 AND height <= $3`
+	_, err = tx.Exec(stmt, user, subspaceID, height)
+	if err != nil {
+		return err
+	}
+
+	// Update the relationships count of the user
+	stmt = `
+UPDATE profile_counters 
+SET relationships_count = (SELECT COUNT(*) FROM user_relationship WHERE creator_address = $1) 
+WHERE profile_address = $1`
+	_, err = tx.Exec(stmt, user)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // ---------------------------------------------------------------------------------------------------
 
 // SaveUserBlock allows to save a user blockage
 func (db *Db) SaveUserBlock(block types.Blockage) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
@@ -141,12 +184,17 @@ DO UPDATE SET blocks_count = profile_counters.blocks_count + 1;
 
 // DeleteBlockage allow to remove a previously saved user blockage
 func (db *Db) DeleteBlockage(block types.Blockage) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, block.Height)
+	if err != nil {
+		return err
+	}
+
 	// Delete the blockage
 	stmt := `
 DELETE FROM user_block 
@@ -173,12 +221,17 @@ DO UPDATE SET blocks_count = profile_counters.blocks_count - 1;
 
 // DeleteAllUserBlocks allows to delete all the user blocks associated with the given subspace from the database
 func (db *Db) DeleteAllUserBlocks(height int64, subspaceID uint64) error {
-	tx, err := db.SQL.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
 	// Delete all the user blocks
 	stmt := `DELETE FROM user_block WHERE subspace_id = $1 AND height <= $2`
 	_, err = tx.Exec(stmt, subspaceID, height)
@@ -195,3 +248,36 @@ func (db *Db) DeleteAllUserBlocks(height int64, subspaceID uint64) error {
 
 	return tx.Commit()
 }
+
+// DeleteUserBlocks deletes all the user blocks created by the given blocker inside the given subspace
+func (db *Db) DeleteUserBlocks(height int64, subspaceID uint64, blocker string) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setChangeHeight(tx, height)
+	if err != nil {
+		return err
+	}
+
+	// Delete the user blocks
+	stmt := `DELETE FROM user_block WHERE blocker_address = $1 AND subspace_id = $2 AND height <= $3`
+	_, err = tx.Exec(stmt, blocker, subspaceID, height)
+	if err != nil {
+		return err
+	}
+
+	// Update the blocks count of the blocker
+	stmt = `
+UPDATE profile_counters 
+SET blocks_count = (SELECT COUNT(*) FROM user_block WHERE blocker_address = $1) 
+WHERE profile_address = $1`
+	_, err = tx.Exec(stmt, blocker)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
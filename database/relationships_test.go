@@ -175,6 +175,49 @@ func (suite *DbTestSuite) TestDeleteRelationship() {
 	}
 }
 
+func (suite *DbTestSuite) TestDeleteUserRelationships() {
+	creator := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	counterparty := "cosmos1u0gz4g865yjadxm2hsst388c462agdz7araedr"
+
+	err := suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		creator,
+		creator,
+		time.Now(),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1))
+	suite.Require().NoError(err)
+
+	// Store a relationship created by each user
+	err = suite.database.SaveRelationship(types.NewRelationship(
+		relationshipstypes.NewRelationship(creator, counterparty, 1), 10,
+	))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveRelationship(types.NewRelationship(
+		relationshipstypes.NewRelationship(counterparty, creator, 1), 10,
+	))
+	suite.Require().NoError(err)
+
+	err = suite.database.DeleteUserRelationships(10, 1, creator)
+	suite.Require().NoError(err)
+
+	// Make sure only the relationship created by the user has been deleted
+	var creators []string
+	err = suite.database.SQL.Select(&creators, "SELECT creator_address FROM user_relationship")
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{counterparty}, creators)
+
+	// Make sure the user's relationships count has been updated
+	var count int
+	err = suite.database.SQL.Get(&count, "SELECT relationships_count FROM profile_counters WHERE profile_address = $1", creator)
+	suite.Require().NoError(err)
+	suite.Require().Zero(count)
+}
+
 // --------------------------------------------------------------------------------------------------------------------
 
 func (suite *DbTestSuite) saveBlockage() types.Blockage {
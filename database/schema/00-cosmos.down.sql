@@ -0,0 +1,2 @@
+DROP TYPE COIN;
+DROP TABLE block;
@@ -0,0 +1 @@
+DROP TABLE fees_params;
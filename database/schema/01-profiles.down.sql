@@ -0,0 +1,9 @@
+DROP TABLE profiles_params;
+DROP TABLE application_link_oracle_request;
+DROP TABLE application_link;
+DROP TABLE default_chain_link;
+DROP TABLE chain_link_proof;
+DROP TABLE chain_link;
+DROP TABLE chain_link_chain_config;
+DROP TABLE dtag_transfer_requests;
+DROP TABLE profile;
@@ -0,0 +1,5 @@
+DROP TABLE subspace_user_permission;
+DROP TABLE subspace_user_group_member;
+DROP TABLE subspace_user_group;
+DROP TABLE subspace_section;
+DROP TABLE subspace;
@@ -0,0 +1,2 @@
+DROP TABLE user_block;
+DROP TABLE user_relationship;
@@ -0,0 +1,10 @@
+DROP TABLE posts_params;
+DROP TABLE poll_answer;
+DROP TABLE post_attachment;
+DROP TABLE post_reference;
+DROP TABLE post_tag;
+DROP TABLE post_url;
+DROP TABLE post_mention;
+DROP TABLE post_hashtag;
+DROP TABLE post_transaction;
+DROP TABLE post;
@@ -0,0 +1,4 @@
+DROP TABLE reports_params;
+DROP TABLE report_reason;
+DROP TABLE report;
+DROP TABLE subspace_report_reason;
@@ -0,0 +1,4 @@
+DROP TABLE subspace_free_text_params;
+DROP TABLE subspace_registered_reaction_params;
+DROP TABLE subspace_registered_reaction;
+DROP TABLE reaction;
@@ -0,0 +1,2 @@
+DROP TABLE tip_post;
+DROP TABLE tip_user;
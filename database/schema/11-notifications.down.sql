@@ -0,0 +1,2 @@
+DROP TABLE notification_token;
+DROP TABLE notification;
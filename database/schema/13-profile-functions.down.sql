@@ -0,0 +1,4 @@
+DROP FUNCTION has_user_blocked_profile(profile, json);
+DROP FUNCTION is_user_blocked_by_profile(profile, json);
+DROP FUNCTION is_user_following_profile(profile, json);
+DROP FUNCTION is_user_followed_by_profile(profile, json);
@@ -0,0 +1,5 @@
+DROP INDEX report_subspace_reporter_index;
+DROP INDEX user_block_blocked_subspace_index;
+DROP INDEX user_relationship_subspace_creator_index;
+DROP INDEX post_reference_post_row_id_index;
+DROP INDEX post_subspace_author_creation_date_index;
@@ -0,0 +1,9 @@
+/**
+ * Indexes used to efficiently build the home feed of a user.
+ * The feed is computed on the fly joining the followed users posts, and excluding the blocked and reported content.
+ */
+CREATE INDEX post_subspace_author_creation_date_index ON post (subspace_id, author_address, creation_date DESC, row_id DESC);
+CREATE INDEX post_reference_post_row_id_index ON post_reference (post_row_id);
+CREATE INDEX user_relationship_subspace_creator_index ON user_relationship (subspace_id, creator_address);
+CREATE INDEX user_block_blocked_subspace_index ON user_block (blocked_address, subspace_id);
+CREATE INDEX report_subspace_reporter_index ON report (subspace_id, reporter_address);
@@ -0,0 +1,18 @@
+/**
+ * Table that contains the previous versions of the edited posts.
+ * Each row contains the content that has been replaced by the edit performed at the given height with the given tx.
+ */
+CREATE TABLE post_revision
+(
+    /* Required for Hasura links */
+    row_id      SERIAL NOT NULL PRIMARY KEY,
+
+    post_row_id BIGINT NOT NULL REFERENCES post (row_id) ON DELETE CASCADE,
+    text        TEXT,
+    entities    JSONB,
+    tags        TEXT[] NOT NULL DEFAULT '{}',
+    height      BIGINT NOT NULL,
+    tx_hash     TEXT   NOT NULL,
+    CONSTRAINT unique_post_revision UNIQUE (post_row_id, tx_hash)
+);
+CREATE INDEX post_revision_post_row_id_index ON post_revision (post_row_id, height);
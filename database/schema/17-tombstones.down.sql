@@ -0,0 +1,17 @@
+DROP INDEX profile_deletion_time_index;
+ALTER TABLE profile
+    DROP COLUMN deletion_time,
+    DROP COLUMN deletion_tx_hash,
+    DROP COLUMN deletion_height;
+
+DROP INDEX reaction_deletion_time_index;
+ALTER TABLE reaction
+    DROP COLUMN deletion_time,
+    DROP COLUMN deletion_tx_hash,
+    DROP COLUMN deletion_height;
+
+DROP INDEX post_deletion_time_index;
+ALTER TABLE post
+    DROP COLUMN deletion_time,
+    DROP COLUMN deletion_tx_hash,
+    DROP COLUMN deletion_height;
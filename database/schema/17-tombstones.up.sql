@@ -0,0 +1,22 @@
+/**
+ * Columns used to mark posts, reactions and profiles as deleted instead of physically removing them.
+ * A row is considered deleted when its deletion height is set. The deletion time is used to purge the
+ * deleted rows once the configured retention period has passed.
+ */
+ALTER TABLE post
+    ADD COLUMN deletion_height  BIGINT,
+    ADD COLUMN deletion_tx_hash TEXT,
+    ADD COLUMN deletion_time    TIMESTAMP WITHOUT TIME ZONE;
+CREATE INDEX post_deletion_time_index ON post (deletion_time) WHERE deletion_time IS NOT NULL;
+
+ALTER TABLE reaction
+    ADD COLUMN deletion_height  BIGINT,
+    ADD COLUMN deletion_tx_hash TEXT,
+    ADD COLUMN deletion_time    TIMESTAMP WITHOUT TIME ZONE;
+CREATE INDEX reaction_deletion_time_index ON reaction (deletion_time) WHERE deletion_time IS NOT NULL;
+
+ALTER TABLE profile
+    ADD COLUMN deletion_height  BIGINT,
+    ADD COLUMN deletion_tx_hash TEXT,
+    ADD COLUMN deletion_time    TIMESTAMP WITHOUT TIME ZONE;
+CREATE INDEX profile_deletion_time_index ON profile (deletion_time) WHERE deletion_time IS NOT NULL;
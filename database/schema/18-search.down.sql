@@ -0,0 +1,15 @@
+DROP FUNCTION search_profiles(BIGINT, TEXT);
+DROP FUNCTION search_posts(BIGINT, TEXT);
+DROP FUNCTION search_recency_weight(TIMESTAMP WITHOUT TIME ZONE);
+DROP FUNCTION search_like_pattern(TEXT);
+
+DROP INDEX post_hashtag_tag_trgm_index;
+DROP INDEX profile_dtag_trgm_index;
+
+DROP INDEX profile_search_vector_index;
+ALTER TABLE profile DROP COLUMN search_vector;
+
+DROP INDEX post_search_vector_index;
+ALTER TABLE post DROP COLUMN search_vector;
+
+/* The pg_trgm extension is left installed since it might be used by other applications */
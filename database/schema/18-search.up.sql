@@ -0,0 +1,93 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+/**
+ * Full-text search vectors, maintained by PostgreSQL every time a post or profile is written.
+ * The "simple" configuration is used since posts and profiles can be written in any language.
+ */
+ALTER TABLE post
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', COALESCE(text, ''))) STORED;
+CREATE INDEX post_search_vector_index ON post USING GIN (search_vector);
+
+ALTER TABLE profile
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+                setweight(to_tsvector('simple', dtag), 'A') ||
+                setweight(to_tsvector('simple', nickname), 'B') ||
+                setweight(to_tsvector('simple', bio), 'C')
+        ) STORED;
+CREATE INDEX profile_search_vector_index ON profile USING GIN (search_vector);
+
+/* Trigram indexes used for DTag prefix search and hashtag lookup */
+CREATE INDEX profile_dtag_trgm_index ON profile USING GIN (dtag gin_trgm_ops);
+CREATE INDEX post_hashtag_tag_trgm_index ON post_hashtag USING GIN (tag gin_trgm_ops);
+
+/* --------------------------------------------------------------------------------------------------------------- */
+
+/**
+ * Function that escapes the given value so that it can be used literally inside a LIKE pattern.
+ */
+CREATE OR REPLACE FUNCTION search_like_pattern(value TEXT)
+    RETURNS TEXT AS
+$$
+SELECT replace(replace(replace(value, '\', '\\'), '%', '\%'), '_', '\_')
+$$ LANGUAGE sql IMMUTABLE;
+
+/**
+ * Function that returns the weight given to search results based on their creation date.
+ * The weight halves after 30 days, and keeps decreasing as the result gets older.
+ */
+CREATE OR REPLACE FUNCTION search_recency_weight(creation_date TIMESTAMP WITHOUT TIME ZONE)
+    RETURNS DOUBLE PRECISION AS
+$$
+SELECT 1 / (1 + GREATEST(EXTRACT(EPOCH FROM (NOW() AT TIME ZONE 'UTC' - creation_date)), 0)::DOUBLE PRECISION / 2592000)
+$$ LANGUAGE sql STABLE;
+
+/**
+ * Function that allows to search the posts of a subspace whose text or hashtags match the given query.
+ * Results are sorted by relevance and recency.
+ */
+CREATE OR REPLACE FUNCTION search_posts(subspace_id BIGINT, search_query TEXT)
+    RETURNS SETOF post AS
+$$
+WITH query AS (SELECT websearch_to_tsquery('simple', search_query) AS tsquery),
+     hashtag_post AS (SELECT DISTINCT post_hashtag.post_row_id AS row_id
+                      FROM post_hashtag
+                      WHERE post_hashtag.tag ILIKE search_like_pattern(ltrim(trim(search_query), '#')))
+SELECT post.*
+FROM post
+         CROSS JOIN query
+         LEFT JOIN hashtag_post ON hashtag_post.row_id = post.row_id
+WHERE trim(search_query) <> ''
+  AND post.subspace_id = search_posts.subspace_id
+  AND post.deletion_height IS NULL
+  AND (post.search_vector @@ query.tsquery OR hashtag_post.row_id IS NOT NULL)
+ORDER BY (ts_rank(post.search_vector, query.tsquery) + CASE WHEN hashtag_post.row_id IS NULL THEN 0 ELSE 1 END)
+             * search_recency_weight(post.creation_date) DESC,
+         post.creation_date DESC,
+         post.row_id DESC
+$$ LANGUAGE sql STABLE;
+
+/**
+ * Function that allows to search the profiles that have posted inside a subspace and whose DTag, nickname or bio
+ * match the given query. DTags starting with the given query are considered matching as well.
+ * Results are sorted by relevance and recency.
+ */
+CREATE OR REPLACE FUNCTION search_profiles(subspace_id BIGINT, search_query TEXT)
+    RETURNS SETOF profile AS
+$$
+WITH query AS (SELECT websearch_to_tsquery('simple', search_query)                AS tsquery,
+                      search_like_pattern(ltrim(trim(search_query), '@')) || '%' AS dtag_prefix)
+SELECT profile.*
+FROM profile
+         CROSS JOIN query
+WHERE trim(search_query) <> ''
+  AND profile.deletion_height IS NULL
+  AND (profile.search_vector @@ query.tsquery OR profile.dtag ILIKE query.dtag_prefix)
+  AND EXISTS(SELECT 1
+             FROM post
+             WHERE post.subspace_id = search_profiles.subspace_id
+               AND post.author_address = profile.address
+               AND post.deletion_height IS NULL)
+ORDER BY (ts_rank(profile.search_vector, query.tsquery) + CASE WHEN profile.dtag ILIKE query.dtag_prefix THEN 1 ELSE 0 END)
+             * search_recency_weight(profile.creation_time) DESC,
+         profile.dtag
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,2 @@
+DROP TABLE post_reaction_counters;
+DROP TABLE post_counters;
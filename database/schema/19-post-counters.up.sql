@@ -0,0 +1,33 @@
+/**
+ * Table that contains the engagement counters related to a post.
+ * This is done in order to improve performance avoiding using COUNT queries.
+ * Deleted posts and reactions are not counted.
+ */
+CREATE TABLE post_counters
+(
+    row_id             SERIAL NOT NULL PRIMARY KEY,
+
+    post_row_id        BIGINT NOT NULL REFERENCES post (row_id) ON DELETE CASCADE,
+    reactions_count    BIGINT NOT NULL DEFAULT 0,
+    comments_count     BIGINT NOT NULL DEFAULT 0,
+    replies_count      BIGINT NOT NULL DEFAULT 0,
+    reposts_count      BIGINT NOT NULL DEFAULT 0,
+    quotes_count       BIGINT NOT NULL DEFAULT 0,
+    poll_answers_count BIGINT NOT NULL DEFAULT 0,
+    tips_count         BIGINT NOT NULL DEFAULT 0,
+    tips_amount        COIN[] NOT NULL DEFAULT '{}',
+    CONSTRAINT unique_post_counters UNIQUE (post_row_id)
+);
+
+/**
+ * Table that contains the number of times each registered reaction has been added to a post.
+ */
+CREATE TABLE post_reaction_counters
+(
+    row_id                 SERIAL NOT NULL PRIMARY KEY,
+
+    post_row_id            BIGINT NOT NULL REFERENCES post (row_id) ON DELETE CASCADE,
+    registered_reaction_id BIGINT NOT NULL,
+    reactions_count        BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT unique_post_reaction_counters UNIQUE (post_row_id, registered_reaction_id)
+);
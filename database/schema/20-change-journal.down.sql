@@ -0,0 +1,21 @@
+DO
+$$
+    DECLARE
+        journaled_table TEXT;
+    BEGIN
+        FOR journaled_table IN
+            SELECT DISTINCT event_object_table
+            FROM information_schema.triggers
+            WHERE trigger_schema = current_schema()
+              AND trigger_name = 'journal_row_change'
+            LOOP
+                EXECUTE format('DROP TRIGGER journal_row_change ON %I', journaled_table);
+            END LOOP;
+    END
+$$;
+
+DROP FUNCTION rollback_to_height(BIGINT);
+DROP FUNCTION restore_journaled_rows(BIGINT, BOOLEAN);
+DROP FUNCTION journal_table_changes(TEXT);
+DROP FUNCTION journal_row_change();
+DROP TABLE change_journal;
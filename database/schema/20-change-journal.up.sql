@@ -0,0 +1,210 @@
+/**
+ * Table that contains the previous version of the rows that have been updated to a greater height or deleted.
+ * Each entry is recorded at the height of the change, so that the rows can be restored when rolling back the
+ * database to a lower height after a chain reorganisation.
+ */
+CREATE TABLE change_journal
+(
+    row_id     BIGSERIAL                   NOT NULL PRIMARY KEY,
+    table_name TEXT                        NOT NULL,
+    operation  TEXT                        NOT NULL,
+    height     BIGINT                      NOT NULL,
+    row_key    JSONB                       NOT NULL,
+    row_data   JSONB                       NOT NULL,
+    changed_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX change_journal_height_index ON change_journal (height);
+
+/**
+ * Trigger function that records the previous version of a row inside the change journal.
+ * The trigger arguments are the names of the columns that identify the row.
+ * Updates are recorded only when they change the row height, while deletions are recorded at the height set
+ * with the athena.change_height setting. Deletions performed without such setting are not recorded.
+ */
+CREATE OR REPLACE FUNCTION journal_row_change()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    change_height BIGINT;
+    change_key    JSONB := '{}';
+    i             INTEGER;
+BEGIN
+    IF current_setting('athena.journal_disabled', true) = 'true' THEN
+        RETURN CASE WHEN TG_OP = 'DELETE' THEN OLD ELSE NEW END;
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        IF NEW.height IS NOT DISTINCT FROM OLD.height THEN
+            RETURN NEW;
+        END IF;
+        change_height := NEW.height;
+    ELSE
+        change_height := NULLIF(current_setting('athena.change_height', true), '')::BIGINT;
+    END IF;
+
+    IF change_height IS NOT NULL THEN
+        FOR i IN 0..TG_NARGS - 1
+            LOOP
+                change_key := change_key || jsonb_build_object(TG_ARGV[i], to_jsonb(OLD) -> TG_ARGV[i]);
+            END LOOP;
+
+        INSERT INTO change_journal (table_name, operation, height, row_key, row_data)
+        VALUES (TG_TABLE_NAME, TG_OP, change_height, change_key, to_jsonb(OLD));
+    END IF;
+
+    RETURN CASE WHEN TG_OP = 'DELETE' THEN OLD ELSE NEW END;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * Function that attaches the change journal trigger to the given table.
+ * The rows are identified using the table primary key, or its first unique index if it has no primary key.
+ * Tables created after this migration that have a height column should call this function as well.
+ */
+CREATE OR REPLACE FUNCTION journal_table_changes(journaled_table TEXT)
+    RETURNS VOID AS
+$$
+DECLARE
+    key_columns TEXT;
+BEGIN
+    SELECT string_agg(quote_literal(attribute.attname), ', ' ORDER BY key_column.position)
+    INTO key_columns
+    FROM (SELECT indexrelid, indkey
+          FROM pg_index
+          WHERE indrelid = journaled_table::regclass
+            AND indisunique
+            AND indexprs IS NULL
+            AND indpred IS NULL
+          ORDER BY indisprimary DESC, indexrelid
+          LIMIT 1) AS key_index
+             CROSS JOIN unnest(key_index.indkey::SMALLINT[]) WITH ORDINALITY AS key_column(attnum, position)
+             JOIN pg_attribute attribute
+                  ON attribute.attrelid = journaled_table::regclass AND attribute.attnum = key_column.attnum;
+
+    EXECUTE format('DROP TRIGGER IF EXISTS journal_row_change ON %I', journaled_table);
+    EXECUTE format('CREATE TRIGGER journal_row_change BEFORE UPDATE OR DELETE ON %I ' ||
+                   'FOR EACH ROW EXECUTE FUNCTION journal_row_change(%s)',
+                   journaled_table, COALESCE(key_columns, ''));
+END;
+$$ LANGUAGE plpgsql;
+
+DO
+$$
+    DECLARE
+        journaled_table TEXT;
+    BEGIN
+        FOR journaled_table IN
+            SELECT columns.table_name
+            FROM information_schema.columns columns
+                     JOIN information_schema.tables tables
+                          ON tables.table_schema = columns.table_schema AND tables.table_name = columns.table_name
+            WHERE columns.table_schema = current_schema()
+              AND columns.column_name = 'height'
+              AND tables.table_type = 'BASE TABLE'
+              AND columns.table_name NOT IN ('block', 'change_journal')
+            LOOP
+                PERFORM journal_table_changes(journaled_table);
+            END LOOP;
+    END
+$$;
+
+/* --------------------------------------------------------------------------------------------------------------- */
+
+/**
+ * Function that restores the rows recorded inside the change journal above the given height.
+ * For each row, the earliest recorded version is the one that was stored at the given height.
+ * If only_missing is true, rows that still exist are left untouched.
+ */
+CREATE OR REPLACE FUNCTION restore_journaled_rows(target_height BIGINT, only_missing BOOLEAN)
+    RETURNS VOID AS
+$$
+DECLARE
+    entry        RECORD;
+    column_list  TEXT;
+    key_columns  TEXT;
+    updated_rows INTEGER;
+BEGIN
+    FOR entry IN
+        SELECT *
+        FROM (SELECT DISTINCT ON (table_name, row_key, CASE WHEN row_key = '{}' THEN row_id END) *
+              FROM change_journal
+              WHERE height > target_height
+              ORDER BY table_name, row_key, CASE WHEN row_key = '{}' THEN row_id END, row_id) AS earliest
+        ORDER BY row_id
+        LOOP
+            SELECT string_agg(quote_ident(column_name), ', ' ORDER BY ordinal_position)
+            INTO column_list
+            FROM information_schema.columns
+            WHERE table_schema = current_schema()
+              AND table_name = entry.table_name
+              AND is_generated = 'NEVER';
+
+            updated_rows := 0;
+            IF NOT only_missing AND entry.row_key <> '{}' THEN
+                SELECT string_agg(quote_ident(key_column), ', ')
+                INTO key_columns
+                FROM jsonb_object_keys(entry.row_key) AS key_column;
+
+                EXECUTE format('UPDATE %I SET (%s) = (SELECT %s FROM jsonb_populate_record(NULL::%I, $1)) ' ||
+                               'WHERE (%s) = (SELECT %s FROM jsonb_populate_record(NULL::%I, $1))',
+                               entry.table_name, column_list, column_list, entry.table_name,
+                               key_columns, key_columns, entry.table_name)
+                    USING entry.row_data;
+                GET DIAGNOSTICS updated_rows = ROW_COUNT;
+            END IF;
+
+            IF updated_rows = 0 THEN
+                EXECUTE format('INSERT INTO %I (%s) SELECT %s FROM jsonb_populate_record(NULL::%I, $1) ' ||
+                               'ON CONFLICT DO NOTHING',
+                               entry.table_name, column_list, column_list, entry.table_name)
+                    USING entry.row_data;
+            END IF;
+        END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * Function that rolls back all the data stored above the given height.
+ * The rows changed above such height are restored from the change journal, while the rows stored above it are
+ * deleted along with the blocks, so that the heights can be parsed again.
+ */
+CREATE OR REPLACE FUNCTION rollback_to_height(target_height BIGINT)
+    RETURNS VOID AS
+$$
+DECLARE
+    rolled_back_table TEXT;
+BEGIN
+    PERFORM set_config('athena.journal_disabled', 'true', true);
+
+    /* Restore the rows first so that the children of restored rows are not deleted in cascade */
+    PERFORM restore_journaled_rows(target_height, false);
+
+    FOR rolled_back_table IN
+        SELECT columns.table_name
+        FROM information_schema.columns columns
+                 JOIN information_schema.tables tables
+                      ON tables.table_schema = columns.table_schema AND tables.table_name = columns.table_name
+        WHERE columns.table_schema = current_schema()
+          AND columns.column_name = 'height'
+          AND tables.table_type = 'BASE TABLE'
+          AND columns.table_name <> 'change_journal'
+        LOOP
+            EXECUTE format('DELETE FROM %I WHERE height > $1', rolled_back_table) USING target_height;
+        END LOOP;
+
+    /* Restore the rows that have been deleted and then stored again with a different key above the height */
+    PERFORM restore_journaled_rows(target_height, true);
+
+    FOR rolled_back_table IN
+        SELECT table_name
+        FROM information_schema.columns
+        WHERE table_schema = current_schema()
+          AND column_name = 'deletion_height'
+        LOOP
+            EXECUTE format('UPDATE %I SET deletion_height = NULL, deletion_tx_hash = NULL, deletion_time = NULL ' ||
+                           'WHERE deletion_height > $1', rolled_back_table) USING target_height;
+        END LOOP;
+
+    DELETE FROM change_journal WHERE height > target_height;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,23 @@
+ALTER TABLE notification
+    RENAME TO notification_partitioned;
+ALTER TABLE notification_partitioned
+    RENAME CONSTRAINT unique_user_notification TO unique_user_notification_partitioned;
+
+CREATE TABLE notification
+(
+    user_address TEXT                        NOT NULL,
+    type         TEXT                        NOT NULL,
+    data         JSONB                       NOT NULL,
+    timestamp    TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    CONSTRAINT unique_user_notification UNIQUE (user_address, data)
+);
+
+INSERT INTO notification (user_address, type, data, timestamp)
+SELECT DISTINCT ON (user_address, data) user_address, type, data, timestamp
+FROM notification_partitioned
+ORDER BY user_address, data, timestamp DESC;
+
+DROP TABLE notification_partitioned;
+
+DROP FUNCTION drop_notification_partitions(TIMESTAMP WITHOUT TIME ZONE);
+DROP FUNCTION create_notification_partition(DATE);
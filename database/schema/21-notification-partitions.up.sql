@@ -0,0 +1,101 @@
+ALTER TABLE notification
+    RENAME TO notification_old;
+ALTER TABLE notification_old
+    RENAME CONSTRAINT unique_user_notification TO unique_user_notification_old;
+
+/**
+ * Table that contains the notifications sent to the users, partitioned by month based on their timestamp.
+ * Notifications having a timestamp outside of all the monthly partitions are stored inside the default one.
+ */
+CREATE TABLE notification
+(
+    id           BIGSERIAL                   NOT NULL,
+    user_address TEXT                        NOT NULL,
+    type         TEXT                        NOT NULL,
+    data         JSONB                       NOT NULL,
+    data_hash    TEXT                        NOT NULL GENERATED ALWAYS AS (md5(data::TEXT)) STORED,
+    timestamp    TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    PRIMARY KEY (id, timestamp),
+    CONSTRAINT unique_user_notification UNIQUE (user_address, data_hash, timestamp)
+) PARTITION BY RANGE (timestamp);
+CREATE INDEX notification_user_address_timestamp_index ON notification (user_address, timestamp DESC);
+CREATE INDEX notification_timestamp_index ON notification (timestamp);
+CREATE INDEX notification_user_address_data_hash_index ON notification (user_address, data_hash);
+
+CREATE TABLE notification_default PARTITION OF notification DEFAULT;
+
+/**
+ * Function that creates the partition of the notification table containing the month of the given date,
+ * if it does not exist yet. The notifications of such month stored inside the default partition are moved to it.
+ */
+CREATE OR REPLACE FUNCTION create_notification_partition(month_date DATE)
+    RETURNS VOID AS
+$$
+DECLARE
+    month_start    DATE := date_trunc('month', month_date)::DATE;
+    month_end      DATE := (date_trunc('month', month_date) + INTERVAL '1 month')::DATE;
+    partition_name TEXT := 'notification_' || to_char(month_date, 'YYYY_MM');
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN;
+    END IF;
+
+    CREATE TEMPORARY TABLE notification_moved AS
+    SELECT id, user_address, type, data, timestamp
+    FROM notification_default
+    WHERE timestamp >= month_start
+      AND timestamp < month_end;
+    DELETE FROM notification_default WHERE timestamp >= month_start AND timestamp < month_end;
+
+    EXECUTE format('CREATE TABLE %I PARTITION OF notification FOR VALUES FROM (%L) TO (%L)',
+                   partition_name, month_start, month_end);
+
+    INSERT INTO notification (id, user_address, type, data, timestamp)
+    SELECT id, user_address, type, data, timestamp
+    FROM notification_moved;
+    DROP TABLE notification_moved;
+END;
+$$ LANGUAGE plpgsql;
+
+/**
+ * Function that drops all the partitions of the notification table containing only notifications older than the
+ * given date, and deletes such notifications from the default partition as well.
+ * Returns the names of the dropped partitions.
+ */
+CREATE OR REPLACE FUNCTION drop_notification_partitions(before_date TIMESTAMP WITHOUT TIME ZONE)
+    RETURNS SETOF TEXT AS
+$$
+DECLARE
+    partition_name TEXT;
+BEGIN
+    FOR partition_name IN
+        SELECT child.relname
+        FROM pg_inherits
+                 JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+                 JOIN pg_class child ON child.oid = pg_inherits.inhrelid
+        WHERE parent.relname = 'notification'
+          AND child.relname ~ '^notification_\d{4}_\d{2}$'
+          AND to_date(substring(child.relname FROM '\d{4}_\d{2}$'), 'YYYY_MM') + INTERVAL '1 month' <= before_date
+        LOOP
+            EXECUTE format('DROP TABLE %I', partition_name);
+            RETURN NEXT partition_name;
+        END LOOP;
+
+    DELETE FROM notification_default WHERE timestamp < before_date;
+END;
+$$ LANGUAGE plpgsql;
+
+/* Create the partitions for the existing notifications and for the current and next month */
+SELECT create_notification_partition(month_date::DATE)
+FROM generate_series(
+             date_trunc('month', LEAST((SELECT MIN(timestamp) FROM notification_old), NOW()::TIMESTAMP)),
+             date_trunc('month', NOW()::TIMESTAMP) + INTERVAL '1 month',
+             INTERVAL '1 month'
+         ) AS month_date;
+
+INSERT INTO notification (user_address, type, data, timestamp)
+SELECT user_address, type, data, timestamp
+FROM notification_old
+ON CONFLICT DO NOTHING;
+
+DROP TABLE notification_old;
@@ -0,0 +1,8 @@
+DROP FUNCTION rollback_subspace_history(BIGINT);
+DROP FUNCTION subspace_users_with_permission_at(BIGINT, TEXT, BIGINT);
+DROP FUNCTION subspace_user_permissions_at(BIGINT, BIGINT);
+DROP FUNCTION subspace_user_group_permissions_at(BIGINT, BIGINT);
+DROP FUNCTION subspace_user_group_members_at(BIGINT, BIGINT);
+DROP TABLE subspace_user_permission_history;
+DROP TABLE subspace_user_group_permission_history;
+DROP TABLE subspace_user_group_member_history;
@@ -0,0 +1,129 @@
+/**
+ * Tables that contain the history of the subspaces memberships and permissions.
+ * Each row represents a period during which the state was valid: it starts at the valid_from height (included) and
+ * ends at the valid_to height (excluded). Rows having a null valid_to height represent the current state.
+ * Rows are never deleted, so that the state at any given height can be retrieved.
+ */
+CREATE TABLE subspace_user_group_member_history
+(
+    row_id            BIGSERIAL                   NOT NULL PRIMARY KEY,
+    subspace_id       BIGINT                      NOT NULL,
+    group_id          BIGINT                      NOT NULL,
+    member_address    TEXT                        NOT NULL,
+    valid_from_height BIGINT                      NOT NULL,
+    valid_from_time   TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    valid_to_height   BIGINT,
+    valid_to_time     TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT unique_subspace_user_group_member_history UNIQUE (subspace_id, group_id, member_address, valid_from_height)
+);
+CREATE INDEX subspace_user_group_member_history_member_index
+    ON subspace_user_group_member_history (subspace_id, member_address, valid_from_height);
+
+CREATE TABLE subspace_user_group_permission_history
+(
+    row_id            BIGSERIAL                   NOT NULL PRIMARY KEY,
+    subspace_id       BIGINT                      NOT NULL,
+    section_id        BIGINT                      NOT NULL,
+    group_id          BIGINT                      NOT NULL,
+    permissions       TEXT[]                      NOT NULL,
+    valid_from_height BIGINT                      NOT NULL,
+    valid_from_time   TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    valid_to_height   BIGINT,
+    valid_to_time     TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT unique_subspace_user_group_permission_history UNIQUE (subspace_id, group_id, valid_from_height)
+);
+
+CREATE TABLE subspace_user_permission_history
+(
+    row_id            BIGSERIAL                   NOT NULL PRIMARY KEY,
+    subspace_id       BIGINT                      NOT NULL,
+    section_id        BIGINT                      NOT NULL,
+    user_address      TEXT                        NOT NULL,
+    permissions       TEXT[]                      NOT NULL,
+    valid_from_height BIGINT                      NOT NULL,
+    valid_from_time   TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    valid_to_height   BIGINT,
+    valid_to_time     TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT unique_subspace_user_permission_history UNIQUE (subspace_id, section_id, user_address, valid_from_height)
+);
+CREATE INDEX subspace_user_permission_history_user_index
+    ON subspace_user_permission_history (subspace_id, user_address, valid_from_height);
+
+/* --------------------------------------------------------------------------------------------------------------- */
+
+/**
+ * Functions that return the state of the given subspace as it was at the given height.
+ */
+CREATE OR REPLACE FUNCTION subspace_user_group_members_at(subspace_id BIGINT, at_height BIGINT)
+    RETURNS SETOF subspace_user_group_member_history AS
+$$
+SELECT *
+FROM subspace_user_group_member_history history
+WHERE history.subspace_id = subspace_user_group_members_at.subspace_id
+  AND history.valid_from_height <= at_height
+  AND (history.valid_to_height IS NULL OR history.valid_to_height > at_height)
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION subspace_user_group_permissions_at(subspace_id BIGINT, at_height BIGINT)
+    RETURNS SETOF subspace_user_group_permission_history AS
+$$
+SELECT *
+FROM subspace_user_group_permission_history history
+WHERE history.subspace_id = subspace_user_group_permissions_at.subspace_id
+  AND history.valid_from_height <= at_height
+  AND (history.valid_to_height IS NULL OR history.valid_to_height > at_height)
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION subspace_user_permissions_at(subspace_id BIGINT, at_height BIGINT)
+    RETURNS SETOF subspace_user_permission_history AS
+$$
+SELECT *
+FROM subspace_user_permission_history history
+WHERE history.subspace_id = subspace_user_permissions_at.subspace_id
+  AND history.valid_from_height <= at_height
+  AND (history.valid_to_height IS NULL OR history.valid_to_height > at_height)
+$$ LANGUAGE sql STABLE;
+
+/**
+ * Function that returns the addresses of the users that had the given permission (or the "everything" permission)
+ * at the given height, either directly or by being members of a user group having it.
+ * The permissions of all the sections of the given subspace are merged: a user is returned if they had the
+ * permission inside at least one section.
+ */
+CREATE OR REPLACE FUNCTION subspace_users_with_permission_at(subspace_id BIGINT, permission TEXT, at_height BIGINT)
+    RETURNS TABLE
+            (
+                user_address TEXT
+            )
+AS
+$$
+SELECT user_permission.user_address
+FROM subspace_user_permissions_at(subspace_id, at_height) user_permission
+WHERE user_permission.permissions && ARRAY [permission, 'everything']
+UNION
+SELECT member.member_address
+FROM subspace_user_group_members_at(subspace_id, at_height) member
+         JOIN subspace_user_group_permissions_at(subspace_id, at_height) user_group
+              ON user_group.group_id = member.group_id
+WHERE user_group.permissions && ARRAY [permission, 'everything']
+$$ LANGUAGE sql STABLE;
+
+/**
+ * Function that removes the history written above the given height, reopening the periods ended above it.
+ */
+CREATE OR REPLACE FUNCTION rollback_subspace_history(target_height BIGINT)
+    RETURNS VOID AS
+$$
+DECLARE
+    history_table TEXT;
+BEGIN
+    FOREACH history_table IN ARRAY ARRAY ['subspace_user_group_member_history',
+        'subspace_user_group_permission_history',
+        'subspace_user_permission_history']
+        LOOP
+            EXECUTE format('DELETE FROM %I WHERE valid_from_height > $1', history_table) USING target_height;
+            EXECUTE format('UPDATE %I SET valid_to_height = NULL, valid_to_time = NULL WHERE valid_to_height > $1',
+                           history_table) USING target_height;
+        END LOOP;
+END;
+$$ LANGUAGE plpgsql;
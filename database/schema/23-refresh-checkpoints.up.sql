@@ -0,0 +1,14 @@
+/**
+ * Table that contains the units of work completed by the parse commands, so that an interrupted refresh can be
+ * resumed without starting over. Subspace and post ids are 0 when the unit does not refer to any of them.
+ * Rows are kept when rolling back the database, since they only keep track of the refresh progress.
+ */
+CREATE TABLE refresh_checkpoint
+(
+    module       TEXT                        NOT NULL,
+    subspace_id  BIGINT                      NOT NULL DEFAULT 0,
+    post_id      BIGINT                      NOT NULL DEFAULT 0,
+    height       BIGINT                      NOT NULL,
+    completed_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (module, subspace_id, post_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE gaps_checkpoint;
+DROP TABLE failed_height;
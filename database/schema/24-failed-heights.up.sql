@@ -0,0 +1,26 @@
+/**
+ * Table that contains the heights that need to be parsed again, either because their block is missing from the
+ * database (in which case the module is "block") or because the handlers of a module returned an error on them.
+ * Rows are removed once the height has been parsed again successfully.
+ */
+CREATE TABLE failed_height
+(
+    height    BIGINT                      NOT NULL,
+    module    TEXT                        NOT NULL,
+    error     TEXT                        NOT NULL,
+    failed_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (height, module)
+);
+
+
+/**
+ * Table that contains the height up to which the periodic gaps check has already searched for missing blocks, so
+ * that each run only searches the heights stored since the previous one. When rolling back the database, the height
+ * is lowered to the rollback height.
+ */
+CREATE TABLE gaps_checkpoint
+(
+    one_row_id BOOLEAN NOT NULL DEFAULT TRUE PRIMARY KEY,
+    height     BIGINT  NOT NULL,
+    CHECK (one_row_id)
+);
@@ -0,0 +1,18 @@
+/**
+ * Table that contains the messages whose handling failed for a module, so that they can be handled again without
+ * parsing the whole block. The authz index is the index of the message inside its MsgExec, or -1 if the message has
+ * not been executed through a MsgExec. The retry count is incremented every time the message fails again.
+ */
+CREATE TABLE failed_message
+(
+    height      BIGINT                      NOT NULL,
+    tx_hash     TEXT                        NOT NULL,
+    msg_index   INTEGER                     NOT NULL,
+    authz_index INTEGER                     NOT NULL DEFAULT -1,
+    module      TEXT                        NOT NULL,
+    msg_type    TEXT                        NOT NULL,
+    error       TEXT                        NOT NULL,
+    retry_count INTEGER                     NOT NULL DEFAULT 0,
+    failed_at   TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (height, tx_hash, msg_index, authz_index, module)
+);
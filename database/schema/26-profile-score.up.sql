@@ -0,0 +1,17 @@
+/**
+ * Table that contains the score of each profile, computed by combining the weighted scores of its application links,
+ * the number and age of its chain links, the age of the profile and its on-chain activity. Each component score,
+ * as well as the final score, ranges from 0 to 100.
+ */
+CREATE TABLE profile_score
+(
+    profile_address       TEXT                        NOT NULL PRIMARY KEY REFERENCES profile (address) ON DELETE CASCADE,
+    score                 INT                         NOT NULL DEFAULT 0,
+    applications_score    INT                         NOT NULL DEFAULT 0,
+    chain_links_score     INT                         NOT NULL DEFAULT 0,
+    chain_links_age_score INT                         NOT NULL DEFAULT 0,
+    profile_age_score     INT                         NOT NULL DEFAULT 0,
+    activity_score        INT                         NOT NULL DEFAULT 0,
+    timestamp             TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+CREATE INDEX profile_score_score_index ON profile_score (score DESC);
@@ -0,0 +1,45 @@
+/**
+ * Function that rolls back all the data stored above the given height.
+ * The rows changed above such height are restored from the change journal, while the rows stored above it are
+ * deleted along with the blocks, so that the heights can be parsed again.
+ */
+CREATE OR REPLACE FUNCTION rollback_to_height(target_height BIGINT)
+    RETURNS VOID AS
+$$
+DECLARE
+    rolled_back_table TEXT;
+BEGIN
+    PERFORM set_config('athena.journal_disabled', 'true', true);
+
+    /* Restore the rows first so that the children of restored rows are not deleted in cascade */
+    PERFORM restore_journaled_rows(target_height, false);
+
+    FOR rolled_back_table IN
+        SELECT columns.table_name
+        FROM information_schema.columns columns
+                 JOIN information_schema.tables tables
+                      ON tables.table_schema = columns.table_schema AND tables.table_name = columns.table_name
+        WHERE columns.table_schema = current_schema()
+          AND columns.column_name = 'height'
+          AND tables.table_type = 'BASE TABLE'
+          AND columns.table_name <> 'change_journal'
+        LOOP
+            EXECUTE format('DELETE FROM %I WHERE height > $1', rolled_back_table) USING target_height;
+        END LOOP;
+
+    /* Restore the rows that have been deleted and then stored again with a different key above the height */
+    PERFORM restore_journaled_rows(target_height, true);
+
+    FOR rolled_back_table IN
+        SELECT table_name
+        FROM information_schema.columns
+        WHERE table_schema = current_schema()
+          AND column_name = 'deletion_height'
+        LOOP
+            EXECUTE format('UPDATE %I SET deletion_height = NULL, deletion_tx_hash = NULL, deletion_time = NULL ' ||
+                           'WHERE deletion_height > $1', rolled_back_table) USING target_height;
+        END LOOP;
+
+    DELETE FROM change_journal WHERE height > target_height;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,67 @@
+package database
+
+import (
+	dbtypes "github.com/desmos-labs/athena/v2/database/types"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// SearchPosts returns the posts of the given subspace matching the given query, sorted by relevance and recency.
+// The results are returned starting from the given offset.
+func (db *Db) SearchPosts(subspaceID uint64, query string, offset uint64, limit uint64) ([]types.SearchPost, error) {
+	stmt := `
+SELECT subspace_id, id, author_address, text, creation_date, last_edited_date 
+FROM search_posts($1, $2) 
+OFFSET $3 LIMIT $4`
+
+	var rows []dbtypes.SearchPostRow
+	err := db.conn().Select(&rows, stmt, subspaceID, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]types.SearchPost, len(rows))
+	for i, row := range rows {
+		post := types.SearchPost{
+			SubspaceID:   row.SubspaceID,
+			ID:           row.ID,
+			Author:       row.AuthorAddress,
+			Text:         row.Text.String,
+			CreationDate: row.CreationDate,
+		}
+		if row.LastEditedDate.Valid {
+			post.LastEditedDate = &row.LastEditedDate.Time
+		}
+		posts[i] = post
+	}
+
+	return posts, nil
+}
+
+// SearchProfiles returns the profiles that have posted inside the given subspace and match the given query,
+// sorted by relevance and recency. The results are returned starting from the given offset.
+func (db *Db) SearchProfiles(subspaceID uint64, query string, offset uint64, limit uint64) ([]types.SearchProfile, error) {
+	stmt := `
+SELECT address, dtag, nickname, bio, profile_pic, cover_pic, creation_time, height 
+FROM search_profiles($1, $2) 
+OFFSET $3 LIMIT $4`
+
+	var rows []dbtypes.ProfileRow
+	err := db.conn().Select(&rows, stmt, subspaceID, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]types.SearchProfile, len(rows))
+	for i, row := range rows {
+		profiles[i] = types.SearchProfile{
+			Address:        row.Address,
+			DTag:           row.DTag,
+			Nickname:       row.Nickname,
+			Bio:            row.Bio,
+			ProfilePicture: row.ProfilePic,
+			CoverPicture:   row.CoverPic,
+		}
+	}
+
+	return profiles, nil
+}
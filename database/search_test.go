@@ -0,0 +1,172 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) setupSearchData() {
+	author := "cosmos15c66kjz44zm58xqlcqjwftan4tnaeq7rtmhn4f"
+
+	err := suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		author,
+		author,
+		time.Now(),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveProfile(types.NewProfile(suite.buildProfile(author), 1))
+	suite.Require().NoError(err)
+
+	posts := []poststypes.Post{
+		poststypes.NewPost(
+			1,
+			0,
+			1,
+			"",
+			"Hello from #desmos",
+			author,
+			0,
+			poststypes.NewEntities([]poststypes.TextTag{poststypes.NewTextTag(11, 17, "desmos")}, nil, nil),
+			nil,
+			nil,
+			poststypes.REPLY_SETTING_EVERYONE,
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			nil,
+			author,
+		),
+		poststypes.NewPost(
+			1,
+			0,
+			2,
+			"",
+			"Another message",
+			author,
+			0,
+			nil,
+			nil,
+			nil,
+			poststypes.REPLY_SETTING_EVERYONE,
+			time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			nil,
+			author,
+		),
+	}
+	for _, post := range posts {
+		err = suite.database.SavePost(types.NewPost(post, 1))
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *DbTestSuite) TestSearchPosts() {
+	suite.setupSearchData()
+
+	testCases := []struct {
+		name       string
+		subspaceID uint64
+		query      string
+		expIDs     []uint64
+	}{
+		{
+			name:       "text matches are returned",
+			subspaceID: 1,
+			query:      "hello",
+			expIDs:     []uint64{1},
+		},
+		{
+			name:       "hashtag matches are returned",
+			subspaceID: 1,
+			query:      "#DESMOS",
+			expIDs:     []uint64{1},
+		},
+		{
+			name:       "posts of other subspaces are not returned",
+			subspaceID: 2,
+			query:      "hello",
+			expIDs:     []uint64{},
+		},
+		{
+			name:       "blank query returns nothing",
+			subspaceID: 1,
+			query:      "  ",
+			expIDs:     []uint64{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		suite.Run(tc.name, func() {
+			posts, err := suite.database.SearchPosts(tc.subspaceID, tc.query, 0, 10)
+			suite.Require().NoError(err)
+
+			ids := make([]uint64, len(posts))
+			for i, post := range posts {
+				ids[i] = post.ID
+			}
+			suite.Require().Equal(tc.expIDs, ids)
+		})
+	}
+}
+
+func (suite *DbTestSuite) TestSearchProfiles() {
+	suite.setupSearchData()
+
+	testCases := []struct {
+		name       string
+		subspaceID uint64
+		query      string
+		expDTags   []string
+	}{
+		{
+			name:       "nickname matches are returned",
+			subspaceID: 1,
+			query:      "user",
+			expDTags:   []string{"TestUser"},
+		},
+		{
+			name:       "dtag prefix matches are returned",
+			subspaceID: 1,
+			query:      "@tes",
+			expDTags:   []string{"TestUser"},
+		},
+		{
+			name:       "like wildcards are matched literally",
+			subspaceID: 1,
+			query:      "%",
+			expDTags:   []string{},
+		},
+		{
+			name:       "profiles that did not post inside the subspace are not returned",
+			subspaceID: 2,
+			query:      "user",
+			expDTags:   []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		suite.Run(tc.name, func() {
+			profiles, err := suite.database.SearchProfiles(tc.subspaceID, tc.query, 0, 10)
+			suite.Require().NoError(err)
+
+			dTags := make([]string, len(profiles))
+			for i, profile := range profiles {
+				dTags[i] = profile.DTag
+			}
+			suite.Require().Equal(tc.expDTags, dTags)
+		})
+	}
+}
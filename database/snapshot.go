@@ -0,0 +1,517 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// snapshotExcludedTables contains the tables that are never included inside a snapshot, since their content
+// is tied to the instance that produced it rather than to the chain data
+var snapshotExcludedTables = []string{
+	"schema_migrations",
+	"change_journal",
+	"refresh_checkpoint",
+	"gaps_checkpoint",
+}
+
+// snapshotQueryer represents the connection used to read the snapshot metadata
+type snapshotQueryer interface {
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+}
+
+// getSchemaVersion returns the version of the latest migration applied to the database
+func getSchemaVersion(q snapshotQueryer) (string, error) {
+	var version sql.NullString
+	err := q.Get(&version, `SELECT MAX(version) FROM schema_migrations`)
+	if err != nil {
+		return "", err
+	}
+
+	if !version.Valid {
+		return "", fmt.Errorf("no migration has been applied to the database")
+	}
+	return version.String, nil
+}
+
+// getSnapshotTables returns the tables that should be included inside a snapshot, sorted so that each table
+// comes after all the tables it references
+func getSnapshotTables(q snapshotQueryer) ([]string, error) {
+	var tables []string
+	stmt := `
+SELECT relname
+FROM pg_class
+         JOIN pg_namespace ON pg_namespace.oid = pg_class.relnamespace
+WHERE pg_namespace.nspname = current_schema()
+  AND relkind IN ('r', 'p')
+  AND NOT relispartition
+  AND relname::TEXT <> ALL($1::TEXT[])
+ORDER BY relname`
+	err := q.Select(&tables, stmt, pq.Array(snapshotExcludedTables))
+	if err != nil {
+		return nil, err
+	}
+
+	var references []struct {
+		Table      string `db:"table_name"`
+		Referenced string `db:"referenced_table_name"`
+	}
+	stmt = `
+SELECT DISTINCT child.relname AS table_name, parent.relname AS referenced_table_name
+FROM pg_constraint
+         JOIN pg_class child ON child.oid = pg_constraint.conrelid
+         JOIN pg_class parent ON parent.oid = pg_constraint.confrelid
+         JOIN pg_namespace ON pg_namespace.oid = child.relnamespace
+WHERE pg_constraint.contype = 'f'
+  AND pg_namespace.nspname = current_schema()
+  AND NOT child.relispartition`
+	err = q.Select(&references, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := make(map[string][]string, len(tables))
+	for _, reference := range references {
+		dependencies[reference.Table] = append(dependencies[reference.Table], reference.Referenced)
+	}
+
+	return sortTablesByDependencies(tables, dependencies)
+}
+
+// sortTablesByDependencies sorts the given tables so that each one comes after all the tables it depends on.
+// Tables that do not depend on each other keep their relative order.
+func sortTablesByDependencies(tables []string, dependencies map[string][]string) ([]string, error) {
+	sorted := make([]string, 0, len(tables))
+	added := make(map[string]bool, len(tables))
+
+	for len(sorted) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			if added[table] {
+				continue
+			}
+
+			ready := true
+			for _, dependency := range dependencies[table] {
+				// Self references are satisfied by importing the rows sorted by primary key
+				if dependency != table && !added[dependency] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				sorted = append(sorted, table)
+				added[table] = true
+				progress = true
+			}
+		}
+
+		if !progress {
+			return nil, fmt.Errorf("circular references found between the tables")
+		}
+	}
+
+	return sorted, nil
+}
+
+// getPrimaryKeyColumns returns the columns of the primary key of the given table, in their key order
+func getPrimaryKeyColumns(q snapshotQueryer, table string) ([]string, error) {
+	var columns []string
+	stmt := `
+SELECT pg_attribute.attname
+FROM pg_index
+         JOIN LATERAL unnest(pg_index.indkey) WITH ORDINALITY AS key_column(attnum, key_order) ON TRUE
+         JOIN pg_attribute
+              ON pg_attribute.attrelid = pg_index.indrelid AND pg_attribute.attnum = key_column.attnum
+WHERE pg_index.indrelid = $1::TEXT::regclass
+  AND pg_index.indisprimary
+ORDER BY key_column.key_order`
+	err := q.Select(&columns, stmt, pq.QuoteIdentifier(table))
+	return columns, err
+}
+
+// getInsertableColumns returns the columns of the given table whose values can be inserted, excluding the
+// generated ones that are computed by the database
+func getInsertableColumns(q snapshotQueryer, table string) ([]string, error) {
+	var columns []string
+	stmt := `
+SELECT attname
+FROM pg_attribute
+WHERE attrelid = $1::TEXT::regclass
+  AND attnum > 0
+  AND NOT attisdropped
+  AND attgenerated = ''
+ORDER BY attnum`
+	err := q.Select(&columns, stmt, pq.QuoteIdentifier(table))
+	return columns, err
+}
+
+// quoteIdentifiers quotes all the given identifiers and joins them using a comma
+func quoteIdentifiers(identifiers []string) string {
+	quoted := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		quoted[i] = pq.QuoteIdentifier(identifier)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// SnapshotExport allows to read the content of the database tables as they were when the export started,
+// so that a consistent snapshot can be taken while Athena keeps parsing the chain
+type SnapshotExport struct {
+	tx *sqlx.Tx
+}
+
+// BeginSnapshotExport starts a new read-only snapshot export
+func (db *Db) BeginSnapshotExport() (*SnapshotExport, error) {
+	tx, err := db.SQL.BeginTxx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotExport{tx: tx}, nil
+}
+
+// SchemaVersion returns the version of the latest migration applied to the database
+func (e *SnapshotExport) SchemaVersion() (string, error) {
+	return getSchemaVersion(e.tx)
+}
+
+// StartHeight returns the height of the first block stored inside the database
+func (e *SnapshotExport) StartHeight() (int64, error) {
+	var height int64
+	err := e.tx.Get(&height, `SELECT COALESCE(MIN(height), 0) FROM block`)
+	return height, err
+}
+
+// Height returns the highest height up to which all the blocks following the first stored one are stored
+// inside the database, so that parsing can be resumed from the next height without skipping any block
+func (e *SnapshotExport) Height() (int64, error) {
+	stmt := `
+SELECT COALESCE(MIN(height), 0)
+FROM block
+WHERE NOT EXISTS(SELECT 1 FROM block next_block WHERE next_block.height = block.height + 1)`
+
+	var height int64
+	err := e.tx.Get(&height, stmt)
+	return height, err
+}
+
+// LatestHeight returns the height of the latest block stored inside the database
+func (e *SnapshotExport) LatestHeight() (int64, error) {
+	var height int64
+	err := e.tx.Get(&height, `SELECT COALESCE(MAX(height), 0) FROM block`)
+	return height, err
+}
+
+// Tables returns the tables that should be exported, sorted so that each table comes after the ones it references
+func (e *SnapshotExport) Tables() ([]string, error) {
+	return getSnapshotTables(e.tx)
+}
+
+// ExportTable calls the given function for each row of the given table, encoded as a JSON object.
+// Rows are sorted by primary key, so that the rows referencing other rows of the same table can be imported.
+// It returns the number of exported rows.
+func (e *SnapshotExport) ExportTable(table string, write func(row []byte) error) (int64, error) {
+	primaryKey, err := getPrimaryKeyColumns(e.tx, table)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := fmt.Sprintf(`SELECT row_to_json(snapshot_row)::TEXT FROM %s snapshot_row`, pq.QuoteIdentifier(table))
+	if len(primaryKey) > 0 {
+		stmt += fmt.Sprintf(` ORDER BY %s`, quoteIdentifiers(primaryKey))
+	}
+
+	rows, err := e.tx.Query(stmt)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var row []byte
+		err = rows.Scan(&row)
+		if err != nil {
+			return count, err
+		}
+
+		err = write(row)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
+
+// Columns returns the columns of the given table that are exported by ExportTableText,
+// excluding the generated ones that are computed by the database
+func (e *SnapshotExport) Columns(table string) ([]string, error) {
+	return getInsertableColumns(e.tx, table)
+}
+
+// ExportTableText calls the given function for each row of the given table, passing the values of the given
+// columns using their PostgreSQL text representation. Rows are sorted by primary key, like inside ExportTable.
+// It returns the number of exported rows.
+func (e *SnapshotExport) ExportTableText(
+	table string, columns []string, write func(values []sql.NullString) error,
+) (int64, error) {
+	primaryKey, err := getPrimaryKeyColumns(e.tx, table)
+	if err != nil {
+		return 0, err
+	}
+
+	textColumns := make([]string, len(columns))
+	for i, column := range columns {
+		textColumns[i] = pq.QuoteIdentifier(column) + "::TEXT"
+	}
+
+	stmt := fmt.Sprintf(`SELECT %s FROM %s`, strings.Join(textColumns, ", "), pq.QuoteIdentifier(table))
+	if len(primaryKey) > 0 {
+		stmt += fmt.Sprintf(` ORDER BY %s`, quoteIdentifiers(primaryKey))
+	}
+
+	rows, err := e.tx.Query(stmt)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return count, err
+		}
+
+		err = write(values)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
+
+// Close ends the snapshot export
+func (e *SnapshotExport) Close() error {
+	return e.tx.Rollback()
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// SnapshotImport allows to import the content of a snapshot inside a single transaction,
+// so that the database is left untouched if the import fails
+type SnapshotImport struct {
+	tx *sqlx.Tx
+
+	// columns contains the insertable columns of each imported table
+	columns map[string][]string
+
+	// columnTypes contains the types of the insertable columns of each table imported using their text representation
+	columnTypes map[string]map[string]string
+}
+
+// BeginSnapshotImport starts a new snapshot import
+func (db *Db) BeginSnapshotImport() (*SnapshotImport, error) {
+	tx, err := db.SQL.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotImport{tx: tx, columns: map[string][]string{}, columnTypes: map[string]map[string]string{}}, nil
+}
+
+// SchemaVersion returns the version of the latest migration applied to the database
+func (i *SnapshotImport) SchemaVersion() (string, error) {
+	return getSchemaVersion(i.tx)
+}
+
+// Tables returns the tables that can be imported, sorted so that each table comes after the ones it references
+func (i *SnapshotImport) Tables() ([]string, error) {
+	return getSnapshotTables(i.tx)
+}
+
+// CheckEmpty returns an error if any of the given tables contains some rows,
+// so that a snapshot is never merged with existing data
+func (i *SnapshotImport) CheckEmpty(tables []string) error {
+	for _, table := range tables {
+		var hasRows bool
+		err := i.tx.Get(&hasRows, fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s)`, pq.QuoteIdentifier(table)))
+		if err != nil {
+			return err
+		}
+
+		if hasRows {
+			return fmt.Errorf("table %s is not empty: snapshots can only be imported inside an empty database", table)
+		}
+	}
+	return nil
+}
+
+// ImportRows stores the given rows, each one encoded as a JSON object, inside the given table
+func (i *SnapshotImport) ImportRows(table string, rows []json.RawMessage) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns, found := i.columns[table]
+	if !found {
+		var err error
+		columns, err = getInsertableColumns(i.tx, table)
+		if err != nil {
+			return err
+		}
+		i.columns[table] = columns
+	}
+
+	bz, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+
+	quotedTable, quotedColumns := pq.QuoteIdentifier(table), quoteIdentifiers(columns)
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM json_populate_recordset(NULL::%s, $1::JSON)`,
+		quotedTable, quotedColumns, quotedColumns, quotedTable)
+	_, err = i.tx.Exec(stmt, string(bz))
+	return err
+}
+
+// ImportTextRows stores the given rows inside the given table. The values of each row are the ones of the given
+// columns, using their PostgreSQL text representation.
+func (i *SnapshotImport) ImportTextRows(table string, columns []string, rows [][]sql.NullString) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columnTypes, err := i.getColumnTypes(table)
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(columns))
+	unnestArgs := make([]string, len(columns))
+	selected := make([]string, len(columns))
+	for index, column := range columns {
+		columnType, found := columnTypes[column]
+		if !found {
+			return fmt.Errorf("column %s of table %s cannot be imported", column, table)
+		}
+
+		columnValues := make([]sql.NullString, len(rows))
+		for rowIndex, row := range rows {
+			if len(row) != len(columns) {
+				return fmt.Errorf("invalid row of table %s: %d values found, expected %d", table, len(row), len(columns))
+			}
+			columnValues[rowIndex] = row[index]
+		}
+
+		values[index] = pq.Array(columnValues)
+		unnestArgs[index] = fmt.Sprintf("$%d::TEXT[]", index+1)
+		selected[index] = fmt.Sprintf("snapshot_row.%s::%s", pq.QuoteIdentifier(column), columnType)
+	}
+
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) SELECT %s FROM unnest(%s) AS snapshot_row(%s)`,
+		pq.QuoteIdentifier(table), quoteIdentifiers(columns), strings.Join(selected, ", "),
+		strings.Join(unnestArgs, ", "), quoteIdentifiers(columns))
+	_, err = i.tx.Exec(stmt, values...)
+	return err
+}
+
+// getColumnTypes returns the types of the insertable columns of the given table
+func (i *SnapshotImport) getColumnTypes(table string) (map[string]string, error) {
+	columnTypes, found := i.columnTypes[table]
+	if found {
+		return columnTypes, nil
+	}
+
+	var rows []struct {
+		Name string `db:"attname"`
+		Type string `db:"column_type"`
+	}
+	stmt := `
+SELECT attname, format_type(atttypid, atttypmod) AS column_type
+FROM pg_attribute
+WHERE attrelid = $1::TEXT::regclass
+  AND attnum > 0
+  AND NOT attisdropped
+  AND attgenerated = ''`
+	err := i.tx.Select(&rows, stmt, pq.QuoteIdentifier(table))
+	if err != nil {
+		return nil, err
+	}
+
+	columnTypes = make(map[string]string, len(rows))
+	for _, row := range rows {
+		columnTypes[row.Name] = row.Type
+	}
+
+	i.columnTypes[table] = columnTypes
+	return columnTypes, nil
+}
+
+// Commit updates the sequences of the imported tables so that the rows stored after the import do not conflict
+// with the imported ones, and then commits the import
+func (i *SnapshotImport) Commit() error {
+	tables := make([]string, 0, len(i.columns)+len(i.columnTypes))
+	for table := range i.columns {
+		tables = append(tables, table)
+	}
+	for table := range i.columnTypes {
+		if _, found := i.columns[table]; !found {
+			tables = append(tables, table)
+		}
+	}
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		var sequences []struct {
+			Column   string `db:"column_name"`
+			Sequence string `db:"sequence_name"`
+		}
+		stmt := `
+SELECT attname AS column_name, pg_get_serial_sequence($1::TEXT, attname) AS sequence_name
+FROM pg_attribute
+WHERE attrelid = $1::TEXT::regclass
+  AND attnum > 0
+  AND NOT attisdropped
+  AND pg_get_serial_sequence($1::TEXT, attname) IS NOT NULL`
+		err := i.tx.Select(&sequences, stmt, pq.QuoteIdentifier(table))
+		if err != nil {
+			return err
+		}
+
+		for _, sequence := range sequences {
+			stmt = fmt.Sprintf(`SELECT setval($1, COALESCE(MAX(%s), 0) + 1, false) FROM %s`,
+				pq.QuoteIdentifier(sequence.Column), pq.QuoteIdentifier(table))
+			_, err = i.tx.Exec(stmt, sequence.Sequence)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return i.tx.Commit()
+}
+
+// Rollback discards all the rows imported so far
+func (i *SnapshotImport) Rollback() error {
+	return i.tx.Rollback()
+}
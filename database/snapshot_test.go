@@ -0,0 +1,201 @@
+package database_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestSnapshot() {
+	owner := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	_, err := suite.database.SQL.Exec(`INSERT INTO block (height, hash, timestamp) VALUES (10, 'hash', NOW())`)
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		owner,
+		owner,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.NewSection(1, 0, 0, "Root", ""), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.NewSection(1, 1, 0, "Child", ""), 10))
+	suite.Require().NoError(err)
+
+	// Export all the tables
+	export, err := suite.database.BeginSnapshotExport()
+	suite.Require().NoError(err)
+
+	height, err := export.Height()
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(10), height)
+
+	tables, err := export.Tables()
+	suite.Require().NoError(err)
+	suite.Require().NotContains(tables, "schema_migrations")
+	suite.Require().NotContains(tables, "notification_default")
+	suite.Require().Less(indexOf(tables, "block"), indexOf(tables, "subspace"))
+	suite.Require().Less(indexOf(tables, "subspace"), indexOf(tables, "subspace_section"))
+
+	exported := map[string][]json.RawMessage{}
+	for _, table := range tables {
+		table := table
+		_, err = export.ExportTable(table, func(row []byte) error {
+			exported[table] = append(exported[table], row)
+			return nil
+		})
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(export.Close())
+	suite.Require().Len(exported["subspace_section"], 2)
+
+	// Import them inside the empty database
+	suite.SetupTest()
+
+	snapshotImport, err := suite.database.BeginSnapshotImport()
+	suite.Require().NoError(err)
+	suite.Require().NoError(snapshotImport.CheckEmpty(tables))
+
+	for _, table := range tables {
+		err = snapshotImport.ImportRows(table, exported[table])
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(snapshotImport.Commit())
+
+	var sections []struct {
+		Name        string `db:"name"`
+		ParentRowID *int64 `db:"parent_row_id"`
+	}
+	err = suite.database.SQL.Select(&sections, `SELECT name, parent_row_id FROM subspace_section ORDER BY id`)
+	suite.Require().NoError(err)
+	suite.Require().Len(sections, 2)
+	suite.Require().Equal("Child", sections[1].Name)
+	suite.Require().NotNil(sections[1].ParentRowID)
+
+	// Make sure the sequences have been updated
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.NewSection(1, 2, 0, "New section", ""), 11))
+	suite.Require().NoError(err)
+
+	// Make sure importing inside a non-empty database fails
+	snapshotImport, err = suite.database.BeginSnapshotImport()
+	suite.Require().NoError(err)
+	defer snapshotImport.Rollback()
+	suite.Require().Error(snapshotImport.CheckEmpty(tables))
+}
+
+func (suite *DbTestSuite) TestSnapshot_Heights() {
+	_, err := suite.database.SQL.Exec(`
+INSERT INTO block (height, hash, timestamp)
+VALUES (5, 'hash5', NOW()), (6, 'hash6', NOW()), (7, 'hash7', NOW()), (9, 'hash9', NOW()), (10, 'hash10', NOW())`)
+	suite.Require().NoError(err)
+
+	export, err := suite.database.BeginSnapshotExport()
+	suite.Require().NoError(err)
+	defer export.Close()
+
+	startHeight, err := export.StartHeight()
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(5), startHeight)
+
+	height, err := export.Height()
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(7), height)
+
+	latestHeight, err := export.LatestHeight()
+	suite.Require().NoError(err)
+	suite.Require().Equal(int64(10), latestHeight)
+}
+
+func (suite *DbTestSuite) TestSnapshot_Text() {
+	owner := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	_, err := suite.database.SQL.Exec(`INSERT INTO block (height, hash, timestamp) VALUES (10, 'hash', NOW())`)
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		owner,
+		owner,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.NewSection(1, 0, 0, "Root", ""), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.NewSection(1, 1, 0, "Child", ""), 10))
+	suite.Require().NoError(err)
+
+	// Export all the tables using their text representation
+	export, err := suite.database.BeginSnapshotExport()
+	suite.Require().NoError(err)
+
+	tables, err := export.Tables()
+	suite.Require().NoError(err)
+
+	columns := map[string][]string{}
+	exported := map[string][][]sql.NullString{}
+	for _, table := range tables {
+		table := table
+		columns[table], err = export.Columns(table)
+		suite.Require().NoError(err)
+
+		_, err = export.ExportTableText(table, columns[table], func(values []sql.NullString) error {
+			exported[table] = append(exported[table], values)
+			return nil
+		})
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(export.Close())
+
+	// Import them inside the empty database
+	suite.SetupTest()
+
+	snapshotImport, err := suite.database.BeginSnapshotImport()
+	suite.Require().NoError(err)
+
+	for _, table := range tables {
+		err = snapshotImport.ImportTextRows(table, columns[table], exported[table])
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(snapshotImport.Commit())
+
+	var subspaces []struct {
+		Name         string    `db:"name"`
+		Treasury     string    `db:"treasury_address"`
+		CreationTime time.Time `db:"creation_time"`
+	}
+	err = suite.database.SQL.Select(&subspaces, `SELECT name, treasury_address, creation_time FROM subspace`)
+	suite.Require().NoError(err)
+	suite.Require().Len(subspaces, 1)
+	suite.Require().Equal("Test subspace", subspaces[0].Name)
+	suite.Require().True(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).Equal(subspaces[0].CreationTime))
+
+	// Make sure the sequences have been updated
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.NewSection(1, 2, 0, "New section", ""), 11))
+	suite.Require().NoError(err)
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -27,7 +27,7 @@ ON CONFLICT (id) DO UPDATE
         height = excluded.height
 WHERE subspace.height <= excluded.height`
 
-	_, err := db.SQL.Exec(stmt,
+	_, err := db.conn().Exec(stmt,
 		subspace.ID,
 		subspace.Name,
 		dbtypes.ToNullString(subspace.Description),
@@ -44,15 +44,13 @@ WHERE subspace.height <= excluded.height`
 // DeleteSubspace removes the subspace with the given id from the database
 func (db *Db) DeleteSubspace(height int64, id uint64) error {
 	stmt := `DELETE FROM subspace WHERE id = $1 AND height <= $2`
-	_, err := db.SQL.Exec(stmt, id, height)
-	return err
+	return db.execDelete(height, stmt, id, height)
 }
 
 // DeleteAllSubspaces removes all the subspaces from the database
 func (db *Db) DeleteAllSubspaces(height int64) error {
 	stmt := `DELETE FROM subspace WHERE height <= $1`
-	_, err := db.SQL.Exec(stmt, height)
-	return err
+	return db.execDelete(height, stmt, height)
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -62,7 +60,7 @@ func (db *Db) getSectionRowID(subspaceID uint64, sectionID uint32) (sql.NullInt6
 	stmt := `SELECT row_id FROM subspace_section WHERE subspace_id = $1 and id = $2`
 
 	var rowID int64
-	err := db.SQL.QueryRow(stmt, subspaceID, sectionID).Scan(&rowID)
+	err := db.conn().QueryRow(stmt, subspaceID, sectionID).Scan(&rowID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return sql.NullInt64{Int64: 0, Valid: false}, nil
 	}
@@ -87,7 +85,7 @@ ON CONFLICT ON CONSTRAINT unique_subspace_section DO UPDATE
         height = excluded.height
 WHERE subspace_section.height <= excluded.height`
 
-	_, err = db.SQL.Exec(stmt,
+	_, err = db.conn().Exec(stmt,
 		section.SubspaceID,
 		section.ID,
 		parentRowID,
@@ -101,8 +99,7 @@ WHERE subspace_section.height <= excluded.height`
 // DeleteSection removes the given section from the subspace
 func (db *Db) DeleteSection(height int64, subspaceID uint64, sectionID uint32) error {
 	stmt := `DELETE FROM subspace_section WHERE subspace_id = $1 AND id = $2 AND height <= $3`
-	_, err := db.SQL.Exec(stmt, subspaceID, sectionID, height)
-	return err
+	return db.execDelete(height, stmt, subspaceID, sectionID, height)
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -112,7 +109,7 @@ func (db *Db) getUserGroupRowID(subspaceID uint64, groupID uint32) (uint64, erro
 	stmt := `SELECT row_id FROM subspace_user_group WHERE subspace_id = $1 and id = $2`
 
 	var rowID uint64
-	err := db.SQL.QueryRow(stmt, subspaceID, groupID).Scan(&rowID)
+	err := db.conn().QueryRow(stmt, subspaceID, groupID).Scan(&rowID)
 	return rowID, err
 }
 
@@ -137,7 +134,7 @@ ON CONFLICT ON CONSTRAINT unique_subspace_user_group DO UPDATE
         height = excluded.height
 WHERE subspace_user_group.height <= excluded.height`
 
-	_, err = db.SQL.Exec(stmt,
+	_, err = db.conn().Exec(stmt,
 		group.SubspaceID,
 		sectionRowID,
 		group.ID,
@@ -152,8 +149,7 @@ WHERE subspace_user_group.height <= excluded.height`
 // DeleteUserGroup removes the given user group from the subspace
 func (db *Db) DeleteUserGroup(height int64, subspaceID uint64, groupID uint32) error {
 	stmt := `DELETE FROM subspace_user_group WHERE subspace_id = $1 AND id = $2 AND height <= $3`
-	_, err := db.SQL.Exec(stmt, subspaceID, groupID, height)
-	return err
+	return db.execDelete(height, stmt, subspaceID, groupID, height)
 }
 
 // AddUserToGroup adds a user to a user group
@@ -168,7 +164,7 @@ INSERT INTO subspace_user_group_member (group_row_id, member_address, height)
 VALUES ($1, $2, $3)
 ON CONFLICT ON CONSTRAINT unique_subspace_group_membership DO NOTHING`
 
-	_, err = db.SQL.Exec(stmt, rowID, member.Member, member.Height)
+	_, err = db.conn().Exec(stmt, rowID, member.Member, member.Height)
 	return err
 }
 
@@ -180,8 +176,7 @@ func (db *Db) RemoveUserFromGroup(member types.UserGroupMember) error {
 	}
 
 	stmt := `DELETE FROM subspace_user_group_member WHERE group_row_id = $1 AND member_address = $2 AND height <= $3`
-	_, err = db.SQL.Exec(stmt, rowID, member.Member, member.Height)
-	return err
+	return db.execDelete(member.Height, stmt, rowID, member.Member, member.Height)
 }
 
 // --------------------------------------------------------------------------------------------------------------------
@@ -206,7 +201,7 @@ ON CONFLICT ON CONSTRAINT unique_subspace_permission DO UPDATE
         height = excluded.height
 WHERE subspace_user_permission.height <= excluded.height`
 
-	_, err = db.SQL.Exec(stmt,
+	_, err = db.conn().Exec(stmt,
 		sectionRowID,
 		permission.User,
 		dbtypes.ConvertPermissions(permission.Permissions),
@@ -223,6 +218,5 @@ func (db *Db) DeleteUserPermission(permission types.UserPermission) error {
 	}
 
 	stmt := `DELETE FROM subspace_user_permission WHERE section_row_id = $1 AND user_address = $2 AND height <= $3`
-	_, err = db.SQL.Exec(stmt, sectionRowID, permission.User, permission.Height)
-	return err
+	return db.execDelete(permission.Height, stmt, sectionRowID, permission.User, permission.Height)
 }
@@ -0,0 +1,272 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dbtypes "github.com/desmos-labs/athena/v2/database/types"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// SaveUserGroupMemberHistory starts a new membership period for the given member at the member height,
+// unless the member already has a period covering such height
+func (db *Db) SaveUserGroupMemberHistory(member types.UserGroupMember, timestamp time.Time) error {
+	return db.setHistoryValue(memberTimeline(member), &historyValue{}, member.Height, timestamp)
+}
+
+// EndUserGroupMemberHistory ends the membership period of the given member covering the member height
+func (db *Db) EndUserGroupMemberHistory(member types.UserGroupMember, timestamp time.Time) error {
+	return db.setHistoryValue(memberTimeline(member), nil, member.Height, timestamp)
+}
+
+// SaveUserGroupPermissionsHistory starts a new period for the permissions of the given group at the group height,
+// ending the period covering such height if the permissions have changed
+func (db *Db) SaveUserGroupPermissionsHistory(group types.UserGroup, timestamp time.Time) error {
+	timeline := historyTimeline{
+		table:      "subspace_user_group_permission_history",
+		keyColumns: []string{"subspace_id", "group_id"},
+		keyValues:  []interface{}{group.SubspaceID, group.ID},
+	}
+	value := &historyValue{
+		columns: []string{"section_id", "permissions"},
+		types:   []string{"BIGINT", "TEXT[]"},
+		values:  []interface{}{group.SectionID, dbtypes.ConvertPermissions(group.Permissions)},
+	}
+	return db.setHistoryValue(timeline, value, group.Height, timestamp)
+}
+
+// EndUserGroupHistory ends the ongoing permissions and membership periods of the given group
+func (db *Db) EndUserGroupHistory(height int64, subspaceID uint64, groupID uint32, timestamp time.Time) error {
+	return db.endSubspaceHistory(height, timestamp, `subspace_id = $3 AND group_id = $4`, subspaceID, groupID)
+}
+
+// SaveUserPermissionHistory starts a new period for the given user permissions at the permission height, ending the
+// period covering such height if the permissions have changed. If the permissions are nil, such period is ended
+// without starting a new one.
+func (db *Db) SaveUserPermissionHistory(permission types.UserPermission, timestamp time.Time) error {
+	timeline := historyTimeline{
+		table:      "subspace_user_permission_history",
+		keyColumns: []string{"subspace_id", "section_id", "user_address"},
+		keyValues:  []interface{}{permission.SubspaceID, permission.SectionID, permission.User},
+	}
+
+	var value *historyValue
+	if permission.Permissions != nil {
+		value = &historyValue{
+			columns: []string{"permissions"},
+			types:   []string{"TEXT[]"},
+			values:  []interface{}{dbtypes.ConvertPermissions(permission.Permissions)},
+		}
+	}
+	return db.setHistoryValue(timeline, value, permission.Height, timestamp)
+}
+
+// memberTimeline returns the timeline of the membership of the given member
+func memberTimeline(member types.UserGroupMember) historyTimeline {
+	return historyTimeline{
+		table:      "subspace_user_group_member_history",
+		keyColumns: []string{"subspace_id", "group_id", "member_address"},
+		keyValues:  []interface{}{member.SubspaceID, member.GroupID, member.Member},
+	}
+}
+
+// historyTimeline identifies the periods of a history table that refer to the same entity
+type historyTimeline struct {
+	table      string
+	keyColumns []string
+	keyValues  []interface{}
+}
+
+// historyValue contains the values of the non-key columns of a history period.
+// Timelines that only tell whether the entity existed (e.g. memberships) use an empty value.
+type historyValue struct {
+	columns []string
+	types   []string
+	values  []interface{}
+}
+
+// setHistoryValue records that the entity of the given timeline had the given value starting from the given height.
+// If the value is nil, the period covering the given height is ended there instead.
+// Since the heights can be parsed out of order, the period covering the given height is split, and the new period
+// ends where the split one ended or, if no period covers the given height, where the next known period starts.
+func (db *Db) setHistoryValue(timeline historyTimeline, value *historyValue, height int64, timestamp time.Time) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Build the condition selecting the timeline periods, using the first parameters for the key values
+	var conditions []string
+	for i, column := range timeline.keyColumns {
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, i+1))
+	}
+	condition := strings.Join(conditions, " AND ")
+	args := timeline.keyValues
+	heightParam := fmt.Sprintf("$%d", len(args)+1)
+
+	// Tell whether the covering period has the given value, using the parameters following the height for it
+	sameValue := "TRUE"
+	if value != nil && len(value.columns) > 0 {
+		var params []string
+		for i, columnType := range value.types {
+			params = append(params, fmt.Sprintf("$%d::%s", len(args)+2+i, columnType))
+		}
+		sameValue = fmt.Sprintf("(%s) IS NOT DISTINCT FROM (%s)",
+			strings.Join(value.columns, ", "), strings.Join(params, ", "))
+	}
+
+	var valueArgs []interface{}
+	if value != nil {
+		valueArgs = value.values
+	}
+
+	// Get the period covering the given height, if any
+	var rowID, validFromHeight int64
+	var validToHeight sql.NullInt64
+	var validToTime sql.NullTime
+	var same bool
+	stmt := fmt.Sprintf(`
+SELECT row_id, valid_from_height, valid_to_height, valid_to_time, %[1]s FROM %[2]s
+WHERE %[3]s AND valid_from_height <= %[4]s AND (valid_to_height IS NULL OR valid_to_height > %[4]s)
+FOR UPDATE`, sameValue, timeline.table, condition, heightParam)
+	queryArgs := append(append(append([]interface{}{}, args...), height), valueArgs...)
+	err = tx.QueryRow(stmt, queryArgs...).Scan(&rowID, &validFromHeight, &validToHeight, &validToTime, &same)
+	found := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	switch {
+	case value == nil && !found:
+		// Nothing to end
+		return tx.Commit()
+
+	case value == nil && validFromHeight == height:
+		// The period started and ended at the same height, so it never existed
+		_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE row_id = $1`, timeline.table), rowID)
+
+	case value == nil:
+		_, err = tx.Exec(fmt.Sprintf(`
+UPDATE %s SET valid_to_height = $2, valid_to_time = $3 WHERE row_id = $1`, timeline.table),
+			rowID, height, timestamp)
+
+	case found && same:
+		// The value did not change
+		return tx.Commit()
+
+	case found && validFromHeight == height:
+		// The period starts at the same height, so its value is replaced
+		var assignments []string
+		for i, column := range value.columns {
+			assignments = append(assignments, fmt.Sprintf("%s = $%d::%s", column, i+2, value.types[i]))
+		}
+		_, err = tx.Exec(fmt.Sprintf(`UPDATE %s SET %s WHERE row_id = $1`,
+			timeline.table, strings.Join(assignments, ", ")), append([]interface{}{rowID}, value.values...)...)
+
+	case found:
+		// Split the covering period, making the new one end where the covering one ended
+		_, err = tx.Exec(fmt.Sprintf(`
+UPDATE %s SET valid_to_height = $2, valid_to_time = $3 WHERE row_id = $1`, timeline.table),
+			rowID, height, timestamp)
+		if err != nil {
+			return err
+		}
+		err = insertHistoryPeriod(tx, timeline, value, height, timestamp, validToHeight, validToTime)
+
+	default:
+		// Make the new period end where the next known period starts, if any
+		stmt = fmt.Sprintf(`
+SELECT valid_from_height, valid_from_time FROM %s WHERE %s AND valid_from_height > %s 
+ORDER BY valid_from_height LIMIT 1`, timeline.table, condition, heightParam)
+		var nextHeight sql.NullInt64
+		var nextTime sql.NullTime
+		err = tx.QueryRow(stmt, append(append([]interface{}{}, args...), height)...).Scan(&nextHeight, &nextTime)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		err = insertHistoryPeriod(tx, timeline, value, height, timestamp, nextHeight, nextTime)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertHistoryPeriod inserts a new period for the given timeline having the given value and bounds
+func insertHistoryPeriod(
+	tx sqlTx, timeline historyTimeline, value *historyValue,
+	fromHeight int64, fromTime time.Time, toHeight sql.NullInt64, toTime sql.NullTime,
+) error {
+	columns := append(append([]string{}, timeline.keyColumns...), value.columns...)
+	columns = append(columns, "valid_from_height", "valid_from_time", "valid_to_height", "valid_to_time")
+
+	values := append(append([]interface{}{}, timeline.keyValues...), value.values...)
+	values = append(values, fromHeight, fromTime, toHeight, toTime)
+
+	params := make([]string, len(values))
+	for i := range params {
+		params[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	stmt := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		timeline.table, strings.Join(columns, ", "), strings.Join(params, ", "))
+	_, err := tx.Exec(stmt, values...)
+	return err
+}
+
+// EndSectionHistory ends the ongoing periods of the permissions set inside the given section,
+// and of the groups contained inside it
+func (db *Db) EndSectionHistory(height int64, subspaceID uint64, sectionID uint32, timestamp time.Time) error {
+	condition := `subspace_id = $3 AND (
+    section_id = $4 OR 
+    group_id IN (
+        SELECT group_id FROM subspace_user_group_permission_history 
+        WHERE subspace_id = $3 AND section_id = $4 
+          AND valid_from_height <= $1 AND (valid_to_height IS NULL OR valid_to_height >= $1)
+    )
+)`
+	return db.endSubspaceHistory(height, timestamp, condition, subspaceID, sectionID)
+}
+
+// EndSubspaceHistory ends all the ongoing periods of the given subspace
+func (db *Db) EndSubspaceHistory(height int64, subspaceID uint64, timestamp time.Time) error {
+	return db.endSubspaceHistory(height, timestamp, `subspace_id = $3`, subspaceID)
+}
+
+// endSubspaceHistory ends the periods covering the given height and matching the given condition inside all the
+// history tables.
+// The condition receives the given arguments starting from the $3 parameter, and it can reference the section_id
+// and group_id columns, which are considered null inside the tables not having them.
+func (db *Db) endSubspaceHistory(height int64, timestamp time.Time, condition string, args ...interface{}) error {
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tables := map[string]string{
+		"subspace_user_group_member_history":     `NULL::BIGINT AS section_id, group_id`,
+		"subspace_user_group_permission_history": `section_id, group_id`,
+		"subspace_user_permission_history":       `section_id, NULL::BIGINT AS group_id`,
+	}
+	for table, columns := range tables {
+		stmt := `
+UPDATE ` + table + ` SET valid_to_height = $1, valid_to_time = $2
+WHERE row_id IN (
+    SELECT row_id FROM (SELECT row_id, subspace_id, ` + columns + ` FROM ` + table + ` 
+                  WHERE valid_from_height < $1 AND (valid_to_height IS NULL OR valid_to_height > $1)) AS covering 
+    WHERE ` + condition + `
+)`
+		_, err = tx.Exec(stmt, append([]interface{}{height, timestamp}, args...)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,104 @@
+package database_test
+
+import (
+	"time"
+
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestSubspaceUsersWithPermissionAt() {
+	member := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	user := "cosmos10s22qjua2n3law0ymstm3txm7764mfk2cjawq5"
+	timestamp := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Give the permission to a group at height 10, and add the member to it at height 20
+	group := types.NewUserGroup(subspacestypes.NewUserGroup(
+		1,
+		0,
+		1,
+		"Editors",
+		"",
+		subspacestypes.NewPermissions(subspacestypes.PermissionEditSubspace),
+	), 10)
+	err := suite.database.SaveUserGroupPermissionsHistory(group, timestamp)
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveUserGroupMemberHistory(types.NewUserGroupMember(1, 1, member, 20), timestamp)
+	suite.Require().NoError(err)
+
+	// Give the permission to the user directly at height 15, and remove it at height 30
+	permission := types.NewUserPermission(subspacestypes.NewUserPermission(
+		1,
+		0,
+		user,
+		subspacestypes.NewPermissions(subspacestypes.PermissionEditSubspace),
+	), 15)
+	err = suite.database.SaveUserPermissionHistory(permission, timestamp)
+	suite.Require().NoError(err)
+
+	permission = types.NewUserPermission(subspacestypes.NewUserPermission(1, 0, user, nil), 30)
+	err = suite.database.SaveUserPermissionHistory(permission, timestamp)
+	suite.Require().NoError(err)
+
+	// Remove the member from the group at height 40
+	err = suite.database.EndUserGroupMemberHistory(types.NewUserGroupMember(1, 1, member, 40), timestamp)
+	suite.Require().NoError(err)
+
+	usersAt := func(height int64) []string {
+		var users []string
+		err := suite.database.SQL.Select(&users, `
+SELECT user_address FROM subspace_users_with_permission_at($1, $2, $3) ORDER BY user_address`,
+			1, string(subspacestypes.PermissionEditSubspace), height)
+		suite.Require().NoError(err)
+		return users
+	}
+
+	suite.Require().Empty(usersAt(5))
+	suite.Require().Equal([]string{user}, usersAt(15))
+	suite.Require().Equal([]string{user, member}, usersAt(25))
+	suite.Require().Equal([]string{member}, usersAt(35))
+	suite.Require().Empty(usersAt(40))
+
+	// Roll back the history and make sure the membership is ongoing again
+	err = suite.database.RollbackToHeight(35)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{member}, usersAt(50))
+}
+
+func (suite *DbTestSuite) TestSaveUserPermissionHistory_OutOfOrder() {
+	user := "cosmos10s22qjua2n3law0ymstm3txm7764mfk2cjawq5"
+	timestamp := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	savePermissions := func(height int64, permissions subspacestypes.Permissions) {
+		permission := types.NewUserPermission(subspacestypes.NewUserPermission(1, 0, user, permissions), height)
+		err := suite.database.SaveUserPermissionHistory(permission, timestamp)
+		suite.Require().NoError(err)
+	}
+
+	// Parse the permissions change at height 30 before the ones at heights 10 and 20
+	savePermissions(30, subspacestypes.NewPermissions(subspacestypes.PermissionEverything))
+	savePermissions(10, subspacestypes.NewPermissions(subspacestypes.PermissionEditSubspace))
+	savePermissions(20, nil)
+
+	usersAt := func(height int64) []string {
+		var users []string
+		err := suite.database.SQL.Select(&users, `SELECT user_address FROM subspace_users_with_permission_at($1, $2, $3)`,
+			1, string(subspacestypes.PermissionEditSubspace), height)
+		suite.Require().NoError(err)
+		return users
+	}
+
+	suite.Require().Empty(usersAt(5))
+	suite.Require().Equal([]string{user}, usersAt(15))
+	suite.Require().Empty(usersAt(25))
+
+	// The everything permission should include the given one
+	suite.Require().Equal([]string{user}, usersAt(35))
+
+	var count int
+	err := suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM subspace_user_permission_history`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(2, count)
+}
@@ -34,7 +34,7 @@ ON CONFLICT ON CONSTRAINT unique_sender_user_tip DO UPDATE
         amount = excluded.amount,
         height = excluded.height
 WHERE tip_user.height <= excluded.height`
-	_, err := db.SQL.Exec(stmt, tip.Sender, target.Address, tip.SubspaceID, pq.Array(dbtypes.NewDbCoins(tip.Amount)), tip.Height)
+	_, err := db.conn().Exec(stmt, tip.Sender, target.Address, tip.SubspaceID, pq.Array(dbtypes.NewDbCoins(tip.Amount)), tip.Height)
 	return err
 }
 
@@ -55,6 +55,23 @@ ON CONFLICT ON CONSTRAINT unique_sender_post_tip DO UPDATE
         amount = excluded.amount,
         height = excluded.height
 WHERE tip_post.height <= excluded.height`
-	_, err = db.SQL.Exec(stmt, tip.Sender, tip.SubspaceID, postRowID, pq.Array(dbtypes.NewDbCoins(tip.Amount)), tip.Height)
-	return err
+
+	tx, err := db.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(stmt, tip.Sender, tip.SubspaceID, postRowID, pq.Array(dbtypes.NewDbCoins(tip.Amount)), tip.Height)
+	if err != nil {
+		return err
+	}
+
+	// Update the tips counters of the post
+	err = updatePostsCounters(tx, postIDCondition, tip.SubspaceID, target.PostID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
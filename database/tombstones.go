@@ -0,0 +1,18 @@
+package database
+
+import (
+	"time"
+)
+
+// PurgeTombstones physically removes all the posts, reactions and profiles that have been marked as deleted
+// longer than the given retention period ago
+func (db *Db) PurgeTombstones(retention time.Duration) error {
+	for _, table := range []string{"reaction", "post", "profile"} {
+		stmt := `DELETE FROM ` + table + ` WHERE deletion_time < NOW() - make_interval(secs => $1)`
+		_, err := db.conn().Exec(stmt, retention.Seconds())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestTombstonePost() {
+	author := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+
+	err := suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		author,
+		author,
+		time.Now(),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+	suite.Require().NoError(err)
+
+	post := poststypes.NewPost(
+		1,
+		0,
+		1,
+		"",
+		"Hello world",
+		author,
+		0,
+		nil,
+		nil,
+		nil,
+		poststypes.REPLY_SETTING_EVERYONE,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+		author,
+	)
+	err = suite.database.SavePost(types.NewPost(post, 10))
+	suite.Require().NoError(err)
+
+	getDeletion := func() (*int64, *string) {
+		var row struct {
+			Height *int64  `db:"deletion_height"`
+			TxHash *string `db:"deletion_tx_hash"`
+		}
+		err := suite.database.SQL.Get(&row, `SELECT deletion_height, deletion_tx_hash FROM post WHERE subspace_id = 1 AND id = 1`)
+		suite.Require().NoError(err)
+		return row.Height, row.TxHash
+	}
+
+	// Tombstoning the post should keep it inside the database
+	deletionTime := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	err = suite.database.TombstonePost(20, 1, 1, "TX_HASH", deletionTime)
+	suite.Require().NoError(err)
+
+	height, txHash := getDeletion()
+	suite.Require().Equal(int64(20), *height)
+	suite.Require().Equal("TX_HASH", *txHash)
+
+	// The deletion time should be the given block time
+	var storedDeletionTime time.Time
+	err = suite.database.SQL.Get(&storedDeletionTime, `SELECT deletion_time FROM post WHERE subspace_id = 1 AND id = 1`)
+	suite.Require().NoError(err)
+	suite.Require().True(deletionTime.Equal(storedDeletionTime))
+
+	// Tombstoned posts should not be considered as stored
+	exists, err := suite.database.HasPost(20, 1, 1)
+	suite.Require().NoError(err)
+	suite.Require().False(exists)
+
+	// Storing the post at a lower height should not restore it
+	err = suite.database.SavePost(types.NewPost(post, 15))
+	suite.Require().NoError(err)
+
+	height, _ = getDeletion()
+	suite.Require().NotNil(height)
+
+	// Storing the post at the deletion height should restore it
+	err = suite.database.SavePost(types.NewPost(post, 20))
+	suite.Require().NoError(err)
+
+	height, txHash = getDeletion()
+	suite.Require().Nil(height)
+	suite.Require().Nil(txHash)
+
+	// Purging should remove only the expired tombstones
+	err = suite.database.TombstonePost(30, 1, 1, "TX_HASH", time.Now().UTC())
+	suite.Require().NoError(err)
+
+	err = suite.database.PurgeTombstones(time.Hour)
+	suite.Require().NoError(err)
+
+	var count int
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post`)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+
+	err = suite.database.PurgeTombstones(-time.Hour)
+	suite.Require().NoError(err)
+
+	err = suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM post`)
+	suite.Require().NoError(err)
+	suite.Require().Zero(count)
+}
+
+func (suite *DbTestSuite) TestTombstoneProfile() {
+	profile := suite.buildProfile("cosmos15c66kjz44zm58xqlcqjwftan4tnaeq7rtmhn4f")
+	err := suite.database.SaveProfile(types.NewProfile(profile, 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.TombstoneProfile(profile.GetAddress().String(), 20, "TX_HASH", time.Now().UTC())
+	suite.Require().NoError(err)
+
+	// Tombstoned profiles should not be returned
+	stored, err := suite.database.GetUserByAddress(profile.GetAddress().String())
+	suite.Require().NoError(err)
+	suite.Require().Nil(stored)
+
+	addresses, err := suite.database.GetProfilesAddresses()
+	suite.Require().NoError(err)
+	suite.Require().Empty(addresses)
+
+	// Creating the profile again should restore it
+	err = suite.database.SaveProfile(types.NewProfile(profile, 30))
+	suite.Require().NoError(err)
+
+	stored, err = suite.database.GetUserByAddress(profile.GetAddress().String())
+	suite.Require().NoError(err)
+	suite.verifyEqual(profile, stored)
+}
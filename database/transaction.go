@@ -0,0 +1,132 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/desmos-labs/athena/v2/x/posts"
+	"github.com/desmos-labs/athena/v2/x/profiles"
+	"github.com/desmos-labs/athena/v2/x/subspaces"
+)
+
+// queryer represents the connection used to run the statements, which is either the database connection
+// pool or the transaction of the current unit of work
+type queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Select(dest interface{}, query string, args ...interface{}) error
+	Get(dest interface{}, query string, args ...interface{}) error
+}
+
+// sqlTx represents a transaction used to perform multiple writes atomically
+type sqlTx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Commit() error
+	Rollback() error
+}
+
+// conn returns the connection that should be used to run the statements
+func (db *Db) conn() queryer {
+	if db.tx != nil {
+		return db.tx
+	}
+	return db.SQL
+}
+
+// begin starts a new transaction. If the database is scoped to a unit of work, a savepoint is created inside its
+// transaction instead, so that committing or rolling it back does not end the unit of work.
+func (db *Db) begin() (sqlTx, error) {
+	if db.tx == nil {
+		return db.SQL.Begin()
+	}
+
+	*db.savepoints++
+	savepoint := &savepointTx{Tx: db.tx.Tx, name: fmt.Sprintf("athena_savepoint_%d", *db.savepoints)}
+	_, err := db.tx.Exec(`SAVEPOINT ` + savepoint.name)
+	if err != nil {
+		return nil, err
+	}
+
+	return savepoint, nil
+}
+
+// InTransaction implements Database
+func (db *Db) InTransaction(fn func(txDb Database) error) error {
+	// Nested units of work are part of the outer one
+	if db.tx != nil {
+		return fn(db)
+	}
+
+	tx, err := db.SQL.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = fn(&Db{
+		cdc:        db.cdc,
+		Database:   db.Database,
+		tx:         tx,
+		savepoints: new(int),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// InPostsTransaction implements posts.Database
+func (db *Db) InPostsTransaction(fn func(txDb posts.Database) error) error {
+	return db.InTransaction(func(txDb Database) error {
+		return fn(txDb)
+	})
+}
+
+// InProfilesTransaction implements profiles.Database
+func (db *Db) InProfilesTransaction(fn func(txDb profiles.Database) error) error {
+	return db.InTransaction(func(txDb Database) error {
+		return fn(txDb)
+	})
+}
+
+// InSubspacesTransaction implements subspaces.Database
+func (db *Db) InSubspacesTransaction(fn func(txDb subspaces.Database) error) error {
+	return db.InTransaction(func(txDb Database) error {
+		return fn(txDb)
+	})
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// savepointTx represents a transaction nested inside the transaction of a unit of work
+type savepointTx struct {
+	*sql.Tx
+	name string
+	done bool
+}
+
+// Commit releases the savepoint, keeping its writes inside the outer transaction
+func (tx *savepointTx) Commit() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+
+	_, err := tx.Tx.Exec(`RELEASE SAVEPOINT ` + tx.name)
+	return err
+}
+
+// Rollback reverts the writes performed after the savepoint has been created
+func (tx *savepointTx) Rollback() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+
+	_, err := tx.Tx.Exec(`ROLLBACK TO SAVEPOINT ` + tx.name)
+	return err
+}
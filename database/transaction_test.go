@@ -0,0 +1,88 @@
+package database_test
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/database"
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestInTransaction() {
+	owner := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	subspace := types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		owner,
+		owner,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+	), 1)
+	post := types.NewPost(poststypes.NewPost(
+		1,
+		0,
+		1,
+		"",
+		"Hello world",
+		owner,
+		0,
+		nil,
+		nil,
+		nil,
+		poststypes.REPLY_SETTING_EVERYONE,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+		owner,
+	), 1)
+
+	countRows := func(table string) int {
+		var count int
+		err := suite.database.SQL.Get(&count, `SELECT COUNT(*) FROM `+table)
+		suite.Require().NoError(err)
+		return count
+	}
+
+	// A failing unit of work should not write anything
+	err := suite.database.InTransaction(func(db database.Database) error {
+		err := db.SaveSubspace(subspace)
+		suite.Require().NoError(err)
+
+		err = db.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+		suite.Require().NoError(err)
+
+		// Writes using nested transactions should be part of the unit of work as well
+		err = db.SavePost(post)
+		suite.Require().NoError(err)
+
+		return fmt.Errorf("error")
+	})
+	suite.Require().Error(err)
+	suite.Require().Zero(countRows("subspace"))
+	suite.Require().Zero(countRows("post"))
+	suite.Require().Zero(countRows("post_counters"))
+
+	// A successful unit of work should write everything
+	err = suite.database.InTransaction(func(db database.Database) error {
+		err := db.SaveSubspace(subspace)
+		if err != nil {
+			return err
+		}
+
+		err = db.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+		if err != nil {
+			return err
+		}
+
+		return db.SavePost(post)
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, countRows("subspace"))
+	suite.Require().Equal(1, countRows("post"))
+	suite.Require().Equal(1, countRows("post_counters"))
+}
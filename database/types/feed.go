@@ -0,0 +1,28 @@
+package types
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FeedPostRow represents a single PostgreSQL row containing the data of a post that is part of a feed
+type FeedPostRow struct {
+	RowID          uint64         `db:"row_id"`
+	SubspaceID     uint64         `db:"subspace_id"`
+	ID             uint64         `db:"id"`
+	AuthorAddress  string         `db:"author_address"`
+	Text           sql.NullString `db:"text"`
+	CreationDate   time.Time      `db:"creation_date"`
+	LastEditedDate sql.NullTime   `db:"last_edited_date"`
+}
+
+// FeedPostReferenceRow represents a single PostgreSQL row containing the data of a post referenced by a feed post
+type FeedPostReferenceRow struct {
+	PostRowID     uint64         `db:"post_row_id"`
+	Type          string         `db:"type"`
+	SubspaceID    uint64         `db:"subspace_id"`
+	ID            uint64         `db:"id"`
+	AuthorAddress string         `db:"author_address"`
+	Text          sql.NullString `db:"text"`
+	CreationDate  time.Time      `db:"creation_date"`
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"database/sql"
+
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+)
+
+// PostContentRow represents a single PostgreSQL row containing the editable content of a post
+type PostContentRow struct {
+	RowID  uint64         `db:"row_id"`
+	Text   sql.NullString `db:"text"`
+	Height int64          `db:"height"`
+}
+
+// PostTextTagRow represents a single PostgreSQL row containing a hashtag or a mention of a post
+type PostTextTagRow struct {
+	Start uint64 `db:"start_index"`
+	End   uint64 `db:"end_index"`
+	Tag   string `db:"tag"`
+}
+
+// PostURLRow represents a single PostgreSQL row containing a url of a post
+type PostURLRow struct {
+	Start        uint64         `db:"start_index"`
+	End          uint64         `db:"end_index"`
+	URL          string         `db:"url"`
+	DisplayValue sql.NullString `db:"display_value"`
+}
+
+// ToTextTags converts the given rows to a slice of poststypes.TextTag
+func ToTextTags(rows []PostTextTagRow) []poststypes.TextTag {
+	tags := make([]poststypes.TextTag, len(rows))
+	for i, row := range rows {
+		tags[i] = poststypes.NewTextTag(row.Start, row.End, row.Tag)
+	}
+	return tags
+}
+
+// ToURLs converts the given rows to a slice of poststypes.Url
+func ToURLs(rows []PostURLRow) []poststypes.Url {
+	urls := make([]poststypes.Url, len(rows))
+	for i, row := range rows {
+		urls[i] = poststypes.NewURL(row.Start, row.End, row.URL, row.DisplayValue.String)
+	}
+	return urls
+}
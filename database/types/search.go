@@ -0,0 +1,16 @@
+package types
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SearchPostRow represents a single PostgreSQL row containing the data of a post returned by a search
+type SearchPostRow struct {
+	SubspaceID     uint64         `db:"subspace_id"`
+	ID             uint64         `db:"id"`
+	AuthorAddress  string         `db:"author_address"`
+	Text           sql.NullString `db:"text"`
+	CreationDate   time.Time      `db:"creation_date"`
+	LastEditedDate sql.NullTime   `db:"last_edited_date"`
+}
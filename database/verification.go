@@ -0,0 +1,222 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// selectEntityStates runs the given statement and returns the states it selects.
+// The statement must return the entity key as the first column, followed by the compared fields.
+func (db *Db) selectEntityStates(stmt string, args ...interface{}) ([]types.EntityState, error) {
+	rows, err := db.conn().Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var states []types.EntityState
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := make(map[string]string, len(columns)-1)
+		for i, column := range columns[1:] {
+			fields[column] = values[i+1].String
+		}
+
+		states = append(states, types.NewEntityState(values[0].String, fields))
+	}
+
+	return states, rows.Err()
+}
+
+// GetSubspacesState returns the state of all the subspaces stored inside the database
+func (db *Db) GetSubspacesState() ([]types.EntityState, error) {
+	stmt := `
+SELECT id::TEXT                       AS key,
+       name,
+       COALESCE(description, '')      AS description,
+       COALESCE(treasury_address, '') AS treasury,
+       owner_address                  AS owner,
+       creator_address                AS creator
+FROM subspace`
+	return db.selectEntityStates(stmt)
+}
+
+// GetSectionsState returns the state of all the sections of the given subspace stored inside the database
+func (db *Db) GetSectionsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT section.subspace_id || '/' || section.id AS key,
+       COALESCE(parent.id, 0)::TEXT           AS parent_id,
+       section.name,
+       COALESCE(section.description, '')      AS description
+FROM subspace_section section
+         LEFT JOIN subspace_section parent ON parent.row_id = section.parent_row_id
+WHERE section.subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetUserGroupsState returns the state of all the user groups of the given subspace stored inside the database
+func (db *Db) GetUserGroupsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT user_group.subspace_id || '/' || user_group.id AS key,
+       section.id::TEXT                             AS section_id,
+       user_group.name,
+       COALESCE(user_group.description, '')         AS description,
+       array_to_string(ARRAY(
+           SELECT permission FROM unnest(user_group.permissions) AS permission ORDER BY permission COLLATE "C"
+       ), ',')                                      AS permissions
+FROM subspace_user_group user_group
+         JOIN subspace_section section ON section.row_id = user_group.section_row_id
+WHERE user_group.subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetUserGroupMembersState returns the state of all the user group members of the given subspace
+// stored inside the database
+func (db *Db) GetUserGroupMembersState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT user_group.subspace_id || '/' || user_group.id || '/' || member.member_address AS key
+FROM subspace_user_group_member member
+         JOIN subspace_user_group user_group ON user_group.row_id = member.group_row_id
+WHERE user_group.subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetUserPermissionsState returns the state of all the user permissions of the given subspace
+// stored inside the database
+func (db *Db) GetUserPermissionsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT section.subspace_id || '/' || section.id || '/' || permission.user_address AS key,
+       array_to_string(ARRAY(
+           SELECT value FROM unnest(permission.permissions) AS value ORDER BY value COLLATE "C"
+       ), ',')                                                                   AS permissions
+FROM subspace_user_permission permission
+         JOIN subspace_section section ON section.row_id = permission.section_row_id
+WHERE section.subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetPostsState returns the state of all the non-deleted posts of the given subspace stored inside the database
+func (db *Db) GetPostsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT post.subspace_id || '/' || post.id   AS key,
+       section.id::TEXT                    AS section_id,
+       COALESCE(post.external_id, '')      AS external_id,
+       COALESCE(post.text, '')             AS text,
+       post.author_address                 AS author,
+       COALESCE(post.owner_address, '')    AS owner,
+       COALESCE(conversation.id, 0)::TEXT  AS conversation_id,
+       post.reply_settings
+FROM post
+         JOIN subspace_section section ON section.row_id = post.section_row_id
+         LEFT JOIN post conversation ON conversation.row_id = post.conversation_row_id
+WHERE post.subspace_id = $1
+  AND post.deletion_height IS NULL`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetProfilesState returns the state of all the non-deleted profiles stored inside the database
+func (db *Db) GetProfilesState() ([]types.EntityState, error) {
+	stmt := `
+SELECT address AS key, dtag, nickname, bio, profile_pic, cover_pic
+FROM profile
+WHERE dtag <> ''
+  AND deletion_height IS NULL`
+	return db.selectEntityStates(stmt)
+}
+
+// GetRegisteredReactionsState returns the state of all the registered reactions of the given subspace
+// stored inside the database
+func (db *Db) GetRegisteredReactionsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT subspace_id || '/' || id AS key, shorthand_code, display_value
+FROM subspace_registered_reaction
+WHERE subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetReactionsState returns the state of all the non-deleted reactions to the non-deleted posts of the
+// given subspace stored inside the database
+func (db *Db) GetReactionsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT post.subspace_id || '/' || post.id || '/' || reaction.id AS key,
+       reaction.author_address                                AS author,
+       CASE
+           WHEN reaction.value ->> '@type' LIKE '%RegisteredReactionValue'
+               THEN 'registered:' || (reaction.value ->> 'registered_reaction_id')
+           ELSE 'free_text:' || COALESCE(reaction.value ->> 'text', '')
+           END                                                AS value
+FROM reaction
+         JOIN post ON post.row_id = reaction.post_row_id
+WHERE post.subspace_id = $1
+  AND post.deletion_height IS NULL
+  AND reaction.deletion_height IS NULL`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetRelationshipsState returns the state of all the relationships of the given subspace stored inside the database
+func (db *Db) GetRelationshipsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT subspace_id || '/' || creator_address || '/' || counterparty_address AS key
+FROM user_relationship
+WHERE subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetUserBlocksState returns the state of all the user blocks of the given subspace stored inside the database
+func (db *Db) GetUserBlocksState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT subspace_id || '/' || blocker_address || '/' || blocked_address AS key,
+       COALESCE(reason, '')                                           AS reason
+FROM user_block
+WHERE subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetReasonsState returns the state of all the reporting reasons of the given subspace stored inside the database
+func (db *Db) GetReasonsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT subspace_id || '/' || id      AS key,
+       title,
+       COALESCE(description, '') AS description
+FROM subspace_report_reason
+WHERE subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
+
+// GetReportsState returns the state of all the reports of the given subspace stored inside the database
+func (db *Db) GetReportsState(subspaceID uint64) ([]types.EntityState, error) {
+	stmt := `
+SELECT report.subspace_id || '/' || report.id AS key,
+       COALESCE(report.message, '')          AS message,
+       report.reporter_address               AS reporter,
+       CASE
+           WHEN report.target ->> '@type' LIKE '%PostTarget' THEN 'post:' || (report.target ->> 'post_id')
+           ELSE 'user:' || COALESCE(report.target ->> 'user', '')
+           END                               AS target,
+       array_to_string(ARRAY(
+           SELECT reason.id
+           FROM report_reason
+                    JOIN subspace_report_reason reason ON reason.row_id = report_reason.reason_row_id
+           WHERE report_reason.report_row_id = report.row_id
+           ORDER BY reason.id
+       ), ',')                               AS reasons
+FROM report
+WHERE report.subspace_id = $1`
+	return db.selectEntityStates(stmt, subspaceID)
+}
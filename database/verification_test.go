@@ -0,0 +1,130 @@
+package database_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	reactionstypes "github.com/desmos-labs/desmos/v7/x/reactions/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+func (suite *DbTestSuite) TestGetSubspacesState() {
+	owner := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+	buildSubspace := func(id uint64, name string) subspacestypes.Subspace {
+		return subspacestypes.NewSubspace(
+			id,
+			name,
+			"",
+			"",
+			owner,
+			owner,
+			time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			sdk.NewCoins(sdk.NewCoin("stake", sdk.NewInt(100000))),
+		)
+	}
+
+	err := suite.database.SaveSubspace(types.NewSubspace(buildSubspace(1, "Stored name"), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSubspace(types.NewSubspace(buildSubspace(2, "Removed subspace"), 10))
+	suite.Require().NoError(err)
+
+	stored, err := suite.database.GetSubspacesState()
+	suite.Require().NoError(err)
+	suite.Require().Len(stored, 2)
+
+	chain := []types.EntityState{
+		types.NewEntityState("1", map[string]string{
+			"name":        "Chain name",
+			"description": "",
+			"treasury":    "",
+			"owner":       owner,
+			"creator":     owner,
+		}),
+		types.NewEntityState("3", nil),
+	}
+
+	report := types.NewVerificationReport("subspaces", 0, 10)
+	report.Compare("subspace", chain, stored, nil)
+	suite.Require().Equal([]types.EntityDiff{{Kind: "subspace", Key: "3"}}, report.Missing)
+	suite.Require().Equal([]types.EntityDiff{{Kind: "subspace", Key: "2"}}, report.Extra)
+	suite.Require().Equal([]types.EntityDiff{{Kind: "subspace", Key: "1", Fields: []string{"name"}}}, report.Mismatched)
+}
+
+func (suite *DbTestSuite) TestGetReactionsState() {
+	author := "cosmos1jsdja3rsp4lyfup3pc2r05uzusc2e6x3zl285s"
+
+	err := suite.database.SaveSubspace(types.NewSubspace(subspacestypes.NewSubspace(
+		1,
+		"Test subspace",
+		"",
+		"",
+		author,
+		author,
+		time.Now(),
+		nil,
+	), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveSection(types.NewSection(subspacestypes.DefaultSection(1), 1))
+	suite.Require().NoError(err)
+
+	err = suite.database.SavePost(types.NewPost(poststypes.NewPost(
+		1,
+		0,
+		1,
+		"",
+		"Hello world",
+		author,
+		0,
+		nil,
+		nil,
+		nil,
+		poststypes.REPLY_SETTING_EVERYONE,
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+		author,
+	), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveReaction(types.NewReaction(reactionstypes.NewReaction(
+		1,
+		1,
+		1,
+		reactionstypes.NewRegisteredReactionValue(2),
+		author,
+	), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.SaveReaction(types.NewReaction(reactionstypes.NewReaction(
+		1,
+		1,
+		2,
+		reactionstypes.NewFreeTextValue("🚀"),
+		author,
+	), 10))
+	suite.Require().NoError(err)
+
+	// Deleted reactions should not be returned
+	err = suite.database.SaveReaction(types.NewReaction(reactionstypes.NewReaction(
+		1,
+		1,
+		3,
+		reactionstypes.NewFreeTextValue("👍"),
+		author,
+	), 10))
+	suite.Require().NoError(err)
+
+	err = suite.database.TombstoneReaction(11, 1, 1, 3, "", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+	suite.Require().NoError(err)
+
+	stored, err := suite.database.GetReactionsState(1)
+	suite.Require().NoError(err)
+	suite.Require().ElementsMatch([]types.EntityState{
+		types.NewEntityState("1/1/1", map[string]string{"author": author, "value": "registered:2"}),
+		types.NewEntityState("1/1/2", map[string]string{"author": author, "value": "free_text:🚀"}),
+	}, stored)
+}
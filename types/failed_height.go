@@ -0,0 +1,25 @@
+package types
+
+import (
+	"time"
+)
+
+// MissingBlockModule is the module associated to the heights whose block is missing from the database
+const MissingBlockModule = "block"
+
+// FailedHeight represents a height that needs to be parsed again for the given module
+type FailedHeight struct {
+	Height   int64     `json:"height"`
+	Module   string    `json:"module"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+func NewFailedHeight(height int64, module string, err string, failedAt time.Time) FailedHeight {
+	return FailedHeight{
+		Height:   height,
+		Module:   module,
+		Error:    err,
+		FailedAt: failedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"time"
+)
+
+// NoAuthzIndex is the authz index of the messages that have not been executed through a MsgExec
+const NoAuthzIndex = -1
+
+// FailedMessage represents a message whose handling failed for the given module
+type FailedMessage struct {
+	Height     int64     `json:"height"`
+	TxHash     string    `json:"tx_hash"`
+	MsgIndex   int       `json:"msg_index"`
+	AuthzIndex int       `json:"authz_index"`
+	Module     string    `json:"module"`
+	MsgType    string    `json:"msg_type"`
+	Error      string    `json:"error"`
+	RetryCount int       `json:"retry_count"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+func NewFailedMessage(
+	height int64, txHash string, msgIndex int, authzIndex int, module string, msgType string,
+	err string, retryCount int, failedAt time.Time,
+) FailedMessage {
+	return FailedMessage{
+		Height:     height,
+		TxHash:     txHash,
+		MsgIndex:   msgIndex,
+		AuthzIndex: authzIndex,
+		Module:     module,
+		MsgType:    msgType,
+		Error:      err,
+		RetryCount: retryCount,
+		FailedAt:   failedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeedCursor identifies the position of a post inside a feed, allowing to paginate it
+type FeedCursor struct {
+	CreationDate time.Time
+	RowID        uint64
+}
+
+// NewFeedCursor returns a new FeedCursor instance
+func NewFeedCursor(creationDate time.Time, rowID uint64) *FeedCursor {
+	return &FeedCursor{
+		CreationDate: creationDate,
+		RowID:        rowID,
+	}
+}
+
+// Encode returns the opaque string representation of the cursor
+func (c *FeedCursor) Encode() string {
+	value := fmt.Sprintf("%d:%d", c.CreationDate.UnixNano(), c.RowID)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// ParseFeedCursor parses the given opaque string as a FeedCursor
+func ParseFeedCursor(value string) (*FeedCursor, error) {
+	bz, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %s", err)
+	}
+
+	parts := strings.Split(string(bz), ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid cursor: %s", value)
+	}
+
+	unixNano, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor creation date: %s", err)
+	}
+
+	rowID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor row id: %s", err)
+	}
+
+	return NewFeedCursor(time.Unix(0, unixNano).UTC(), rowID), nil
+}
+
+// FeedPostReference contains the details of a post that has been reposted or quoted by a feed post
+type FeedPostReference struct {
+	Type         string
+	SubspaceID   uint64
+	PostID       uint64
+	Author       string
+	Text         string
+	CreationDate time.Time
+}
+
+// FeedPost represents a single post that is part of a user feed
+type FeedPost struct {
+	SubspaceID     uint64
+	ID             uint64
+	Author         string
+	Text           string
+	CreationDate   time.Time
+	LastEditedDate *time.Time
+
+	// References contains the posts that are reposted or quoted by this post
+	References []FeedPostReference
+
+	// Cursor identifies the position of this post inside the feed
+	Cursor *FeedCursor
+}
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"reflect"
+	"sort"
+	"strings"
+
 	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
 )
 
@@ -65,3 +69,97 @@ func NewPostsParams(params poststypes.Params, height int64) PostsParams {
 		Height: height,
 	}
 }
+
+// PostContent contains the editable content of a stored post, along with the height at which it was stored
+type PostContent struct {
+	Text     string
+	Entities *poststypes.Entities
+	Tags     []string
+	Height   int64
+}
+
+func NewPostContent(text string, entities *poststypes.Entities, tags []string, height int64) PostContent {
+	return PostContent{
+		Text:     text,
+		Entities: entities,
+		Tags:     tags,
+		Height:   height,
+	}
+}
+
+// Equal tells whether this content is equal to the one of the given post, regardless of the entities order.
+// Texts are compared ignoring leading and trailing spaces, since they are not stored inside the database.
+func (c PostContent) Equal(post poststypes.Post) bool {
+	return strings.TrimSpace(c.Text) == strings.TrimSpace(post.Text) &&
+		reflect.DeepEqual(normalizeTags(c.Tags), normalizeTags(post.Tags)) &&
+		reflect.DeepEqual(normalizeEntities(c.Entities), normalizeEntities(post.Entities))
+}
+
+// normalizeTags returns the given tags, making sure they are not nil
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// normalizeEntities returns a copy of the given entities having non-nil slices sorted by their position
+func normalizeEntities(entities *poststypes.Entities) poststypes.Entities {
+	normalized := poststypes.Entities{
+		Hashtags: []poststypes.TextTag{},
+		Mentions: []poststypes.TextTag{},
+		Urls:     []poststypes.Url{},
+	}
+	if entities == nil {
+		return normalized
+	}
+
+	normalized.Hashtags = append(normalized.Hashtags, entities.Hashtags...)
+	normalized.Mentions = append(normalized.Mentions, entities.Mentions...)
+	normalized.Urls = append(normalized.Urls, entities.Urls...)
+
+	sortTextTags(normalized.Hashtags)
+	sortTextTags(normalized.Mentions)
+	sort.Slice(normalized.Urls, func(i, j int) bool {
+		if normalized.Urls[i].Start != normalized.Urls[j].Start {
+			return normalized.Urls[i].Start < normalized.Urls[j].Start
+		}
+		return normalized.Urls[i].Url < normalized.Urls[j].Url
+	})
+
+	return normalized
+}
+
+// sortTextTags sorts the given text tags by their position
+func sortTextTags(tags []poststypes.TextTag) {
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Start != tags[j].Start {
+			return tags[i].Start < tags[j].Start
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+}
+
+// PostRevision represents a previous content of a post, which has been replaced by the edit performed
+// at the given height with the given transaction
+type PostRevision struct {
+	SubspaceID uint64
+	PostID     uint64
+	Text       string
+	Entities   *poststypes.Entities
+	Tags       []string
+	Height     int64
+	TxHash     string
+}
+
+func NewPostRevision(subspaceID uint64, postID uint64, content PostContent, height int64, txHash string) PostRevision {
+	return PostRevision{
+		SubspaceID: subspaceID,
+		PostID:     postID,
+		Text:       content.Text,
+		Entities:   content.Entities,
+		Tags:       content.Tags,
+		Height:     height,
+		TxHash:     txHash,
+	}
+}
@@ -25,3 +25,49 @@ func NewApplicationLinkScore(address string, application string, username string
 type ProfileScoreDetails interface {
 	GetScore() uint64
 }
+
+// -------------------------------------------------------------------------------------------------------------------
+
+// ProfileScoreData contains the data used to compute the aggregated score of a profile
+type ProfileScoreData struct {
+	DesmosAddress string
+	CreationTime  time.Time
+
+	// ApplicationsScores contains the highest score of the links of the profile for each application
+	ApplicationsScores map[string]uint64
+
+	ChainLinksCount uint64
+
+	// OldestChainLinkTime is the creation time of the oldest chain link of the profile, if any
+	OldestChainLinkTime *time.Time
+
+	// ActivityCount is the number of posts and reactions created by the profile
+	ActivityCount uint64
+}
+
+func NewProfileScoreData(
+	address string, creationTime time.Time, applicationsScores map[string]uint64,
+	chainLinksCount uint64, oldestChainLinkTime *time.Time, activityCount uint64,
+) ProfileScoreData {
+	return ProfileScoreData{
+		DesmosAddress:       address,
+		CreationTime:        creationTime,
+		ApplicationsScores:  applicationsScores,
+		ChainLinksCount:     chainLinksCount,
+		OldestChainLinkTime: oldestChainLinkTime,
+		ActivityCount:       activityCount,
+	}
+}
+
+// AggregatedProfileScore contains the score of a profile along with the scores of the components it is computed from.
+// All the scores range from 0 to 100
+type AggregatedProfileScore struct {
+	DesmosAddress      string
+	Score              uint64
+	ApplicationsScore  uint64
+	ChainLinksScore    uint64
+	ChainLinksAgeScore uint64
+	ProfileAgeScore    uint64
+	ActivityScore      uint64
+	Timestamp          time.Time
+}
@@ -0,0 +1,18 @@
+package types
+
+// RefreshCheckpoint represents a unit of work that has been completed by a refresh
+type RefreshCheckpoint struct {
+	Module     string
+	SubspaceID uint64
+	PostID     uint64
+	Height     int64
+}
+
+func NewRefreshCheckpoint(module string, subspaceID uint64, postID uint64, height int64) RefreshCheckpoint {
+	return RefreshCheckpoint{
+		Module:     module,
+		SubspaceID: subspaceID,
+		PostID:     postID,
+		Height:     height,
+	}
+}
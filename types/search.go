@@ -0,0 +1,25 @@
+package types
+
+import (
+	"time"
+)
+
+// SearchPost represents a single post returned by a search
+type SearchPost struct {
+	SubspaceID     uint64
+	ID             uint64
+	Author         string
+	Text           string
+	CreationDate   time.Time
+	LastEditedDate *time.Time
+}
+
+// SearchProfile represents a single profile returned by a search
+type SearchProfile struct {
+	Address        string
+	DTag           string
+	Nickname       string
+	Bio            string
+	ProfilePicture string
+	CoverPicture   string
+}
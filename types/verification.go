@@ -0,0 +1,181 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EntityKey returns the key identifying the entity having the given identifiers
+func EntityKey(ids ...interface{}) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprint(id)
+	}
+	return strings.Join(parts, "/")
+}
+
+// SplitEntityKey returns the identifiers contained inside the given entity key
+func SplitEntityKey(key string) []string {
+	return strings.Split(key, "/")
+}
+
+// EntityState contains the fields of an entity that are compared when verifying the database against the chain state
+type EntityState struct {
+	Key    string
+	Fields map[string]string
+}
+
+func NewEntityState(key string, fields map[string]string) EntityState {
+	return EntityState{
+		Key:    key,
+		Fields: fields,
+	}
+}
+
+// JoinPermissions returns the given permissions as a sorted comma-separated list, so that they can be compared
+// regardless of the order in which they have been set
+func JoinPermissions(permissions []string) string {
+	sorted := make([]string, len(permissions))
+	copy(sorted, permissions)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// EntityFixer allows to repair the entities that differ between the database and the chain state
+type EntityFixer interface {
+	// Save stores the chain state of the entity having the given key
+	Save(key string) error
+
+	// Delete removes the stored entity having the given key
+	Delete(key string) error
+}
+
+type entityFixer struct {
+	save   func(key string) error
+	delete func(key string) error
+}
+
+// NewEntityFixer returns an EntityFixer that uses the given functions to repair the entities
+func NewEntityFixer(save func(key string) error, delete func(key string) error) EntityFixer {
+	return entityFixer{
+		save:   save,
+		delete: delete,
+	}
+}
+
+func (f entityFixer) Save(key string) error {
+	return f.save(key)
+}
+
+func (f entityFixer) Delete(key string) error {
+	return f.delete(key)
+}
+
+// EntityDiff represents an entity that differs between the database and the chain state
+type EntityDiff struct {
+	Kind   string   `json:"kind"`
+	Key    string   `json:"key"`
+	Fields []string `json:"fields,omitempty"`
+	Fixed  bool     `json:"fixed"`
+	Error  string   `json:"error,omitempty"`
+
+	fixer EntityFixer
+}
+
+// VerificationReport contains the entities of a module that have drifted from the chain state
+type VerificationReport struct {
+	Module     string       `json:"module"`
+	SubspaceID uint64       `json:"subspace_id,omitempty"`
+	Height     int64        `json:"height"`
+	Missing    []EntityDiff `json:"missing"`
+	Extra      []EntityDiff `json:"extra"`
+	Mismatched []EntityDiff `json:"mismatched"`
+}
+
+func NewVerificationReport(module string, subspaceID uint64, height int64) *VerificationReport {
+	return &VerificationReport{
+		Module:     module,
+		SubspaceID: subspaceID,
+		Height:     height,
+		Missing:    []EntityDiff{},
+		Extra:      []EntityDiff{},
+		Mismatched: []EntityDiff{},
+	}
+}
+
+// Compare compares the given chain and stored states of the entities of the given kind,
+// adding to the report the ones that are missing, extra or mismatched. The given fixer is used to repair them.
+func (r *VerificationReport) Compare(kind string, chain []EntityState, stored []EntityState, fixer EntityFixer) {
+	storedByKey := make(map[string]EntityState, len(stored))
+	for _, entity := range stored {
+		storedByKey[entity.Key] = entity
+	}
+
+	chainKeys := make(map[string]bool, len(chain))
+	for _, entity := range chain {
+		chainKeys[entity.Key] = true
+
+		storedEntity, found := storedByKey[entity.Key]
+		if !found {
+			r.Missing = append(r.Missing, EntityDiff{Kind: kind, Key: entity.Key, fixer: fixer})
+			continue
+		}
+
+		var fields []string
+		for field, value := range entity.Fields {
+			if storedEntity.Fields[field] != value {
+				fields = append(fields, field)
+			}
+		}
+
+		if len(fields) > 0 {
+			sort.Strings(fields)
+			r.Mismatched = append(r.Mismatched, EntityDiff{Kind: kind, Key: entity.Key, Fields: fields, fixer: fixer})
+		}
+	}
+
+	for _, entity := range stored {
+		if !chainKeys[entity.Key] {
+			r.Extra = append(r.Extra, EntityDiff{Kind: kind, Key: entity.Key, fixer: fixer})
+		}
+	}
+}
+
+// HasDrift tells whether the report contains any entity that differs between the database and the chain state
+func (r *VerificationReport) HasDrift() bool {
+	return len(r.Missing) > 0 || len(r.Extra) > 0 || len(r.Mismatched) > 0
+}
+
+// Fix repairs all the drifted entities, storing the missing and mismatched ones and removing the extra ones.
+// The entities that cannot be repaired are marked with the error that occurred, and an error is returned
+// after all the other entities have been repaired.
+func (r *VerificationReport) Fix() error {
+	failed := 0
+	fix := func(diff *EntityDiff, fixFn func(key string) error) {
+		err := fixFn(diff.Key)
+		if err != nil {
+			diff.Error = err.Error()
+			failed++
+			return
+		}
+		diff.Fixed = true
+	}
+
+	for _, diffs := range [][]EntityDiff{r.Missing, r.Mismatched} {
+		for i := range diffs {
+			fix(&diffs[i], diffs[i].fixer.Save)
+		}
+	}
+
+	// Remove the extra entities starting from the last compared ones, so that children are removed before parents
+	for i := len(r.Extra) - 1; i >= 0; i-- {
+		fix(&r.Extra[i], r.Extra[i].fixer.Delete)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d %s entities could not be fixed", failed, r.Module)
+	}
+
+	return nil
+}
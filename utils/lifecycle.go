@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// Lifecycle manages the long-running services that run alongside the parser (e.g. the API server).
+// All the services share the same context, which is cancelled when the lifecycle is stopped or when any of
+// the services fails. This allows them to shut down gracefully before the resources they depend on are released.
+type Lifecycle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewLifecycle returns a new Lifecycle instance whose context is derived from the given one
+func NewLifecycle(parent context.Context) *Lifecycle {
+	ctx, cancel := context.WithCancel(parent)
+	return &Lifecycle{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Context returns the context shared by all the services
+func (l *Lifecycle) Context() context.Context {
+	return l.ctx
+}
+
+// Done returns a channel that is closed when the lifecycle starts stopping
+func (l *Lifecycle) Done() <-chan struct{} {
+	return l.ctx.Done()
+}
+
+// Go runs the given service inside a new goroutine. The service must return once the given context is done.
+// If the service returns an error, the error is recorded and all the other services are stopped as well.
+func (l *Lifecycle) Go(service func(ctx context.Context) error) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+
+		err := service(l.ctx)
+		if err != nil {
+			l.mu.Lock()
+			if l.err == nil {
+				l.err = err
+			}
+			l.mu.Unlock()
+
+			l.cancel()
+		}
+	}()
+}
+
+// Err returns the first error returned by a service, if any
+func (l *Lifecycle) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Stop cancels the shared context and waits for all the services to return.
+// It can be called multiple times, and returns the first error returned by a service, if any.
+func (l *Lifecycle) Stop() error {
+	l.cancel()
+	l.wg.Wait()
+	return l.Err()
+}
@@ -2,11 +2,31 @@ package utils
 
 import (
 	"fmt"
+	"time"
 
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/forbole/juno/v5/node"
+	juno "github.com/forbole/juno/v5/types"
 )
 
+// GetBlockTime returns the timestamp of the block having the given height
+func GetBlockTime(node node.Node, height int64) (time.Time, error) {
+	block, err := node.Block(height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error while getting block: %s", err)
+	}
+	return block.Block.Time, nil
+}
+
+// GetTxTimestamp returns the timestamp of the block containing the given transaction
+func GetTxTimestamp(tx *juno.Tx) (time.Time, error) {
+	timestamp, err := time.Parse(time.RFC3339, tx.Timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error while parsing tx timestamp: %s", err)
+	}
+	return timestamp, nil
+}
+
 // QueryTxs queries all the transactions from the given node corresponding to the given query
 func QueryTxs(node node.Node, query string) ([]*coretypes.ResultTx, error) {
 	var txs []*coretypes.ResultTx
@@ -0,0 +1,286 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// JobStatus represents the status of a refresh job
+type JobStatus string
+
+const (
+	JobStatusRunning    JobStatus = "running"
+	JobStatusCancelling JobStatus = "cancelling"
+	JobStatusCompleted  JobStatus = "completed"
+	JobStatusFailed     JobStatus = "failed"
+	JobStatusCancelled  JobStatus = "cancelled"
+)
+
+// JobLog represents a single progress log line of a job
+type JobLog struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// Job contains the details of a refresh job that runs in background
+type Job struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	SubspaceID *uint64   `json:"subspace_id,omitempty"`
+	Status     JobStatus `json:"status"`
+	Error      string    `json:"error,omitempty"`
+
+	// Cancellable tells whether the job stops its work when it gets cancelled
+	Cancellable bool `json:"cancellable"`
+
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Logs      []JobLog   `json:"-"`
+}
+
+// IsDone tells whether the job has already terminated its execution
+func (j Job) IsDone() bool {
+	return j.Status != JobStatusRunning && j.Status != JobStatusCancelling
+}
+
+// JobLogger allows a job to report its progress
+type JobLogger interface {
+	Logf(format string, args ...interface{})
+}
+
+// JobRunner represents the function executed by a job.
+// Implementations should periodically check the given context and return as soon as it is cancelled.
+type JobRunner func(ctx context.Context, logger JobLogger) error
+
+// --------------------------------------------------------------------------------------------------------------------
+
+const (
+	// MaxFinishedJobs represents the maximum number of terminated jobs that are kept in memory.
+	// Once exceeded, the oldest terminated jobs are removed.
+	MaxFinishedJobs = 100
+)
+
+// jobEntry contains a job along with the function that allows to cancel it
+type jobEntry struct {
+	job    *Job
+	cancel context.CancelFunc
+}
+
+// JobsManager keeps track of all the refresh jobs that have been started
+type JobsManager struct {
+	mu     sync.RWMutex
+	wg     sync.WaitGroup
+	lastID uint64
+	jobs   map[string]*jobEntry
+}
+
+// NewJobsManager returns a new JobsManager instance
+func NewJobsManager() *JobsManager {
+	return &JobsManager{
+		jobs: map[string]*jobEntry{},
+	}
+}
+
+// StartJob starts a new job of the given type that executes the provided runner in background.
+// If cancellable is false, the runner does not check its context and the job cannot be cancelled using CancelJob.
+// It returns a copy of the started job.
+func (m *JobsManager) StartJob(jobType string, subspaceID *uint64, cancellable bool, runner JobRunner) Job {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.mu.Lock()
+	m.lastID++
+	job := &Job{
+		ID:          strconv.FormatUint(m.lastID, 10),
+		Type:        jobType,
+		SubspaceID:  subspaceID,
+		Status:      JobStatusRunning,
+		Cancellable: cancellable,
+		StartTime:   time.Now(),
+	}
+	m.jobs[job.ID] = &jobEntry{job: job, cancel: cancel}
+	snapshot := copyJob(job)
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer cancel()
+
+		err := runner(ctx, &jobLogger{manager: m, jobID: job.ID})
+		m.completeJob(ctx, job.ID, err)
+	}()
+
+	return snapshot
+}
+
+// completeJob sets the final status of the job having the given id based on the given context and error.
+// A job is considered cancelled only if it has been interrupted by the cancellation of its context,
+// so that jobs terminating successfully after being cancelled are still reported as completed.
+func (m *JobsManager) completeJob(ctx context.Context, jobID string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job := m.jobs[jobID].job
+	endTime := time.Now()
+	job.EndTime = &endTime
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		job.Status = JobStatusCancelled
+	case err != nil:
+		job.Status = JobStatusFailed
+		job.Error = err.Error()
+	default:
+		job.Status = JobStatusCompleted
+	}
+
+	log.Info().Str("module", "apis").Str("job", job.ID).Str("type", job.Type).
+		Str("status", string(job.Status)).Msg("admin job terminated")
+
+	m.pruneFinishedJobs()
+}
+
+// pruneFinishedJobs removes the oldest terminated jobs so that at most MaxFinishedJobs of them are kept.
+// NOTE: This must be called while holding the manager lock.
+func (m *JobsManager) pruneFinishedJobs() {
+	var finished []string
+	for _, id := range m.sortedIDs() {
+		if m.jobs[id].job.IsDone() {
+			finished = append(finished, id)
+		}
+	}
+
+	for len(finished) > MaxFinishedJobs {
+		delete(m.jobs, finished[0])
+		finished = finished[1:]
+	}
+}
+
+// sortedIDs returns the ids of the stored jobs, sorted in ascending order.
+// NOTE: This must be called while holding the manager lock.
+func (m *JobsManager) sortedIDs() []string {
+	ids := make([]uint64, 0, len(m.jobs))
+	for id := range m.jobs {
+		value, _ := strconv.ParseUint(id, 10, 64)
+		ids = append(ids, value)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	sortedIDs := make([]string, len(ids))
+	for i, id := range ids {
+		sortedIDs[i] = strconv.FormatUint(id, 10)
+	}
+	return sortedIDs
+}
+
+// GetJob returns a copy of the job having the given id, if any
+func (m *JobsManager) GetJob(jobID string) (Job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, found := m.jobs[jobID]
+	if !found {
+		return Job{}, false
+	}
+	return copyJob(entry.job), true
+}
+
+// GetJobs returns a copy of all the jobs that have been started, sorted by their id
+func (m *JobsManager) GetJobs() []Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, id := range m.sortedIDs() {
+		jobs = append(jobs, copyJob(m.jobs[id].job))
+	}
+	return jobs
+}
+
+// CancelJob cancels the job having the given id, marking it as cancelling until its runner returns.
+// It returns an error if the job does not exist, if it cannot be cancelled or if it has already terminated.
+func (m *JobsManager) CancelJob(jobID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, found := m.jobs[jobID]
+	if !found {
+		return fmt.Errorf("job with id %s not found", jobID)
+	}
+
+	if entry.job.IsDone() {
+		return fmt.Errorf("job with id %s has already terminated", jobID)
+	}
+
+	if !entry.job.Cancellable {
+		return fmt.Errorf("job with id %s of type %s cannot be cancelled", jobID, entry.job.Type)
+	}
+
+	entry.job.Status = JobStatusCancelling
+	entry.cancel()
+	return nil
+}
+
+// Wait blocks until all the started jobs have terminated
+func (m *JobsManager) Wait() {
+	m.wg.Wait()
+}
+
+// Stop cancels all the running jobs and waits for them to terminate.
+// The jobs that cannot be cancelled are waited until they complete their work.
+func (m *JobsManager) Stop() {
+	m.mu.RLock()
+	for _, entry := range m.jobs {
+		if !entry.job.IsDone() {
+			entry.cancel()
+		}
+	}
+	m.mu.RUnlock()
+
+	m.Wait()
+}
+
+// appendLog appends a new log line to the job having the given id
+func (m *JobsManager) appendLog(jobID string, message string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, found := m.jobs[jobID]
+	if !found {
+		return
+	}
+	entry.job.Logs = append(entry.job.Logs, JobLog{Timestamp: time.Now(), Message: message})
+}
+
+// copyJob returns a copy of the given job that can be safely read without holding the manager lock
+func copyJob(job *Job) Job {
+	jobCopy := *job
+	jobCopy.Logs = make([]JobLog, len(job.Logs))
+	copy(jobCopy.Logs, job.Logs)
+	return jobCopy
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+var (
+	_ JobLogger = &jobLogger{}
+)
+
+// jobLogger represents the JobLogger implementation that stores the logs inside the job
+type jobLogger struct {
+	manager *JobsManager
+	jobID   string
+}
+
+// Logf implements JobLogger
+func (l *jobLogger) Logf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	l.manager.appendLog(l.jobID, message)
+	log.Debug().Str("module", "apis").Str("job", l.jobID).Msg(message)
+}
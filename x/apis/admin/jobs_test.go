@@ -0,0 +1,184 @@
+package admin_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/x/apis/admin"
+)
+
+func TestJobsManager_StartJob(t *testing.T) {
+	testCases := []struct {
+		name      string
+		runner    admin.JobRunner
+		expStatus admin.JobStatus
+		expError  string
+		expLogs   []string
+	}{
+		{
+			name: "successful job is marked as completed",
+			runner: func(_ context.Context, logger admin.JobLogger) error {
+				logger.Logf("refreshing subspace %d", 1)
+				return nil
+			},
+			expStatus: admin.JobStatusCompleted,
+			expLogs:   []string{"refreshing subspace 1"},
+		},
+		{
+			name: "failing job is marked as failed",
+			runner: func(_ context.Context, logger admin.JobLogger) error {
+				logger.Logf("refreshing subspace %d", 1)
+				return fmt.Errorf("grpc error")
+			},
+			expStatus: admin.JobStatusFailed,
+			expError:  "grpc error",
+			expLogs:   []string{"refreshing subspace 1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			manager := admin.NewJobsManager()
+
+			started := manager.StartJob("posts", nil, true, tc.runner)
+			require.Equal(t, "1", started.ID)
+			require.Equal(t, "posts", started.Type)
+			require.Equal(t, admin.JobStatusRunning, started.Status)
+
+			manager.Wait()
+
+			job, found := manager.GetJob(started.ID)
+			require.True(t, found)
+			require.Equal(t, tc.expStatus, job.Status)
+			require.Equal(t, tc.expError, job.Error)
+			require.NotNil(t, job.EndTime)
+
+			logs := make([]string, len(job.Logs))
+			for i, jobLog := range job.Logs {
+				logs[i] = jobLog.Message
+			}
+			require.Equal(t, tc.expLogs, logs)
+		})
+	}
+}
+
+func TestJobsManager_CancelJob(t *testing.T) {
+	manager := admin.NewJobsManager()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	job := manager.StartJob("reactions", nil, true, func(ctx context.Context, _ admin.JobLogger) error {
+		close(started)
+		<-ctx.Done()
+		<-release
+		return ctx.Err()
+	})
+	require.True(t, job.Cancellable)
+
+	// Cancelling a non existing job returns an error
+	require.Error(t, manager.CancelJob("2"))
+
+	<-started
+	require.NoError(t, manager.CancelJob(job.ID))
+
+	// The job is marked as cancelling until its runner returns
+	stored, found := manager.GetJob(job.ID)
+	require.True(t, found)
+	require.Equal(t, admin.JobStatusCancelling, stored.Status)
+	require.False(t, stored.IsDone())
+
+	close(release)
+	manager.Wait()
+
+	stored, found = manager.GetJob(job.ID)
+	require.True(t, found)
+	require.Equal(t, admin.JobStatusCancelled, stored.Status)
+
+	// Cancelling an already terminated job returns an error
+	require.Error(t, manager.CancelJob(job.ID))
+}
+
+func TestJobsManager_CancelJob_NotCancellable(t *testing.T) {
+	manager := admin.NewJobsManager()
+
+	release := make(chan struct{})
+	job := manager.StartJob("profiles", nil, false, func(_ context.Context, _ admin.JobLogger) error {
+		<-release
+		return nil
+	})
+	require.False(t, job.Cancellable)
+
+	// Cancelling a job that cannot be cancelled returns an error and leaves it running
+	require.Error(t, manager.CancelJob(job.ID))
+
+	stored, found := manager.GetJob(job.ID)
+	require.True(t, found)
+	require.Equal(t, admin.JobStatusRunning, stored.Status)
+
+	close(release)
+	manager.Wait()
+
+	stored, found = manager.GetJob(job.ID)
+	require.True(t, found)
+	require.Equal(t, admin.JobStatusCompleted, stored.Status)
+}
+
+func TestJobsManager_GetJobs(t *testing.T) {
+	manager := admin.NewJobsManager()
+
+	subspaceID := uint64(1)
+	for i := 0; i < 3; i++ {
+		manager.StartJob("subspaces", &subspaceID, false, func(_ context.Context, _ admin.JobLogger) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+	}
+	manager.Wait()
+
+	jobs := manager.GetJobs()
+	require.Len(t, jobs, 3)
+	for i, job := range jobs {
+		require.Equal(t, fmt.Sprint(i+1), job.ID)
+		require.Equal(t, &subspaceID, job.SubspaceID)
+		require.True(t, job.IsDone())
+	}
+}
+
+func TestJobsManager_PruneFinishedJobs(t *testing.T) {
+	manager := admin.NewJobsManager()
+
+	// Keep a job running so that it is never pruned
+	running := manager.StartJob("posts", nil, true, func(ctx context.Context, _ admin.JobLogger) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	for i := 0; i < admin.MaxFinishedJobs+5; i++ {
+		job := manager.StartJob("subspaces", nil, true, func(_ context.Context, _ admin.JobLogger) error {
+			return nil
+		})
+		require.Eventually(t, func() bool {
+			stored, found := manager.GetJob(job.ID)
+			return !found || stored.IsDone()
+		}, time.Second, time.Millisecond)
+	}
+
+	jobs := manager.GetJobs()
+	require.Len(t, jobs, admin.MaxFinishedJobs+1)
+	require.Equal(t, running.ID, jobs[0].ID)
+
+	// The oldest terminated jobs should have been removed
+	_, found := manager.GetJob("2")
+	require.False(t, found)
+
+	// Stopping the manager should cancel the running job, which is then pruned being the oldest terminated one
+	manager.Stop()
+	require.Len(t, manager.GetJobs(), admin.MaxFinishedJobs)
+	_, found = manager.GetJob(running.ID)
+	require.False(t, found)
+}
@@ -0,0 +1,220 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/desmos-labs/athena/v2/x/authz"
+	"github.com/desmos-labs/athena/v2/x/contracts"
+	"github.com/desmos-labs/athena/v2/x/feegrant"
+	"github.com/desmos-labs/athena/v2/x/posts"
+	"github.com/desmos-labs/athena/v2/x/profiles"
+	profilesscore "github.com/desmos-labs/athena/v2/x/profiles-score"
+	"github.com/desmos-labs/athena/v2/x/reactions"
+	"github.com/desmos-labs/athena/v2/x/relationships"
+	"github.com/desmos-labs/athena/v2/x/reports"
+	"github.com/desmos-labs/athena/v2/x/subspaces"
+)
+
+// Modules contains the modules whose data can be refreshed using the admin endpoints
+type Modules struct {
+	Authz         *authz.Module
+	Contracts     *contracts.Module
+	Feegrant      *feegrant.Module
+	Posts         *posts.Module
+	Profiles      *profiles.Module
+	ProfilesScore *profilesscore.Module
+	Reactions     *reactions.Module
+	Relationships *relationships.Module
+	Reports       *reports.Module
+	Subspaces     *subspaces.Module
+}
+
+// refresher represents a function that refreshes some data at the given height.
+// If the refreshed data is scoped by subspace, subspaceIDs contains the ids of the subspaces to be refreshed.
+type refresher struct {
+	subspaceScoped bool
+
+	// cancellable tells whether the refresh function checks the context while refreshing the data of a single
+	// subspace. Subspace scoped refreshers that only check it between subspaces can be cancelled only when
+	// refreshing all the subspaces.
+	cancellable bool
+
+	refresh func(ctx context.Context, logger JobLogger, height int64, subspaceIDs []uint64) error
+}
+
+// isCancellable tells whether a job running this refresher can be stopped before it completes its work
+func (r refresher) isCancellable(subspaceID *uint64) bool {
+	return r.cancellable || (r.subspaceScoped && subspaceID == nil)
+}
+
+// buildRefreshers returns all the refreshers that can be run as jobs, indexed by the job type
+func buildRefreshers(m Modules) map[string]refresher {
+	return map[string]refresher{
+		"authorizations": {
+			refresh: func(_ context.Context, logger JobLogger, height int64, _ []uint64) error {
+				logger.Logf("refreshing authz authorizations at height %d", height)
+				return m.Authz.RefreshAuthorizations(height)
+			},
+		},
+		"fee-grants": {
+			refresh: func(_ context.Context, logger JobLogger, height int64, _ []uint64) error {
+				logger.Logf("refreshing fee grant allowances at height %d", height)
+				return m.Feegrant.RefreshFeeGrants(height)
+			},
+		},
+		"profiles": {
+			refresh: func(_ context.Context, logger JobLogger, height int64, _ []uint64) error {
+				logger.Logf("refreshing profiles at height %d", height)
+				return m.Profiles.RefreshProfiles(height)
+			},
+		},
+		"chain-links": {
+			refresh: func(_ context.Context, logger JobLogger, height int64, _ []uint64) error {
+				logger.Logf("refreshing chain links at height %d", height)
+				return m.Profiles.RefreshChainLinks(height)
+			},
+		},
+		"application-links": {
+			refresh: func(_ context.Context, logger JobLogger, height int64, _ []uint64) error {
+				logger.Logf("refreshing application links at height %d", height)
+				return m.Profiles.RefreshApplicationLinks(height)
+			},
+		},
+		"application-links-scores": {
+			refresh: func(_ context.Context, logger JobLogger, _ int64, _ []uint64) error {
+				logger.Logf("refreshing application links scores")
+				return m.ProfilesScore.RefreshApplicationLinksScores()
+			},
+		},
+		"profiles-scores": {
+			refresh: func(_ context.Context, logger JobLogger, _ int64, _ []uint64) error {
+				logger.Logf("refreshing profiles scores")
+				return m.ProfilesScore.RefreshProfilesScores()
+			},
+		},
+		"subspaces": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("subspace", func(height int64, subspaceID uint64) error {
+				return m.Subspaces.RefreshSubspaceData(height, subspaceID)
+			}),
+		},
+		"relationships": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("relationships", func(height int64, subspaceID uint64) error {
+				return m.Relationships.RefreshRelationshipsData(height, subspaceID)
+			}),
+		},
+		"user-blocks": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("user blocks", func(height int64, subspaceID uint64) error {
+				return m.Relationships.RefreshUserBlocksData(height, subspaceID)
+			}),
+		},
+		"posts": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("posts", func(height int64, subspaceID uint64) error {
+				return m.Posts.RefreshPostsData(height, subspaceID)
+			}),
+		},
+		"reactions": {
+			subspaceScoped: true,
+			cancellable:    true,
+			refresh:        m.refreshReactions,
+		},
+		"registered-reactions": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("registered reactions", func(height int64, subspaceID uint64) error {
+				return m.Reactions.RefreshRegisteredReactionsData(height, subspaceID)
+			}),
+		},
+		"reactions-params": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("reactions params", func(height int64, subspaceID uint64) error {
+				return m.Reactions.RefreshParamsData(height, subspaceID)
+			}),
+		},
+		"reports": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("reports", func(height int64, subspaceID uint64) error {
+				return m.Reports.RefreshReportsData(height, subspaceID)
+			}),
+		},
+		"reports-reasons": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("reports reasons", func(height int64, subspaceID uint64) error {
+				return m.Reports.RefreshReasonsData(height, subspaceID)
+			}),
+		},
+		"contracts": {
+			subspaceScoped: true,
+			refresh: forEachSubspace("contracts", func(height int64, subspaceID uint64) error {
+				return m.Contracts.RefreshData(height, subspaceID)
+			}),
+		},
+	}
+}
+
+// refreshReactions refreshes the reactions of all the posts present inside the given subspaces
+func (m Modules) refreshReactions(ctx context.Context, logger JobLogger, height int64, subspaceIDs []uint64) error {
+	for _, subspaceID := range subspaceIDs {
+		posts, err := m.Posts.QuerySubspacePosts(height, subspaceID)
+		if err != nil {
+			return err
+		}
+
+		logger.Logf("refreshing reactions of %d posts for subspace %d at height %d", len(posts), subspaceID, height)
+		for _, post := range posts {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			err = m.Reactions.RefreshReactionsData(height, post.SubspaceID, post.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// querySubspacesIDs returns the ids of all the subspaces existing at the given height, sorted in ascending order
+func (m Modules) querySubspacesIDs(height int64) ([]uint64, error) {
+	subs, err := m.Subspaces.QueryAllSubspaces(height)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying subspaces: %s", err)
+	}
+
+	subspaceIDs := make([]uint64, len(subs))
+	for i, subspace := range subs {
+		subspaceIDs[i] = subspace.ID
+	}
+
+	sort.Slice(subspaceIDs, func(i, j int) bool {
+		return subspaceIDs[i] < subspaceIDs[j]
+	})
+
+	return subspaceIDs, nil
+}
+
+// forEachSubspace returns a refresh function that calls the given function for each of the subspaces,
+// stopping as soon as the job gets cancelled
+func forEachSubspace(
+	name string, refresh func(height int64, subspaceID uint64) error,
+) func(ctx context.Context, logger JobLogger, height int64, subspaceIDs []uint64) error {
+	return func(ctx context.Context, logger JobLogger, height int64, subspaceIDs []uint64) error {
+		for i, subspaceID := range subspaceIDs {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			logger.Logf("refreshing %s for subspace %d at height %d (%d/%d)", name, subspaceID, height, i+1, len(subspaceIDs))
+			err := refresh(height, subspaceID)
+			if err != nil {
+				return fmt.Errorf("error while refreshing %s for subspace %d: %s", name, subspaceID, err)
+			}
+		}
+		return nil
+	}
+}
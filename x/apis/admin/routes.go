@@ -0,0 +1,266 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/forbole/juno/v5/node"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/desmos-labs/athena/v2/x/apis"
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
+)
+
+// JobRequest contains the data used to start a new refresh job
+type JobRequest struct {
+	Type       string  `json:"type"`
+	SubspaceID *uint64 `json:"subspace_id,omitempty"`
+}
+
+// JobsResponse contains the list of started jobs
+type JobsResponse struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// JobLogsResponse contains the progress logs of a job
+type JobLogsResponse struct {
+	Logs []JobLog `json:"logs"`
+}
+
+// NewRegistrar returns an apis.Registrar that registers the admin endpoints allowing to run the refresh
+// routines of the given modules as background jobs.
+// The endpoints are registered only if an admin token has been set inside the apis configuration.
+func NewRegistrar(modules Modules) apis.Registrar {
+	return func(ctx apis.Context, router *gin.Engine) error {
+		cfgBz, err := ctx.JunoConfig.GetBytes()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := apis.ParseConfig(cfgBz)
+		if err != nil {
+			return err
+		}
+
+		if cfg == nil || cfg.Admin == nil || cfg.Admin.Token == "" {
+			log.Debug().Str("module", "apis").Msg("admin token not set, skipping admin endpoints registration")
+			return nil
+		}
+
+		jobs := NewJobsManager()
+		registerRoutes(router, ctx.OpenAPI, cfg.Admin.Token, newHandler(ctx.Proxy, modules, jobs))
+
+		// Cancel the running jobs and wait for them when the API server stops,
+		// so that they do not use the database after it has been closed
+		if ctx.Lifecycle != nil {
+			ctx.Lifecycle.Go(func(lifecycleCtx context.Context) error {
+				<-lifecycleCtx.Done()
+				jobs.Stop()
+				return nil
+			})
+		}
+
+		return nil
+	}
+}
+
+// registerRoutes registers the admin endpoints inside the given router, protecting them with the given token
+func registerRoutes(router *gin.Engine, document *openapi.Document, token string, h *handler) {
+	document.Add(
+		openapi.Operation{
+			Method:        http.MethodGet,
+			Path:          "/admin/jobs",
+			Summary:       "Returns all the started refresh jobs",
+			Tags:          []string{"admin"},
+			Authenticated: true,
+			Response:      JobsResponse{},
+			Errors:        []int{http.StatusUnauthorized},
+		},
+		openapi.Operation{
+			Method:         http.MethodPost,
+			Path:           "/admin/jobs",
+			Summary:        "Starts a new refresh job in background",
+			Tags:           []string{"admin"},
+			Authenticated:  true,
+			Request:        JobRequest{},
+			Response:       Job{},
+			ResponseStatus: http.StatusAccepted,
+			Errors:         []int{http.StatusBadRequest, http.StatusUnauthorized},
+		},
+		openapi.Operation{
+			Method:        http.MethodGet,
+			Path:          "/admin/jobs/:id",
+			Summary:       "Returns the status of a refresh job",
+			Tags:          []string{"admin"},
+			Authenticated: true,
+			Response:      Job{},
+			Errors:        []int{http.StatusUnauthorized, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:        http.MethodGet,
+			Path:          "/admin/jobs/:id/logs",
+			Summary:       "Returns the progress logs of a refresh job",
+			Tags:          []string{"admin"},
+			Authenticated: true,
+			Response:      JobLogsResponse{},
+			Errors:        []int{http.StatusUnauthorized, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:  http.MethodPost,
+			Path:    "/admin/jobs/:id/cancel",
+			Summary: "Cancels a running refresh job",
+			Description: "Only the jobs having the cancellable field set to true can be cancelled. " +
+				"The job is marked as cancelling until it stops its work.",
+			Tags:           []string{"admin"},
+			Authenticated:  true,
+			Response:       Job{},
+			ResponseStatus: http.StatusAccepted,
+			Errors:         []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusConflict},
+		},
+	)
+
+	group := router.Group("/admin", authMiddleware(token))
+	group.GET("/jobs", h.getJobs)
+	group.POST("/jobs", h.startJob)
+	group.GET("/jobs/:id", h.getJob)
+	group.GET("/jobs/:id/logs", h.getJobLogs)
+	group.POST("/jobs/:id/cancel", h.cancelJob)
+}
+
+// authMiddleware returns a gin.HandlerFunc that aborts all the requests that do not contain the given token
+// inside the Authorization header, using the Bearer scheme
+func authMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		requestToken, found := strings.CutPrefix(authHeader, "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, openapi.ErrorResponse{Error: "invalid or missing admin token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// handler contains the handlers of the admin endpoints
+type handler struct {
+	node       node.Node
+	modules    Modules
+	refreshers map[string]refresher
+	jobs       *JobsManager
+}
+
+// newHandler returns a new handler instance
+func newHandler(node node.Node, modules Modules, jobs *JobsManager) *handler {
+	return &handler{
+		node:       node,
+		modules:    modules,
+		refreshers: buildRefreshers(modules),
+		jobs:       jobs,
+	}
+}
+
+// getJobs returns all the started jobs
+func (h *handler) getJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, JobsResponse{Jobs: h.jobs.GetJobs()})
+}
+
+// startJob starts a new refresh job based on the request body
+func (h *handler) startJob(c *gin.Context) {
+	var req JobRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: fmt.Sprintf("invalid request body: %s", err)})
+		return
+	}
+
+	refresher, found := h.refreshers[req.Type]
+	if !found {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: fmt.Sprintf("invalid job type %s, supported types: %s",
+			req.Type, strings.Join(h.supportedTypes(), ", "))})
+		return
+	}
+
+	if req.SubspaceID != nil && !refresher.subspaceScoped {
+		c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: fmt.Sprintf("job type %s does not support subspace_id", req.Type)})
+		return
+	}
+
+	cancellable := refresher.isCancellable(req.SubspaceID)
+	job := h.jobs.StartJob(req.Type, req.SubspaceID, cancellable, func(ctx context.Context, logger JobLogger) error {
+		height, err := h.node.LatestHeight()
+		if err != nil {
+			return fmt.Errorf("error while getting latest block height: %s", err)
+		}
+
+		var subspaceIDs []uint64
+		if refresher.subspaceScoped {
+			if req.SubspaceID != nil {
+				subspaceIDs = []uint64{*req.SubspaceID}
+			} else {
+				subspaceIDs, err = h.modules.querySubspacesIDs(height)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return refresher.refresh(ctx, logger, height, subspaceIDs)
+	})
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// getJob returns the status of the job having the id specified inside the path
+func (h *handler) getJob(c *gin.Context) {
+	job, found := h.jobs.GetJob(c.Param("id"))
+	if !found {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: "job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// getJobLogs returns the progress logs of the job having the id specified inside the path
+func (h *handler) getJobLogs(c *gin.Context) {
+	job, found := h.jobs.GetJob(c.Param("id"))
+	if !found {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: "job not found"})
+		return
+	}
+	c.JSON(http.StatusOK, JobLogsResponse{Logs: job.Logs})
+}
+
+// cancelJob cancels the job having the id specified inside the path
+func (h *handler) cancelJob(c *gin.Context) {
+	jobID := c.Param("id")
+	if _, found := h.jobs.GetJob(jobID); !found {
+		c.JSON(http.StatusNotFound, openapi.ErrorResponse{Error: "job not found"})
+		return
+	}
+
+	err := h.jobs.CancelJob(jobID)
+	if err != nil {
+		c.JSON(http.StatusConflict, openapi.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	job, _ := h.jobs.GetJob(jobID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// supportedTypes returns the sorted list of the supported job types
+func (h *handler) supportedTypes() []string {
+	types := make([]string, 0, len(h.refreshers))
+	for jobType := range h.refreshers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"fmt"
+)
+
+// Invalidator allows to invalidate the cached entries associated to some tags
+type Invalidator interface {
+	// Invalidate removes all the entries associated to at least one of the given tags
+	Invalidate(tags ...string)
+}
+
+// Cache represents a generic cache that can be used to store the responses of the read API endpoints.
+// Each entry is associated to a set of tags, which allow to invalidate it when the data it depends on changes.
+// Implementations must be safe for concurrent use, and are responsible for expiring the entries after their TTL.
+type Cache interface {
+	Invalidator
+
+	// Get returns the value associated to the given key, if present and not expired
+	Get(key string) ([]byte, bool)
+
+	// Set stores the given value associating it to the given key and tags
+	Set(key string, value []byte, tags ...string)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// ProfilesListTag is the tag associated to the entries that list multiple profiles (e.g. search results),
+// and that should be invalidated every time any profile changes
+const ProfilesListTag = "profiles:list"
+
+// SubspacePostsTag returns the tag associated to the entries that depend on the posts of the given subspace
+func SubspacePostsTag(subspaceID uint64) string {
+	return fmt.Sprintf("posts:%d", subspaceID)
+}
+
+// SubspaceFeedsTag returns the tag associated to the feeds of all the users inside the given subspace
+func SubspaceFeedsTag(subspaceID uint64) string {
+	return fmt.Sprintf("feeds:%d", subspaceID)
+}
+
+// FeedTag returns the tag associated to the feed of the given user inside the given subspace, which depends on
+// the relationships created by the user, the blocks involving the user and the reports created by the user
+func FeedTag(subspaceID uint64, address string) string {
+	return fmt.Sprintf("feed:%d:%s", subspaceID, address)
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+var (
+	_ Cache = &LRU{}
+)
+
+// lruEntry represents a single entry stored inside the LRU cache
+type lruEntry struct {
+	key        string
+	value      []byte
+	tags       []string
+	expiration time.Time
+}
+
+// LRU represents an in-memory Cache that evicts the least recently used entries once its maximum size is
+// reached, and expires each entry once its TTL has elapsed
+type LRU struct {
+	mu sync.Mutex
+
+	size int
+	ttl  time.Duration
+
+	entries *list.List
+	items   map[string]*list.Element
+	tags    map[string]map[string]struct{}
+}
+
+// NewLRU returns a new LRU cache storing at most size entries, each one for the given ttl
+func NewLRU(size int, ttl time.Duration) *LRU {
+	return &LRU{
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		items:   map[string]*list.Element{},
+		tags:    map[string]map[string]struct{}{},
+	}
+}
+
+// Get implements Cache
+func (c *LRU) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, found := c.items[key]
+	if !found {
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiration) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.entries.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set implements Cache
+func (c *LRU) Set(key string, value []byte, tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, found := c.items[key]; found {
+		c.remove(element)
+	}
+
+	element := c.entries.PushFront(&lruEntry{
+		key:        key,
+		value:      value,
+		tags:       tags,
+		expiration: time.Now().Add(c.ttl),
+	})
+	c.items[key] = element
+
+	for _, tag := range tags {
+		keys, found := c.tags[tag]
+		if !found {
+			keys = map[string]struct{}{}
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+
+	// Evict the least recently used entries
+	for c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+	}
+}
+
+// Invalidate implements Cache
+func (c *LRU) Invalidate(tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if element, found := c.items[key]; found {
+				c.remove(element)
+			}
+		}
+	}
+}
+
+// Len returns the number of entries currently stored inside the cache
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// remove removes the given element from the cache, along with its tags references.
+// NOTE: The caller must hold the lock.
+func (c *LRU) remove(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	c.entries.Remove(element)
+	delete(c.items, entry.key)
+
+	for _, tag := range entry.tags {
+		keys := c.tags[tag]
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
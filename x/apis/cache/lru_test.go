@@ -0,0 +1,75 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/x/apis/cache"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	lru := cache.NewLRU(2, time.Minute)
+
+	lru.Set("a", []byte("1"))
+	lru.Set("b", []byte("2"))
+
+	value, found := lru.Get("a")
+	require.True(t, found)
+	require.Equal(t, []byte("1"), value)
+
+	// Adding a new entry evicts the least recently used one
+	lru.Set("c", []byte("3"))
+	require.Equal(t, 2, lru.Len())
+
+	_, found = lru.Get("b")
+	require.False(t, found)
+
+	_, found = lru.Get("a")
+	require.True(t, found)
+
+	// Replacing an entry does not increase the size
+	lru.Set("c", []byte("4"))
+	require.Equal(t, 2, lru.Len())
+
+	value, found = lru.Get("c")
+	require.True(t, found)
+	require.Equal(t, []byte("4"), value)
+}
+
+func TestLRU_Expiration(t *testing.T) {
+	lru := cache.NewLRU(10, 10*time.Millisecond)
+
+	lru.Set("a", []byte("1"), "tag")
+	_, found := lru.Get("a")
+	require.True(t, found)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, found = lru.Get("a")
+	require.False(t, found)
+	require.Zero(t, lru.Len())
+}
+
+func TestLRU_Invalidate(t *testing.T) {
+	lru := cache.NewLRU(10, time.Minute)
+
+	lru.Set("search", []byte("1"), cache.SubspacePostsTag(1))
+	lru.Set("feed-1", []byte("2"), cache.SubspacePostsTag(1), cache.FeedTag(1, "cosmos1"))
+	lru.Set("feed-2", []byte("3"), cache.SubspacePostsTag(1), cache.FeedTag(1, "cosmos2"))
+	lru.Set("profiles", []byte("4"), cache.ProfilesListTag)
+
+	// Invalidating a single feed only removes the entries depending on it
+	lru.Invalidate(cache.FeedTag(1, "cosmos1"))
+	_, found := lru.Get("feed-1")
+	require.False(t, found)
+	require.Equal(t, 3, lru.Len())
+
+	// Invalidating the subspace posts removes all the entries depending on them
+	lru.Invalidate(cache.SubspacePostsTag(1))
+	require.Equal(t, 1, lru.Len())
+
+	lru.Invalidate(cache.ProfilesListTag)
+	require.Zero(t, lru.Len())
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// HeaderName is the name of the header telling whether a response has been served from the cache
+	HeaderName = "X-Cache"
+
+	headerHit  = "HIT"
+	headerMiss = "MISS"
+)
+
+// TagsFunc returns the tags that should be associated to the cached response of the given request.
+// Returning no tags means that the response will be expired only after the cache TTL.
+type TagsFunc func(c *gin.Context) []string
+
+// cachedResponse represents a response stored inside the cache
+type cachedResponse struct {
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// responseRecorder wraps a gin.ResponseWriter recording the written body
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write implements gin.ResponseWriter
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements gin.ResponseWriter
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Middleware returns a gin.HandlerFunc that serves the successful responses of GET requests from the given cache,
+// storing them associated to the tags returned by the given function.
+// If the given cache is nil, all the requests are passed through to the next handlers.
+func Middleware(cache Cache, tags TagsFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cache == nil || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		key := c.Request.URL.RequestURI()
+		if value, found := cache.Get(key); found {
+			var response cachedResponse
+			err := json.Unmarshal(value, &response)
+			if err == nil {
+				c.Header(HeaderName, headerHit)
+				c.Data(http.StatusOK, response.ContentType, response.Body)
+				c.Abort()
+				return
+			}
+			log.Error().Str("module", "apis").Err(err).Str("key", key).Msg("error while reading cached response")
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Header(HeaderName, headerMiss)
+		c.Next()
+
+		if c.Writer.Status() != http.StatusOK {
+			return
+		}
+
+		var entryTags []string
+		if tags != nil {
+			entryTags = tags(c)
+		}
+
+		value, err := json.Marshal(cachedResponse{
+			ContentType: c.Writer.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			log.Error().Str("module", "apis").Err(err).Str("key", key).Msg("error while caching response")
+			return
+		}
+
+		cache.Set(key, value, entryTags...)
+	}
+}
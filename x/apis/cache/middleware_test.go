@@ -0,0 +1,63 @@
+package cache_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/x/apis/cache"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lru := cache.NewLRU(10, time.Minute)
+	router := gin.New()
+
+	calls := 0
+	router.GET("/posts/:id", cache.Middleware(lru, func(c *gin.Context) []string {
+		return []string{cache.SubspacePostsTag(1)}
+	}), func(c *gin.Context) {
+		calls++
+		if c.Param("id") != "1" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	// The first request is served by the handler
+	res := get("/posts/1")
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, "MISS", res.Header().Get(cache.HeaderName))
+	require.JSONEq(t, `{"calls":1}`, res.Body.String())
+
+	// The second request is served from the cache
+	res = get("/posts/1")
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, "HIT", res.Header().Get(cache.HeaderName))
+	require.Equal(t, "application/json; charset=utf-8", res.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"calls":1}`, res.Body.String())
+
+	// Invalidating the posts makes the handler to be called again
+	lru.Invalidate(cache.SubspacePostsTag(1))
+	res = get("/posts/1")
+	require.Equal(t, "MISS", res.Header().Get(cache.HeaderName))
+	require.JSONEq(t, `{"calls":2}`, res.Body.String())
+
+	// Error responses are not cached
+	get("/posts/2")
+	res = get("/posts/2")
+	require.Equal(t, http.StatusNotFound, res.Code)
+	require.Equal(t, 4, calls)
+}
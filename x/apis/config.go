@@ -1,12 +1,48 @@
 package apis
 
 import (
+	"time"
+
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Address string `yaml:"address,omitempty"`
-	Port    uint   `yaml:"port"`
+	Address string       `yaml:"address,omitempty"`
+	Port    uint         `yaml:"port"`
+	Admin   *AdminConfig `yaml:"admin,omitempty"`
+	Cache   *CacheConfig `yaml:"cache,omitempty"`
+
+	// SwaggerUI tells whether the Swagger UI page, which loads its assets from unpkg.com, should be served
+	SwaggerUI bool `yaml:"swagger_ui,omitempty"`
+}
+
+// AdminConfig contains the configuration of the admin endpoints.
+// If no token is set, the admin endpoints will not be registered.
+type AdminConfig struct {
+	Token string `yaml:"token"`
+}
+
+// CacheConfig contains the configuration of the in-memory cache used to store the responses of the read endpoints.
+// If not set, the responses will not be cached.
+type CacheConfig struct {
+	Size int           `yaml:"size"`
+	TTL  time.Duration `yaml:"ttl"`
+}
+
+// GetSize returns the maximum number of cached responses
+func (c *CacheConfig) GetSize() int {
+	if c.Size <= 0 {
+		return 1000
+	}
+	return c.Size
+}
+
+// GetTTL returns the amount of time after which the cached responses expire
+func (c *CacheConfig) GetTTL() time.Duration {
+	if c.TTL <= 0 {
+		return 30 * time.Second
+	}
+	return c.TTL
 }
 
 func ParseConfig(bz []byte) (*Config, error) {
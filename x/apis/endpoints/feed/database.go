@@ -0,0 +1,9 @@
+package feed
+
+import (
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+type Database interface {
+	GetHomeFeed(subspaceID uint64, userAddress string, cursor *types.FeedCursor, limit uint64) ([]types.FeedPost, error)
+}
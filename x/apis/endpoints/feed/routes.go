@@ -0,0 +1,153 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/x/apis/cache"
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
+)
+
+const (
+	// DefaultLimit is the number of posts returned when no limit is specified
+	DefaultLimit = 20
+
+	// MaxLimit is the maximum number of posts that can be returned by a single request
+	MaxLimit = 100
+)
+
+// PostReference contains the details of a post that has been reposted or quoted by a feed post
+type PostReference struct {
+	Type         string    `json:"type"`
+	SubspaceID   uint64    `json:"subspace_id"`
+	PostID       uint64    `json:"post_id"`
+	Author       string    `json:"author"`
+	Text         string    `json:"text"`
+	CreationDate time.Time `json:"creation_date"`
+}
+
+// Post represents a single post inside the feed
+type Post struct {
+	SubspaceID     uint64          `json:"subspace_id"`
+	ID             uint64          `json:"id"`
+	Author         string          `json:"author"`
+	Text           string          `json:"text"`
+	CreationDate   time.Time       `json:"creation_date"`
+	LastEditedDate *time.Time      `json:"last_edited_date,omitempty"`
+	References     []PostReference `json:"references"`
+}
+
+// Response contains a single page of the feed of a user
+type Response struct {
+	Posts []Post `json:"posts"`
+
+	// NextCursor is the cursor to be used to get the next page, if any
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// RegisterRoutes registers the feed endpoints inside the given router.
+// If the given cache is not nil, the responses are cached until the posts of the subspace change.
+func RegisterRoutes(router *gin.Engine, document *openapi.Document, responsesCache cache.Cache, db Database) {
+	document.Add(openapi.Operation{
+		Method:      http.MethodGet,
+		Path:        "/feed/:subspace_id/:address",
+		Summary:     "Returns the home feed of a user within a subspace",
+		Description: "Returns the posts, reposts and quotes created by the users followed by the given address, excluding blocked users and reported content. Results are sorted from the most recent one.",
+		Tags:        []string{"feed"},
+		Parameters: []openapi.Parameter{
+			{Name: "cursor", Description: "Cursor returned by the previous page"},
+			{Name: "limit", Description: fmt.Sprintf("Number of posts to return (default %d, max %d)", DefaultLimit, MaxLimit), Value: uint64(0)},
+		},
+		Response: Response{},
+		Errors:   []int{http.StatusBadRequest, http.StatusInternalServerError},
+	})
+
+	router.GET("/feed/:subspace_id/:address", cache.Middleware(responsesCache, cacheTags), func(c *gin.Context) {
+		subspaceID, err := strconv.ParseUint(c.Param("subspace_id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: fmt.Sprintf("invalid subspace id: %s", err)})
+			return
+		}
+
+		var cursor *types.FeedCursor
+		if cursorValue := c.Query("cursor"); cursorValue != "" {
+			cursor, err = types.ParseFeedCursor(cursorValue)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+				return
+			}
+		}
+
+		limit := uint64(DefaultLimit)
+		if limitValue := c.Query("limit"); limitValue != "" {
+			limit, err = strconv.ParseUint(limitValue, 10, 64)
+			if err != nil || limit == 0 || limit > MaxLimit {
+				c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: fmt.Sprintf("invalid limit, must be between 1 and %d", MaxLimit)})
+				return
+			}
+		}
+
+		posts, err := db.GetHomeFeed(subspaceID, c.Param("address"), cursor, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, openapi.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, buildResponse(posts, limit))
+	})
+}
+
+// cacheTags returns the cache tags of the feed response, which depends on all the posts of the subspace
+// as well as on the relationships, blocks and reports of the user
+func cacheTags(c *gin.Context) []string {
+	subspaceID, err := strconv.ParseUint(c.Param("subspace_id"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return []string{
+		cache.SubspacePostsTag(subspaceID),
+		cache.SubspaceFeedsTag(subspaceID),
+		cache.FeedTag(subspaceID, c.Param("address")),
+	}
+}
+
+// buildResponse builds the Response containing the given posts
+func buildResponse(posts []types.FeedPost, limit uint64) Response {
+	response := Response{Posts: make([]Post, len(posts))}
+	for i, post := range posts {
+		references := make([]PostReference, len(post.References))
+		for j, reference := range post.References {
+			references[j] = PostReference{
+				Type:         reference.Type,
+				SubspaceID:   reference.SubspaceID,
+				PostID:       reference.PostID,
+				Author:       reference.Author,
+				Text:         reference.Text,
+				CreationDate: reference.CreationDate,
+			}
+		}
+
+		response.Posts[i] = Post{
+			SubspaceID:     post.SubspaceID,
+			ID:             post.ID,
+			Author:         post.Author,
+			Text:           post.Text,
+			CreationDate:   post.CreationDate,
+			LastEditedDate: post.LastEditedDate,
+			References:     references,
+		}
+	}
+
+	// A full page means that there might be more posts to be returned
+	if len(posts) > 0 && uint64(len(posts)) == limit {
+		nextCursor := posts[len(posts)-1].Cursor.Encode()
+		response.NextCursor = &nextCursor
+	}
+
+	return response
+}
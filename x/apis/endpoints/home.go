@@ -6,9 +6,18 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
 )
 
-func RegisterRoutesList(r *gin.Engine) {
+func RegisterRoutesList(r *gin.Engine, document *openapi.Document) {
+	document.Add(openapi.Operation{
+		Method:   http.MethodGet,
+		Path:     "/",
+		Summary:  "Returns the list of all the available endpoints",
+		Response: "",
+	})
+
 	r.GET("/", func(c *gin.Context) {
 		routes := make([]string, len(r.Routes()))
 		for i, route := range r.Routes() {
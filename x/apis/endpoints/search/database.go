@@ -0,0 +1,10 @@
+package search
+
+import (
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+type Database interface {
+	SearchPosts(subspaceID uint64, query string, offset uint64, limit uint64) ([]types.SearchPost, error)
+	SearchProfiles(subspaceID uint64, query string, offset uint64, limit uint64) ([]types.SearchProfile, error)
+}
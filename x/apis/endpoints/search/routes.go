@@ -0,0 +1,217 @@
+package search
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/x/apis/cache"
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
+)
+
+const (
+	// DefaultLimit is the number of results returned when no limit is specified
+	DefaultLimit = 20
+
+	// MaxLimit is the maximum number of results that can be returned by a single request
+	MaxLimit = 100
+)
+
+// Post represents a single post matching the search query
+type Post struct {
+	SubspaceID     uint64     `json:"subspace_id"`
+	ID             uint64     `json:"id"`
+	Author         string     `json:"author"`
+	Text           string     `json:"text"`
+	CreationDate   time.Time  `json:"creation_date"`
+	LastEditedDate *time.Time `json:"last_edited_date,omitempty"`
+}
+
+// PostsResponse contains a single page of the posts matching the search query
+type PostsResponse struct {
+	Posts []Post `json:"posts"`
+
+	// NextOffset is the offset to be used to get the next page, if any
+	NextOffset *uint64 `json:"next_offset,omitempty"`
+}
+
+// Profile represents a single profile matching the search query
+type Profile struct {
+	Address        string `json:"address"`
+	DTag           string `json:"dtag"`
+	Nickname       string `json:"nickname"`
+	Bio            string `json:"bio"`
+	ProfilePicture string `json:"profile_picture"`
+	CoverPicture   string `json:"cover_picture"`
+}
+
+// ProfilesResponse contains a single page of the profiles matching the search query
+type ProfilesResponse struct {
+	Profiles []Profile `json:"profiles"`
+
+	// NextOffset is the offset to be used to get the next page, if any
+	NextOffset *uint64 `json:"next_offset,omitempty"`
+}
+
+// request contains the parameters of a search request
+type request struct {
+	subspaceID uint64
+	query      string
+	offset     uint64
+	limit      uint64
+}
+
+// RegisterRoutes registers the search endpoints inside the given router.
+// If the given cache is not nil, the responses are cached until the searched data changes.
+func RegisterRoutes(router *gin.Engine, document *openapi.Document, responsesCache cache.Cache, db Database) {
+	parameters := []openapi.Parameter{
+		{Name: "q", Description: "Search query, supporting quoted phrases, OR and - operators", Required: true},
+		{Name: "offset", Description: "Number of results to skip", Value: uint64(0)},
+		{Name: "limit", Description: fmt.Sprintf("Number of results to return (default %d, max %d)", DefaultLimit, MaxLimit), Value: uint64(0)},
+	}
+
+	document.Add(
+		openapi.Operation{
+			Method:      http.MethodGet,
+			Path:        "/search/:subspace_id/posts",
+			Summary:     "Searches the posts of a subspace",
+			Description: "Returns the posts of the subspace whose text or hashtags match the given query. Results are sorted by relevance and recency.",
+			Tags:        []string{"search"},
+			Parameters:  parameters,
+			Response:    PostsResponse{},
+			Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		openapi.Operation{
+			Method:      http.MethodGet,
+			Path:        "/search/:subspace_id/profiles",
+			Summary:     "Searches the profiles that have posted inside a subspace",
+			Description: "Returns the profiles whose DTag, nickname or bio match the given query, as well as the ones having a DTag starting with it. Results are sorted by relevance and recency.",
+			Tags:        []string{"search"},
+			Parameters:  parameters,
+			Response:    ProfilesResponse{},
+			Errors:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+	)
+
+	router.GET("/search/:subspace_id/posts", cache.Middleware(responsesCache, postsCacheTags), func(c *gin.Context) {
+		req, err := parseRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		posts, err := db.SearchPosts(req.subspaceID, req.query, req.offset, req.limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, openapi.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, buildPostsResponse(posts, req))
+	})
+
+	router.GET("/search/:subspace_id/profiles", cache.Middleware(responsesCache, profilesCacheTags), func(c *gin.Context) {
+		req, err := parseRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, openapi.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		profiles, err := db.SearchProfiles(req.subspaceID, req.query, req.offset, req.limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, openapi.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, buildProfilesResponse(profiles, req))
+	})
+}
+
+// parseRequest parses the search request parameters from the given context
+func parseRequest(c *gin.Context) (request, error) {
+	subspaceID, err := strconv.ParseUint(c.Param("subspace_id"), 10, 64)
+	if err != nil {
+		return request{}, fmt.Errorf("invalid subspace id: %s", err)
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return request{}, fmt.Errorf("missing search query")
+	}
+
+	var offset uint64
+	if offsetValue := c.Query("offset"); offsetValue != "" {
+		offset, err = strconv.ParseUint(offsetValue, 10, 64)
+		if err != nil {
+			return request{}, fmt.Errorf("invalid offset: %s", err)
+		}
+	}
+
+	limit := uint64(DefaultLimit)
+	if limitValue := c.Query("limit"); limitValue != "" {
+		limit, err = strconv.ParseUint(limitValue, 10, 64)
+		if err != nil || limit == 0 || limit > MaxLimit {
+			return request{}, fmt.Errorf("invalid limit, must be between 1 and %d", MaxLimit)
+		}
+	}
+
+	return request{subspaceID: subspaceID, query: query, offset: offset, limit: limit}, nil
+}
+
+// postsCacheTags returns the cache tags of the posts search response, which depends on all the posts of the subspace
+func postsCacheTags(c *gin.Context) []string {
+	subspaceID, err := strconv.ParseUint(c.Param("subspace_id"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return []string{cache.SubspacePostsTag(subspaceID)}
+}
+
+// profilesCacheTags returns the cache tags of the profiles search response, which depends on all the profiles
+// as well as on the posts of the subspace, since only the profiles that have posted inside it are returned
+func profilesCacheTags(c *gin.Context) []string {
+	subspaceID, err := strconv.ParseUint(c.Param("subspace_id"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return []string{cache.ProfilesListTag, cache.SubspacePostsTag(subspaceID)}
+}
+
+// nextOffset returns the offset of the page following the given one, or nil if there are no more results
+func nextOffset(req request, count int) *uint64 {
+	// A full page means that there might be more results to be returned
+	if count == 0 || uint64(count) < req.limit {
+		return nil
+	}
+	next := req.offset + uint64(count)
+	return &next
+}
+
+// buildPostsResponse builds the PostsResponse containing the given posts
+func buildPostsResponse(posts []types.SearchPost, req request) PostsResponse {
+	response := PostsResponse{Posts: make([]Post, len(posts)), NextOffset: nextOffset(req, len(posts))}
+	for i, post := range posts {
+		response.Posts[i] = Post{
+			SubspaceID:     post.SubspaceID,
+			ID:             post.ID,
+			Author:         post.Author,
+			Text:           post.Text,
+			CreationDate:   post.CreationDate,
+			LastEditedDate: post.LastEditedDate,
+		}
+	}
+	return response
+}
+
+// buildProfilesResponse builds the ProfilesResponse containing the given profiles
+func buildProfilesResponse(profiles []types.SearchProfile, req request) ProfilesResponse {
+	response := ProfilesResponse{Profiles: make([]Profile, len(profiles)), NextOffset: nextOffset(req, len(profiles))}
+	for i, profile := range profiles {
+		response.Profiles[i] = Profile(profile)
+	}
+	return response
+}
@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
+)
+
+const (
+	// shutdownTimeout is the maximum amount of time given to the in-flight requests to complete during shutdown
+	shutdownTimeout = 5 * time.Second
 )
 
 // RunAdditionalOperations implements modules.AdditionalOperationsModule
@@ -22,13 +27,20 @@ func (m *Module) RunAdditionalOperations() error {
 	router.Use(m.Logger(), gin.Recovery(), cors.Default())
 
 	// Register the endpoints
+	m.ctx.Lifecycle = m.lifecycle
 	if m.registrar != nil {
 		err := m.registrar(m.ctx, router)
 		if err != nil {
-			panic(err)
+			return fmt.Errorf("error while registering API endpoints: %s", err)
 		}
 	}
 
+	// Register the documentation endpoints
+	openapi.RegisterRoutes(router, m.ctx.OpenAPI, m.cfg.SwaggerUI)
+	for _, route := range m.ctx.OpenAPI.Undocumented(router.Routes()) {
+		log.Warn().Str("module", "apis").Str("route", route).Msg("route has no OpenAPI schema")
+	}
+
 	// Build the HTTP server to be able to shut it down if needed
 	httpServer := &http.Server{
 		Addr:              fmt.Sprintf("%s:%d", m.cfg.Address, m.cfg.Port),
@@ -43,14 +55,18 @@ func (m *Module) RunAdditionalOperations() error {
 		m.configurator(router, httpServer)
 	}
 
-	// Listen for and trap any OS signal to gracefully shutdown and exit
-	go m.trapSignal(httpServer)
+	// Listen synchronously so that errors such as an already used port are returned to the caller
+	listener, err := net.Listen("tcp", httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("error while starting API server: %s", err)
+	}
 
-	// Start the HTTP server
-	go m.startServer(httpServer)
+	// Start the HTTP server, tying it to the lifecycle so that it is shut down gracefully when stopping
+	m.lifecycle.Go(func(ctx context.Context) error {
+		return m.serve(ctx, httpServer, listener)
+	})
 
-	// Block main process (signal capture will call WaitGroup's Done)
-	log.Info().Str("module", "apis").Str("address", httpServer.Addr).Msg("started API server")
+	log.Info().Str("module", "apis").Str("address", listener.Addr().String()).Msg("started API server")
 	return nil
 }
 
@@ -62,35 +78,35 @@ func (m *Module) Logger() gin.HandlerFunc {
 	}
 }
 
-// trapSignal traps the stops signals to gracefully shut down the server
-func (m *Module) trapSignal(httpServer *http.Server) {
-	// Wait for interrupt signal to gracefully shutdown the server with
-	// a timeout of 5 seconds.
-	quit := make(chan os.Signal, 1)
-
-	// Kill (no param) default send syscall.SIGTERM
-	// Kill -2 is syscall.SIGINT
-	// Kill -9 is syscall.SIGKILL but can't be caught, so don't need to add it
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Debug().Str("module", "apis").Msg("shutting down API server")
-
-	// The context is used to inform the server it has 5 seconds to finish
-	// the request it is currently handling
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := httpServer.Shutdown(ctx); err != nil {
-		log.Error().Err(err).Str("module", "apis").Msg("API server forces to shutdown")
-	}
+// serve serves the API requests using the given listener until the given context is done.
+// Once the context is done, the server stops accepting new connections and waits for the in-flight requests to
+// complete before returning.
+func (m *Module) serve(ctx context.Context, httpServer *http.Server, listener net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("error while serving API requests: %s", err)
+		}
+		return nil
 
-	log.Debug().Str("module", "apis").Msg("API server shutdown")
-}
+	case <-ctx.Done():
+		log.Debug().Str("module", "apis").Msg("shutting down API server")
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		err := httpServer.Shutdown(shutdownCtx)
+		<-serveErr
+		if err != nil {
+			return fmt.Errorf("error while shutting down API server: %s", err)
+		}
 
-// startServer starts the API server
-func (m *Module) startServer(httpServer *http.Server) {
-	err := httpServer.ListenAndServe()
-	if err != nil && !errors.Is(err, http.ErrServerClosed) {
-		panic(err)
+		log.Debug().Str("module", "apis").Msg("API server shutdown")
+		return nil
 	}
 }
@@ -0,0 +1,146 @@
+package apis_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/forbole/juno/v5/modules/registrar"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/utils"
+	"github.com/desmos-labs/athena/v2/x/apis"
+)
+
+// freePort returns a TCP port that is currently not in use
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// fakeDatabase mimics the database.Db close hooks, calling them before being closed
+type fakeDatabase struct {
+	hooks   []func()
+	onClose func()
+}
+
+func (db *fakeDatabase) RegisterCloseHook(hook func()) {
+	db.hooks = append(db.hooks, hook)
+}
+
+func (db *fakeDatabase) Close() {
+	for _, hook := range db.hooks {
+		hook()
+	}
+	db.onClose()
+}
+
+// buildModule returns a new apis.Module listening on the given port and using the given registrar and lifecycle
+func buildModule(t *testing.T, port int, apisRegistrar apis.Registrar, lifecycle *utils.Lifecycle) *apis.Module {
+	junoCfg, err := config.DefaultConfigParser([]byte(fmt.Sprintf(`
+apis:
+  address: 127.0.0.1
+  port: %d
+`, port)))
+	require.NoError(t, err)
+
+	ctx := apis.NewContext(registrar.Context{JunoConfig: junoCfg}, nil)
+	return apis.NewModule(ctx).WithRegistrar(apisRegistrar).WithLifecycle(lifecycle)
+}
+
+// TestModule_StopDrainsInFlightRequests makes sure that closing the database stops the API server first,
+// and that the server waits for the in-flight requests to complete before stopping
+func TestModule_StopDrainsInFlightRequests(t *testing.T) {
+	port := freePort(t)
+	lifecycle := utils.NewLifecycle(context.Background())
+
+	var mu sync.Mutex
+	var events []string
+	addEvent := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	module := buildModule(t, port, func(_ apis.Context, router *gin.Engine) error {
+		router.GET("/slow", func(c *gin.Context) {
+			close(started)
+			<-release
+			addEvent("request completed")
+			c.String(http.StatusOK, "done")
+		})
+		return nil
+	}, lifecycle)
+	require.NoError(t, module.RunAdditionalOperations())
+
+	// Start an in-flight request
+	responseCh := make(chan string, 1)
+	go func() {
+		res, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/slow", port))
+		if err != nil {
+			responseCh <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		responseCh <- string(body)
+	}()
+	<-started
+
+	// Close the database, which should stop the server first
+	db := &fakeDatabase{onClose: func() { addEvent("database closed") }}
+	db.RegisterCloseHook(func() {
+		require.NoError(t, lifecycle.Stop())
+		addEvent("server stopped")
+	})
+
+	stopped := make(chan struct{})
+	go func() {
+		db.Close()
+		close(stopped)
+	}()
+
+	// Make sure the server is waiting for the request to complete
+	select {
+	case <-stopped:
+		t.Fatal("server stopped before the in-flight request completed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+
+	require.Equal(t, "done", <-responseCh)
+	require.Equal(t, []string{"request completed", "server stopped", "database closed"}, events)
+
+	// Make sure the server no longer accepts connections
+	_, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/slow", port))
+	require.Error(t, err)
+}
+
+func TestModule_ListenErrorIsReturned(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lifecycle := utils.NewLifecycle(context.Background())
+	module := buildModule(t, listener.Addr().(*net.TCPAddr).Port, nil, lifecycle)
+
+	err = module.RunAdditionalOperations()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while starting API server")
+
+	// Make sure no service has been started
+	require.NoError(t, lifecycle.Stop())
+}
@@ -1,7 +1,11 @@
 package apis
 
 import (
+	"context"
+
 	"github.com/forbole/juno/v5/modules"
+
+	"github.com/desmos-labs/athena/v2/utils"
 )
 
 var (
@@ -15,6 +19,7 @@ type Module struct {
 	cfg          *Config
 	registrar    Registrar
 	configurator Configurator
+	lifecycle    *utils.Lifecycle
 }
 
 func NewModule(ctx Context) *Module {
@@ -28,8 +33,9 @@ func NewModule(ctx Context) *Module {
 	}
 
 	return &Module{
-		ctx: ctx,
-		cfg: cfg,
+		ctx:       ctx,
+		cfg:       cfg,
+		lifecycle: utils.NewLifecycle(context.Background()),
 	}
 }
 
@@ -45,6 +51,15 @@ func (m *Module) WithConfigurator(configurator Configurator) *Module {
 	return m
 }
 
+// WithLifecycle allows setting the lifecycle the API server should be tied to.
+// The server is shut down gracefully when the lifecycle is stopped.
+func (m *Module) WithLifecycle(lifecycle *utils.Lifecycle) *Module {
+	if lifecycle != nil {
+		m.lifecycle = lifecycle
+	}
+	return m
+}
+
 func (m *Module) Name() string {
 	return "apis"
 }
@@ -0,0 +1,239 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// BearerAuth is the name of the security scheme used by the operations that require a bearer token
+	BearerAuth = "bearerAuth"
+
+	contentTypeJSON   = "application/json"
+	contentTypeText   = "text/plain"
+	contentTypeHTML   = "text/html"
+	contentTypeBinary = "application/octet-stream"
+)
+
+// Parameter describes a query parameter accepted by an operation.
+// Path parameters are extracted automatically from the operation path.
+type Parameter struct {
+	Name        string
+	Description string
+	Required    bool
+
+	// Value is an instance of the parameter type, used to generate its schema
+	Value interface{}
+}
+
+// Operation describes a single API endpoint, along with its request and response schemas
+type Operation struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tags        []string
+	Parameters  []Parameter
+
+	// Authenticated tells whether the operation requires a bearer token
+	Authenticated bool
+
+	// Request is an instance of the JSON request body type, if any
+	Request interface{}
+
+	// Response is an instance of the successful response type. Strings are served as plain text.
+	Response interface{}
+
+	// ResponseStatus is the status code of the successful response. Defaults to 200.
+	ResponseStatus int
+
+	// ResponseContentType overrides the content type of the successful response
+	ResponseContentType string
+
+	// Errors contains the status codes of the error responses that can be returned by the operation
+	Errors []int
+}
+
+// ErrorResponse represents the body of an error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// key returns the unique key identifying the operation
+func (o Operation) key() string {
+	return operationKey(o.Method, o.Path)
+}
+
+// operationKey returns the unique key of the operation having the given method and path
+func operationKey(method, path string) string {
+	return fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// Document collects the operations registered by the various API registrars, and allows to generate the
+// OpenAPI 3 specification describing them
+type Document struct {
+	mu         sync.RWMutex
+	title      string
+	version    string
+	operations map[string]Operation
+}
+
+// NewDocument returns a new Document instance
+func NewDocument(title string, version string) *Document {
+	return &Document{
+		title:      title,
+		version:    version,
+		operations: map[string]Operation{},
+	}
+}
+
+// Add adds the given operations to the document, replacing any operation having the same method and path
+func (d *Document) Add(operations ...Operation) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, operation := range operations {
+		d.operations[operation.key()] = operation
+	}
+}
+
+// Undocumented returns the method and path of all the given routes that do not have an associated operation
+func (d *Document) Undocumented(routes gin.RoutesInfo) []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var undocumented []string
+	for _, route := range routes {
+		key := operationKey(route.Method, route.Path)
+		if _, found := d.operations[key]; !found {
+			undocumented = append(undocumented, key)
+		}
+	}
+	sort.Strings(undocumented)
+	return undocumented
+}
+
+// Spec generates the OpenAPI 3 specification of all the operations added to this document
+func (d *Document) Spec() *Spec {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	generator := newSchemaGenerator()
+	spec := &Spec{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: d.title, Version: d.version},
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: generator.components,
+			SecuritySchemes: map[string]SecurityScheme{
+				BearerAuth: {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+
+	for _, operation := range d.operations {
+		path, pathParams := convertPath(operation.Path)
+		item, found := spec.Paths[path]
+		if !found {
+			item = PathItem{}
+		}
+		item[strings.ToLower(operation.Method)] = buildOperationSpec(generator, operation, pathParams)
+		spec.Paths[path] = item
+	}
+
+	return spec
+}
+
+// buildOperationSpec builds the OpenAPI specification of the given operation
+func buildOperationSpec(generator *schemaGenerator, operation Operation, pathParams []string) OperationSpec {
+	spec := OperationSpec{
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Tags:        operation.Tags,
+		Responses:   map[string]ResponseSpec{},
+	}
+
+	for _, param := range pathParams {
+		spec.Parameters = append(spec.Parameters, ParameterSpec{
+			Name:     param,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	for _, param := range operation.Parameters {
+		schema := generator.schemaOf(param.Value)
+		if param.Value == nil {
+			schema = &Schema{Type: "string"}
+		}
+
+		spec.Parameters = append(spec.Parameters, ParameterSpec{
+			Name:        param.Name,
+			In:          "query",
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      schema,
+		})
+	}
+
+	if operation.Request != nil {
+		spec.RequestBody = &RequestBodySpec{
+			Required: true,
+			Content:  map[string]MediaType{contentTypeJSON: {Schema: generator.schemaOf(operation.Request)}},
+		}
+	}
+
+	if operation.Authenticated {
+		spec.Security = []map[string][]string{{BearerAuth: {}}}
+	}
+
+	status := operation.ResponseStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	contentType := operation.ResponseContentType
+	if contentType == "" {
+		contentType = contentTypeJSON
+		if _, isString := operation.Response.(string); isString {
+			contentType = contentTypeText
+		}
+	}
+
+	spec.Responses[strconv.Itoa(status)] = ResponseSpec{
+		Description: http.StatusText(status),
+		Content:     map[string]MediaType{contentType: {Schema: generator.schemaOf(operation.Response)}},
+	}
+
+	for _, errorStatus := range operation.Errors {
+		spec.Responses[strconv.Itoa(errorStatus)] = ResponseSpec{
+			Description: http.StatusText(errorStatus),
+			Content:     map[string]MediaType{contentTypeJSON: {Schema: generator.schemaOf(ErrorResponse{})}},
+		}
+	}
+
+	return spec
+}
+
+// convertPath converts the given Gin path to an OpenAPI path, returning the names of the path parameters
+func convertPath(ginPath string) (string, []string) {
+	var params []string
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			param := segment[1:]
+			params = append(params, param)
+			segments[i] = fmt.Sprintf("{%s}", param)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
@@ -0,0 +1,73 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
+)
+
+type testPost struct {
+	ID           uint64     `json:"id"`
+	Text         *string    `json:"text,omitempty"`
+	Tags         []string   `json:"tags"`
+	CreationDate time.Time  `json:"creation_date"`
+	Author       testAuthor `json:"author"`
+	internal     string
+}
+
+type testAuthor struct {
+	Address string `json:"address"`
+	Ignored string `json:"-"`
+}
+
+func TestDocument_Spec(t *testing.T) {
+	document := openapi.NewDocument("Test", "1.0.0")
+	document.Add(openapi.Operation{
+		Method:     http.MethodGet,
+		Path:       "/posts/:subspace_id/:id",
+		Parameters: []openapi.Parameter{{Name: "limit", Value: uint64(0)}},
+		Response:   testPost{},
+		Errors:     []int{http.StatusNotFound},
+	})
+
+	spec := document.Spec()
+	require.Equal(t, "3.0.3", spec.OpenAPI)
+
+	operation := spec.Paths["/posts/{subspace_id}/{id}"]["get"]
+	require.Len(t, operation.Parameters, 3)
+	require.Equal(t, "path", operation.Parameters[0].In)
+	require.Equal(t, "subspace_id", operation.Parameters[0].Name)
+	require.Equal(t, "query", operation.Parameters[2].In)
+	require.Equal(t, "integer", operation.Parameters[2].Schema.Type)
+
+	require.Equal(t, "#/components/schemas/testPost", operation.Responses["200"].Content["application/json"].Schema.Ref)
+	require.Equal(t, "#/components/schemas/ErrorResponse", operation.Responses["404"].Content["application/json"].Schema.Ref)
+
+	post := spec.Components.Schemas["testPost"]
+	require.ElementsMatch(t, []string{"id", "tags", "creation_date", "author"}, post.Required)
+	require.NotContains(t, post.Properties, "internal")
+	require.Equal(t, "date-time", post.Properties["creation_date"].Format)
+	require.True(t, post.Properties["text"].Nullable)
+	require.Equal(t, "array", post.Properties["tags"].Type)
+	require.Equal(t, "#/components/schemas/testAuthor", post.Properties["author"].Ref)
+
+	author := spec.Components.Schemas["testAuthor"]
+	require.NotContains(t, author.Properties, "Ignored")
+}
+
+func TestDocument_Undocumented(t *testing.T) {
+	document := openapi.NewDocument("Test", "1.0.0")
+	document.Add(openapi.Operation{Method: http.MethodGet, Path: "/documented", Response: ""})
+
+	routes := gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/documented"},
+		{Method: http.MethodPost, Path: "/documented"},
+		{Method: http.MethodGet, Path: "/undocumented"},
+	}
+	require.Equal(t, []string{"GET /undocumented", "POST /documented"}, document.Undocumented(routes))
+}
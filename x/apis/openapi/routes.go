@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"embed"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// SpecPath is the path on which the OpenAPI specification is served
+	SpecPath = "/openapi.json"
+
+	// SwaggerUIPath is the path on which the Swagger UI is served
+	SwaggerUIPath = "/swagger"
+
+	// SwaggerUIAssetsPath is the path on which the Swagger UI assets are served
+	SwaggerUIAssetsPath = SwaggerUIPath + "/*file"
+
+	// swaggerUIDir is the directory containing the embedded Swagger UI page and assets
+	swaggerUIDir = "swagger"
+)
+
+// swaggerUIFiles contains the Swagger UI page along with the swagger-ui-dist assets it loads,
+// so that the UI works without reaching any external CDN
+//
+//go:embed swagger
+var swaggerUIFiles embed.FS
+
+// RegisterRoutes registers the endpoint serving the OpenAPI specification of the given document.
+// If swaggerUI is true, the Swagger UI page and its assets are registered as well.
+func RegisterRoutes(router *gin.Engine, document *Document, swaggerUI bool) {
+	document.Add(Operation{
+		Method:   http.MethodGet,
+		Path:     SpecPath,
+		Summary:  "Returns the OpenAPI specification of all the available endpoints",
+		Tags:     []string{"docs"},
+		Response: Spec{},
+	})
+
+	router.GET(SpecPath, func(c *gin.Context) {
+		c.JSON(http.StatusOK, document.Spec())
+	})
+
+	if !swaggerUI {
+		return
+	}
+
+	document.Add(
+		Operation{
+			Method:              http.MethodGet,
+			Path:                SwaggerUIPath,
+			Summary:             "Returns the Swagger UI allowing to explore the available endpoints",
+			Tags:                []string{"docs"},
+			Response:            "",
+			ResponseContentType: contentTypeHTML,
+		},
+		Operation{
+			Method:              http.MethodGet,
+			Path:                SwaggerUIAssetsPath,
+			Summary:             "Returns the given asset of the Swagger UI",
+			Tags:                []string{"docs"},
+			Response:            "",
+			ResponseContentType: contentTypeBinary,
+			Errors:              []int{http.StatusNotFound},
+		},
+	)
+
+	router.GET(SwaggerUIPath, func(c *gin.Context) {
+		serveSwaggerUIFile(c, "/")
+	})
+
+	router.GET(SwaggerUIAssetsPath, func(c *gin.Context) {
+		serveSwaggerUIFile(c, c.Param("file"))
+	})
+}
+
+// serveSwaggerUIFile serves the embedded Swagger UI file having the given name, or the Swagger UI page if it is empty
+func serveSwaggerUIFile(c *gin.Context, name string) {
+	// Cleaning the name as an absolute path makes sure that no file outside the directory can be served
+	name = path.Clean("/" + name)
+	if name == "/" {
+		name = "/index.html"
+	}
+
+	bz, err := swaggerUIFiles.ReadFile(swaggerUIDir + name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "file not found"})
+		return
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = http.DetectContentType(bz)
+	}
+
+	c.Data(http.StatusOK, contentType, bz)
+}
@@ -0,0 +1,85 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
+)
+
+func TestRegisterRoutes_SwaggerUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	openapi.RegisterRoutes(router, openapi.NewDocument("Test", "1.0.0"), true)
+
+	testCases := []struct {
+		name                string
+		path                string
+		expStatus           int
+		expContentType      string
+		expBodyContains     string
+		expBodyNotContained string
+	}{
+		{
+			name:                "page is served",
+			path:                "/swagger",
+			expStatus:           http.StatusOK,
+			expContentType:      "text/html; charset=utf-8",
+			expBodyContains:     `src="/swagger/swagger-ui-bundle.js"`,
+			expBodyNotContained: "https://",
+		},
+		{
+			name:            "page is served with the trailing slash",
+			path:            "/swagger/",
+			expStatus:       http.StatusOK,
+			expContentType:  "text/html; charset=utf-8",
+			expBodyContains: "SwaggerUIBundle",
+		},
+		{
+			name:            "script is served",
+			path:            "/swagger/swagger-ui-bundle.js",
+			expStatus:       http.StatusOK,
+			expContentType:  "text/javascript; charset=utf-8",
+			expBodyContains: "SwaggerUIBundle",
+		},
+		{
+			name:           "stylesheet is served",
+			path:           "/swagger/swagger-ui.css",
+			expStatus:      http.StatusOK,
+			expContentType: "text/css; charset=utf-8",
+		},
+		{
+			name:      "missing file returns 404",
+			path:      "/swagger/missing.js",
+			expStatus: http.StatusNotFound,
+		},
+		{
+			name:      "files outside the directory are not served",
+			path:      "/swagger/../routes.go",
+			expStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			require.Equal(t, tc.expStatus, recorder.Code)
+			if tc.expContentType != "" {
+				require.Equal(t, tc.expContentType, recorder.Header().Get("Content-Type"))
+			}
+			if tc.expBodyContains != "" {
+				require.Contains(t, recorder.Body.String(), tc.expBodyContains)
+			}
+			if tc.expBodyNotContained != "" {
+				require.NotContains(t, recorder.Body.String(), tc.expBodyNotContained)
+			}
+		})
+	}
+}
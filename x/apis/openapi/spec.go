@@ -0,0 +1,67 @@
+package openapi
+
+// Spec represents an OpenAPI 3 document
+type Spec struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info contains the metadata about the API
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem contains the operations available on a single path, indexed by their lowercase HTTP method
+type PathItem map[string]OperationSpec
+
+// OperationSpec describes a single API operation on a path
+type OperationSpec struct {
+	Summary     string                  `json:"summary,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Parameters  []ParameterSpec         `json:"parameters,omitempty"`
+	RequestBody *RequestBodySpec        `json:"requestBody,omitempty"`
+	Responses   map[string]ResponseSpec `json:"responses"`
+	Security    []map[string][]string   `json:"security,omitempty"`
+}
+
+// ParameterSpec describes a single operation parameter
+type ParameterSpec struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBodySpec describes a single request body
+type RequestBodySpec struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// ResponseSpec describes a single response of an operation
+type ResponseSpec struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType contains the schema of a request or response body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable objects of the specification
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme defines a security scheme that can be used by the operations
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
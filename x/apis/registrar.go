@@ -1,23 +1,40 @@
 package apis
 
 import (
+	junocmd "github.com/forbole/juno/v5/cmd"
 	"github.com/forbole/juno/v5/modules/registrar"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 
+	"github.com/desmos-labs/athena/v2/utils"
+	"github.com/desmos-labs/athena/v2/x/apis/cache"
 	"github.com/desmos-labs/athena/v2/x/apis/endpoints"
+	"github.com/desmos-labs/athena/v2/x/apis/endpoints/feed"
+	"github.com/desmos-labs/athena/v2/x/apis/endpoints/search"
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
 )
 
 // Context contains all the useful data that might be used when registering an API handler
 type Context struct {
 	registrar.Context
 	GRPCConnection *grpc.ClientConn
+
+	// OpenAPI is the document where each registrar should describe the routes it registers
+	OpenAPI *openapi.Document
+
+	// Cache is the cache that should be used to store the responses of the read endpoints, if any
+	Cache cache.Cache
+
+	// Lifecycle is the lifecycle of the API server. Registrars can use it to run services that should be
+	// stopped along with the server
+	Lifecycle *utils.Lifecycle
 }
 
 func NewContext(ctx registrar.Context, grpcConnection *grpc.ClientConn) Context {
 	return Context{
 		Context:        ctx,
 		GRPCConnection: grpcConnection,
+		OpenAPI:        openapi.NewDocument("Athena APIs", junocmd.Version),
 	}
 }
 
@@ -38,7 +55,16 @@ func CombinedRegistrar(registrars ...Registrar) Registrar {
 }
 
 // DefaultRegistrar returns the default API registrar
-func DefaultRegistrar(_ Context, router *gin.Engine) error {
-	endpoints.RegisterRoutesList(router)
+func DefaultRegistrar(ctx Context, router *gin.Engine) error {
+	endpoints.RegisterRoutesList(router, ctx.OpenAPI)
+
+	if feedDb, ok := ctx.Database.(feed.Database); ok {
+		feed.RegisterRoutes(router, ctx.OpenAPI, ctx.Cache, feedDb)
+	}
+
+	if searchDb, ok := ctx.Database.(search.Database); ok {
+		search.RegisterRoutes(router, ctx.OpenAPI, ctx.Cache, searchDb)
+	}
+
 	return nil
 }
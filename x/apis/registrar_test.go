@@ -0,0 +1,75 @@
+package apis_test
+
+import (
+	"strings"
+	"testing"
+
+	junodb "github.com/forbole/juno/v5/database"
+	"github.com/forbole/juno/v5/modules/registrar"
+	"github.com/forbole/juno/v5/types/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/x/apis"
+	"github.com/desmos-labs/athena/v2/x/apis/admin"
+	"github.com/desmos-labs/athena/v2/x/apis/openapi"
+)
+
+// stubDatabase implements the databases required by the default routes, so that all of them are registered
+type stubDatabase struct {
+	junodb.Database
+}
+
+func (db stubDatabase) GetHomeFeed(uint64, string, *types.FeedCursor, uint64) ([]types.FeedPost, error) {
+	return nil, nil
+}
+
+func (db stubDatabase) SearchPosts(uint64, string, uint64, uint64) ([]types.SearchPost, error) {
+	return nil, nil
+}
+
+func (db stubDatabase) SearchProfiles(uint64, string, uint64, uint64) ([]types.SearchProfile, error) {
+	return nil, nil
+}
+
+// TestRegisteredRoutesHaveSchemas makes sure that all the routes registered by the Athena registrars are
+// described inside the OpenAPI document
+func TestRegisteredRoutesHaveSchemas(t *testing.T) {
+	junoCfg, err := config.DefaultConfigParser([]byte(`
+apis:
+  port: 3000
+  admin:
+    token: secret
+`))
+	require.NoError(t, err)
+
+	ctx := apis.NewContext(registrar.Context{JunoConfig: junoCfg, Database: stubDatabase{}}, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	registrars := apis.CombinedRegistrar(
+		apis.DefaultRegistrar,
+		admin.NewRegistrar(admin.Modules{}),
+	)
+	require.NoError(t, registrars(ctx, router))
+	openapi.RegisterRoutes(router, ctx.OpenAPI, true)
+
+	undocumented := ctx.OpenAPI.Undocumented(router.Routes())
+	require.Empty(t, undocumented, "routes without an OpenAPI schema: %s", strings.Join(undocumented, ", "))
+
+	paths := map[string]bool{}
+	for _, route := range router.Routes() {
+		paths[route.Path] = true
+	}
+
+	spec := ctx.OpenAPI.Spec()
+	require.Len(t, spec.Paths, len(paths))
+	require.Contains(t, spec.Paths, "/admin/jobs/{id}/logs")
+	require.Contains(t, spec.Paths, "/feed/{subspace_id}/{address}")
+	require.Contains(t, spec.Paths, "/search/{subspace_id}/posts")
+	require.Contains(t, spec.Paths, "/search/{subspace_id}/profiles")
+	require.Contains(t, spec.Paths, openapi.SwaggerUIPath)
+	require.Contains(t, spec.Paths, openapi.SwaggerUIPath+"/{file}")
+}
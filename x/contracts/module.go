@@ -19,8 +19,15 @@ type SmartContractModule interface {
 	RefreshData(height int64, subspaceID uint64) error
 }
 
+// PostsSmartContractModule represents a smart contract module storing data related to single posts
+type PostsSmartContractModule interface {
+	// RefreshPostData refreshes the smart contract data related to the given post
+	RefreshPostData(height int64, subspaceID uint64, postID uint64) error
+}
+
 var (
-	_ SmartContractModule = &Module{}
+	_ SmartContractModule      = &Module{}
+	_ PostsSmartContractModule = &Module{}
 )
 
 // Module represents the module that allows to handle all smart contracts modules easily
@@ -88,3 +95,26 @@ func (m Module) RefreshData(height int64, subspaceID uint64) error {
 
 	return nil
 }
+
+// RefreshPostData implements PostsSmartContractModule
+func (m Module) RefreshPostData(height int64, subspaceID uint64, postID uint64) error {
+	for _, module := range m.modules {
+		if module == nil {
+			continue
+		}
+
+		postsModule, ok := module.(PostsSmartContractModule)
+		if !ok {
+			continue
+		}
+
+		log.Info().Int64("height", height).Uint64("subspace id", subspaceID).Uint64("post id", postID).
+			Msgf("refreshing %s", module.Name())
+		err := postsModule.RefreshPostData(height, subspaceID, postID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
 	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
 
 	"github.com/desmos-labs/athena/v2/types"
@@ -45,7 +46,28 @@ func (m *Module) RefreshData(height int64, subspaceID uint64) error {
 		}
 
 		// Refresh the tips data
-		err = m.refreshTips(height, contractAddress)
+		err = m.refreshTips(height, contractAddress, allTips)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RefreshPostData refreshes the tips sent to the given post using the tips contracts of its subspace, if any
+func (m *Module) RefreshPostData(height int64, subspaceID uint64, postID uint64) error {
+	for _, contractAddress := range m.cfg.Addresses {
+		isSubspaceContract, err := m.isSubspaceContract(height, subspaceID, contractAddress)
+		if err != nil {
+			return err
+		}
+
+		if !isSubspaceContract {
+			continue
+		}
+
+		err = m.refreshTips(height, contractAddress, postTips(postID))
 		if err != nil {
 			return err
 		}
@@ -84,9 +106,27 @@ func (m *Module) refreshContractConfig(height int64, address string) error {
 	return m.db.SaveContract(types.NewContract(address, types.ContractTypeTips, configBz, height))
 }
 
+// allTips is the filter that accepts all the tips
+func allTips(_ *wasmtypes.MsgExecuteContract) bool {
+	return true
+}
+
+// postTips returns the filter that accepts only the tips sent to the post having the given id
+func postTips(postID uint64) func(msg *wasmtypes.MsgExecuteContract) bool {
+	return func(msg *wasmtypes.MsgExecuteContract) bool {
+		msgSendTip, ok := utils.IsMsgSendTip(msg)
+		if !ok || msgSendTip == nil || msgSendTip.Target == nil || msgSendTip.Target.Content == nil {
+			return false
+		}
+
+		tipPostID, err := poststypes.ParsePostID(msgSendTip.Target.Content.PostID)
+		return err == nil && tipPostID == postID
+	}
+}
+
 // refreshTips fetches and stores all the tips sent using the contract having the given address
-// before or on the provided height
-func (m *Module) refreshTips(height int64, contractAddress string) error {
+// before or on the provided height, skipping the MsgExecuteContract not accepted by the given filter
+func (m *Module) refreshTips(height int64, contractAddress string, filter func(msg *wasmtypes.MsgExecuteContract) bool) error {
 	// Query all the transactions
 	permissionsQuery := fmt.Sprintf("%s.%s='%s' AND %s.%s='%s' AND tx.height <= %d",
 		wasmtypes.WasmModuleEventType,
@@ -122,6 +162,10 @@ func (m *Module) refreshTips(height int64, contractAddress string) error {
 				}
 
 				for innerIndex, innerMsg := range innerMsgs {
+					if executeMsg, ok := innerMsg.(*wasmtypes.MsgExecuteContract); ok && !filter(executeMsg) {
+						continue
+					}
+
 					err = m.HandleMsgExec(index, msg, innerIndex, innerMsg, transaction)
 					if err != nil {
 						return err
@@ -129,7 +173,7 @@ func (m *Module) refreshTips(height int64, contractAddress string) error {
 				}
 			}
 
-			if msg, ok := msg.(*wasmtypes.MsgExecuteContract); ok {
+			if msg, ok := msg.(*wasmtypes.MsgExecuteContract); ok && filter(msg) {
 				err = m.handleMsgExecuteContract(transaction, msg)
 				if err != nil {
 					return err
@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	_ contracts.SmartContractModule = &Module{}
+	_ contracts.SmartContractModule      = &Module{}
+	_ contracts.PostsSmartContractModule = &Module{}
 )
 
 type Module struct {
@@ -0,0 +1,38 @@
+package failures
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config contains the configuration of the failed messages retries
+type Config struct {
+	// MaxRetries is the number of times a failed message is retried by the periodic task before giving up.
+	// Messages that exceed it can still be retried using the parse failed-messages command.
+	MaxRetries int `yaml:"max_retries"`
+
+	// RetryInterval is the interval between two runs of the periodic task retrying the failed messages.
+	// If zero, the failed messages are only retried using the parse failed-messages command.
+	RetryInterval time.Duration `yaml:"retry_interval"`
+}
+
+// DefaultConfig returns the configuration used when no failures configuration is provided
+func DefaultConfig() *Config {
+	return &Config{
+		MaxRetries:    10,
+		RetryInterval: 10 * time.Minute,
+	}
+}
+
+func ParseConfig(bz []byte) (*Config, error) {
+	type T struct {
+		Config *Config `yaml:"failures"`
+	}
+	cfg := T{Config: DefaultConfig()}
+	err := yaml.Unmarshal(bz, &cfg)
+	if cfg.Config == nil {
+		return DefaultConfig(), err
+	}
+	return cfg.Config, err
+}
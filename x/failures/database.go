@@ -0,0 +1,11 @@
+package failures
+
+import (
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+type Database interface {
+	SaveFailedMessage(message types.FailedMessage) error
+	GetFailedMessages() ([]types.FailedMessage, error)
+	DeleteFailedMessage(message types.FailedMessage) error
+}
@@ -0,0 +1,52 @@
+package failures
+
+import (
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	if m.cfg.RetryInterval == 0 {
+		return nil
+	}
+
+	log.Info().Str("module", "failures").Msg("setting up periodic tasks")
+
+	// Retry the failed messages periodically
+	if _, err := scheduler.Every(m.cfg.RetryInterval).Do(m.retryFailedMessages); err != nil {
+		return fmt.Errorf("error while scheduling failures peridic operation: %s", err)
+	}
+
+	return nil
+}
+
+// retryFailedMessages handles again the failed messages that have not exceeded the maximum number of retries
+func (m *Module) retryFailedMessages() {
+	messages, err := m.db.GetFailedMessages()
+	if err != nil {
+		log.Error().Err(err).Msg("error while getting failed messages")
+		return
+	}
+
+	var retried []types.FailedMessage
+	for _, message := range messages {
+		if message.RetryCount < m.cfg.MaxRetries {
+			retried = append(retried, message)
+		}
+	}
+
+	if len(retried) == 0 {
+		return
+	}
+
+	log.Info().Int("failed messages", len(retried)).Msg("retrying failed messages")
+	err = m.Retry(retried)
+	if err != nil {
+		log.Error().Err(err).Msg("error while retrying failed messages")
+	}
+}
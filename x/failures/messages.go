@@ -0,0 +1,38 @@
+package failures
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	juno "github.com/forbole/juno/v5/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// FindMsgIndexes returns the index of the given message inside the given transaction and, if it has been executed
+// through a MsgExec, its index inside such MsgExec. The message is looked up by identity, since the parser handles
+// the same instances that are cached inside the transaction messages.
+func FindMsgIndexes(tx *juno.Tx, msg sdk.Msg) (msgIndex int, authzIndex int, found bool) {
+	for i, msgAny := range tx.Body.Messages {
+		cachedMsg, ok := msgAny.GetCachedValue().(sdk.Msg)
+		if !ok {
+			continue
+		}
+
+		if cachedMsg == msg {
+			return i, types.NoAuthzIndex, true
+		}
+
+		msgExec, ok := cachedMsg.(*authz.MsgExec)
+		if !ok {
+			continue
+		}
+
+		for j, executedAny := range msgExec.Msgs {
+			if executedMsg, ok := executedAny.GetCachedValue().(sdk.Msg); ok && executedMsg == msg {
+				return i, j, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
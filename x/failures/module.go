@@ -0,0 +1,55 @@
+package failures
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/node"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ modules.Module                   = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+)
+
+// Module represents the module that handles again the messages whose handling failed
+type Module struct {
+	cfg  *Config
+	node node.Node
+	cdc  codec.Codec
+	db   Database
+
+	// modules contains the modules used to handle the failed messages again
+	modules modules.Modules
+}
+
+// NewModule returns a new Module instance
+func NewModule(junoCfg config.Config, node node.Node, cdc codec.Codec, db Database) *Module {
+	bz, err := junoCfg.GetBytes()
+	if err != nil {
+		panic(err)
+	}
+
+	cfg, err := ParseConfig(bz)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Module{
+		cfg:  cfg,
+		node: node,
+		cdc:  cdc,
+		db:   db,
+	}
+}
+
+// WithModules sets the modules used to handle the failed messages again
+func (m *Module) WithModules(modules []modules.Module) *Module {
+	m.modules = modules
+	return m
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return "failures"
+}
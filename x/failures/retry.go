@@ -0,0 +1,98 @@
+package failures
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/forbole/juno/v5/modules"
+	juno "github.com/forbole/juno/v5/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// Retry handles again the given failed messages. Messages that are handled successfully are removed,
+// while the ones that fail again are stored along with their new error and an incremented retry count.
+func (m *Module) Retry(messages []types.FailedMessage) error {
+	txs := map[string]*juno.Tx{}
+	for _, message := range messages {
+		module, found := m.modules.FindByName(message.Module)
+		if !found {
+			log.Warn().Str("module", message.Module).Int64("height", message.Height).
+				Msg("module not enabled, skipping failed message")
+			continue
+		}
+
+		err := m.retryMessage(module, message, txs)
+		if err == nil {
+			err = m.db.DeleteFailedMessage(message)
+			if err != nil {
+				return fmt.Errorf("error while deleting failed message: %s", err)
+			}
+			continue
+		}
+
+		log.Error().Err(err).Str("module", message.Module).Int64("height", message.Height).
+			Str("tx hash", message.TxHash).Int("msg index", message.MsgIndex).Msg("error while retrying failed message")
+
+		message.Error = err.Error()
+		message.FailedAt = time.Now().UTC()
+		err = m.db.SaveFailedMessage(message)
+		if err != nil {
+			return fmt.Errorf("error while storing failed message: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// retryMessage handles again the given failed message using the given module.
+// The given transactions are used as a cache to avoid fetching the same transaction multiple times.
+func (m *Module) retryMessage(module modules.Module, message types.FailedMessage, txs map[string]*juno.Tx) error {
+	tx, found := txs[message.TxHash]
+	if !found {
+		var err error
+		tx, err = m.node.Tx(message.TxHash)
+		if err != nil {
+			return fmt.Errorf("error while getting transaction: %s", err)
+		}
+		txs[message.TxHash] = tx
+	}
+
+	if message.MsgIndex >= len(tx.Body.Messages) {
+		return fmt.Errorf("invalid message index: %d", message.MsgIndex)
+	}
+
+	var msg sdk.Msg
+	err := m.cdc.UnpackAny(tx.Body.Messages[message.MsgIndex], &msg)
+	if err != nil {
+		return fmt.Errorf("error while unpacking message: %s", err)
+	}
+
+	if message.AuthzIndex == types.NoAuthzIndex {
+		messageModule, ok := module.(modules.MessageModule)
+		if !ok {
+			return fmt.Errorf("module %s does not handle messages", message.Module)
+		}
+		return messageModule.HandleMsg(message.MsgIndex, msg, tx)
+	}
+
+	msgExec, ok := msg.(*authz.MsgExec)
+	if !ok || message.AuthzIndex >= len(msgExec.Msgs) {
+		return fmt.Errorf("invalid authz index: %d", message.AuthzIndex)
+	}
+
+	var executedMsg sdk.Msg
+	err = m.cdc.UnpackAny(msgExec.Msgs[message.AuthzIndex], &executedMsg)
+	if err != nil {
+		return fmt.Errorf("error while unpacking MsgExec inner message: %s", err)
+	}
+
+	authzModule, ok := module.(modules.AuthzMessageModule)
+	if !ok {
+		return fmt.Errorf("module %s does not handle MsgExec messages", message.Module)
+	}
+	return authzModule.HandleMsgExec(message.MsgIndex, msgExec, message.AuthzIndex, executedMsg, tx)
+}
@@ -0,0 +1,35 @@
+package gaps
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// Config contains the configuration of the periodic gaps check
+type Config struct {
+	// MaxScannedHeights is the maximum number of heights searched for missing blocks by each run of the periodic task
+	MaxScannedHeights int64 `yaml:"max_scanned_heights"`
+
+	// MaxBackfilledHeights is the maximum number of failed heights parsed again by each run of the periodic task.
+	// The remaining ones are parsed again by the next runs, starting from the ones that failed least recently.
+	MaxBackfilledHeights int `yaml:"max_backfilled_heights"`
+}
+
+// DefaultConfig returns the configuration used when no gaps configuration is provided
+func DefaultConfig() *Config {
+	return &Config{
+		MaxScannedHeights:    100000,
+		MaxBackfilledHeights: 100,
+	}
+}
+
+func ParseConfig(bz []byte) (*Config, error) {
+	type T struct {
+		Config *Config `yaml:"gaps"`
+	}
+	cfg := T{Config: DefaultConfig()}
+	err := yaml.Unmarshal(bz, &cfg)
+	if cfg.Config == nil {
+		return DefaultConfig(), err
+	}
+	return cfg.Config, err
+}
@@ -0,0 +1,16 @@
+package gaps
+
+import (
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+type Database interface {
+	GetLastBlockHeight() (int64, error)
+	GetMissingHeights(startHeight, endHeight int64) []int64
+
+	SaveFailedHeight(failedHeight types.FailedHeight) error
+	GetFailedHeights() ([]types.FailedHeight, error)
+	DeleteFailedHeight(height int64, module string) error
+	SaveGapsCheckpoint(height int64) error
+	GetGapsCheckpoint() (int64, error)
+}
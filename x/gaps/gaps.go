@@ -0,0 +1,91 @@
+package gaps
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/parser"
+	"github.com/rs/zerolog/log"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// StartHeight returns the first height that should be stored inside the database
+func (m *Module) StartHeight() int64 {
+	if m.junoCfg.Parser.StartHeight > 0 {
+		return m.junoCfg.Parser.StartHeight
+	}
+	return 1
+}
+
+// FindGaps stores the heights between from and to (both included) whose block is missing from the database,
+// and returns all the failed heights, including the ones on which the modules handlers returned an error
+func (m *Module) FindGaps(from int64, to int64) ([]types.FailedHeight, error) {
+	if from <= to {
+		now := time.Now().UTC()
+		for _, height := range m.db.GetMissingHeights(from, to) {
+			err := m.db.SaveFailedHeight(types.NewFailedHeight(height, types.MissingBlockModule, "missing block", now))
+			if err != nil {
+				return nil, fmt.Errorf("error while storing missing height %d: %s", height, err)
+			}
+		}
+	}
+
+	return m.db.GetFailedHeights()
+}
+
+// Backfill parses the given failed heights again. Missing blocks are parsed using all the modules,
+// while the other heights are parsed only using the module that failed on them.
+// Heights that fail again are stored along with their new error.
+func (m *Module) Backfill(failedHeights []types.FailedHeight) error {
+	if m.parserCtx == nil {
+		return fmt.Errorf("parser context not set")
+	}
+
+	for _, failedHeight := range failedHeights {
+		worker, found := m.buildWorker(failedHeight.Module)
+		if !found {
+			log.Warn().Str("module", failedHeight.Module).Int64("height", failedHeight.Height).
+				Msg("module not enabled, skipping failed height")
+			continue
+		}
+
+		// Remove the failed height before parsing it again, so that the modules errors can store it again
+		err := m.db.DeleteFailedHeight(failedHeight.Height, failedHeight.Module)
+		if err != nil {
+			return fmt.Errorf("error while deleting failed height %d: %s", failedHeight.Height, err)
+		}
+
+		log.Info().Str("module", failedHeight.Module).Int64("height", failedHeight.Height).Msg("parsing failed height")
+		err = worker.Process(failedHeight.Height)
+		if err != nil {
+			log.Error().Err(err).Str("module", failedHeight.Module).Int64("height", failedHeight.Height).
+				Msg("error while parsing failed height")
+
+			failedHeight = types.NewFailedHeight(failedHeight.Height, failedHeight.Module, err.Error(), time.Now().UTC())
+			err = m.db.SaveFailedHeight(failedHeight)
+			if err != nil {
+				return fmt.Errorf("error while storing failed height %d: %s", failedHeight.Height, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// buildWorker returns the worker that should be used to parse again the heights that failed for the given module
+func (m *Module) buildWorker(module string) (parser.Worker, bool) {
+	workerModules := m.parserCtx.Modules
+	if module != types.MissingBlockModule {
+		failedModule, found := modules.Modules(m.parserCtx.Modules).FindByName(module)
+		if !found {
+			return parser.Worker{}, false
+		}
+		workerModules = []modules.Module{failedModule}
+	}
+
+	ctx := m.parserCtx
+	workerCtx := parser.NewContext(ctx.EncodingConfig, ctx.Node, ctx.Database, ctx.Logger, workerModules)
+	return parser.NewWorker(workerCtx, nil, 0), true
+}
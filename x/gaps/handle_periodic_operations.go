@@ -0,0 +1,88 @@
+package gaps
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// recentHeightsMargin represents the number of most recent heights that are not checked,
+	// since they might still be being parsed
+	recentHeightsMargin = 100
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	log.Info().Str("module", "gaps").Msg("setting up periodic tasks")
+
+	// Find and parse again the failed heights every 10 minutes
+	if _, err := scheduler.Every(10).Minutes().Do(m.backfillGaps); err != nil {
+		return fmt.Errorf("error while scheduling gaps peridic operation: %s", err)
+	}
+
+	return nil
+}
+
+// backfillGaps finds the heights that have not been parsed properly since the previous run, and parses
+// a limited number of the failed heights again
+func (m *Module) backfillGaps() {
+	lastHeight, err := m.db.GetLastBlockHeight()
+	if err != nil {
+		log.Error().Err(err).Msg("error while getting last block height")
+		return
+	}
+
+	checkpoint, err := m.db.GetGapsCheckpoint()
+	if err != nil {
+		log.Error().Err(err).Msg("error while getting gaps checkpoint")
+		return
+	}
+
+	// Only search the heights that have not been searched yet, up to the maximum number of heights per run
+	from := checkpoint + 1
+	if from < m.StartHeight() {
+		from = m.StartHeight()
+	}
+
+	to := lastHeight - recentHeightsMargin
+	if maxTo := from + m.cfg.MaxScannedHeights - 1; to > maxTo {
+		to = maxTo
+	}
+
+	failedHeights, err := m.FindGaps(from, to)
+	if err != nil {
+		log.Error().Err(err).Msg("error while finding gaps")
+		return
+	}
+
+	// The missing heights have been stored, so the next run can start after the searched ones
+	if from <= to {
+		err = m.db.SaveGapsCheckpoint(to)
+		if err != nil {
+			log.Error().Err(err).Msg("error while storing gaps checkpoint")
+			return
+		}
+	}
+
+	if len(failedHeights) == 0 {
+		return
+	}
+
+	// Parse again the heights that failed least recently first, so that the ones failing again are not
+	// retried before the others
+	sort.SliceStable(failedHeights, func(i, j int) bool {
+		return failedHeights[i].FailedAt.Before(failedHeights[j].FailedAt)
+	})
+	if len(failedHeights) > m.cfg.MaxBackfilledHeights {
+		failedHeights = failedHeights[:m.cfg.MaxBackfilledHeights]
+	}
+
+	log.Info().Int("failed heights", len(failedHeights)).Msg("backfilling failed heights")
+	err = m.Backfill(failedHeights)
+	if err != nil {
+		log.Error().Err(err).Msg("error while backfilling failed heights")
+	}
+}
@@ -0,0 +1,127 @@
+package gaps
+
+import (
+	"sync"
+	"time"
+
+	tmctypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/forbole/juno/v5/logging"
+	"github.com/forbole/juno/v5/modules"
+	juno "github.com/forbole/juno/v5/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/x/failures"
+)
+
+var (
+	_ logging.Logger = &Logger{}
+)
+
+// LoggerDatabase represents the database used by the Logger to store the failures of the modules handlers
+type LoggerDatabase interface {
+	SaveFailedHeight(failedHeight types.FailedHeight) error
+	SaveFailedMessage(message types.FailedMessage) error
+}
+
+// Logger represents a logging.Logger that, along with logging the errors returned by the modules handlers,
+// stores them so that they can be handled again. Since Juno only logs such errors, without handling the
+// block again, the failed messages are stored to be retried by the failures module, while the heights on which
+// the other handlers failed are stored to be parsed again.
+type Logger struct {
+	logging.Logger
+
+	mu sync.RWMutex
+	db LoggerDatabase
+}
+
+// NewLogger returns a new Logger instance wrapping the given one
+func NewLogger(logger logging.Logger) *Logger {
+	return &Logger{
+		Logger: logger,
+	}
+}
+
+// SetDatabase sets the database used to store the failed heights and messages.
+// Until it is set, the errors are only logged.
+func (l *Logger) SetDatabase(db LoggerDatabase) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.db = db
+}
+
+// saveFailedHeight stores the given height as failed for the given module
+func (l *Logger) saveFailedHeight(height int64, module modules.Module, err error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.db == nil {
+		return
+	}
+
+	saveErr := l.db.SaveFailedHeight(types.NewFailedHeight(height, module.Name(), err.Error(), time.Now().UTC()))
+	if saveErr != nil {
+		l.Logger.Error("error while storing failed height", "err", saveErr, logging.LogKeyHeight, height)
+	}
+}
+
+// saveFailedMessage stores the given message as failed for the given module,
+// returning false if it could not be found inside the given transaction or stored
+func (l *Logger) saveFailedMessage(module modules.Module, tx *juno.Tx, msg sdk.Msg, err error) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.db == nil {
+		return true
+	}
+
+	msgIndex, authzIndex, found := failures.FindMsgIndexes(tx, msg)
+	if !found {
+		return false
+	}
+
+	saveErr := l.db.SaveFailedMessage(types.NewFailedMessage(
+		tx.Height, tx.TxHash, msgIndex, authzIndex, module.Name(), sdk.MsgTypeURL(msg), err.Error(), 0, time.Now().UTC(),
+	))
+	if saveErr != nil {
+		l.Logger.Error("error while storing failed message", "err", saveErr, logging.LogKeyHeight, tx.Height)
+		return false
+	}
+
+	return true
+}
+
+// GenesisError implements logging.Logger
+func (l *Logger) GenesisError(module modules.Module, err error) {
+	l.Logger.GenesisError(module, err)
+	l.saveFailedHeight(0, module, err)
+}
+
+// BlockError implements logging.Logger
+func (l *Logger) BlockError(module modules.Module, block *tmctypes.ResultBlock, err error) {
+	l.Logger.BlockError(module, block, err)
+	l.saveFailedHeight(block.Block.Height, module, err)
+}
+
+// EventsError implements logging.Logger
+func (l *Logger) EventsError(module modules.Module, block *tmctypes.ResultBlock, err error) {
+	l.Logger.EventsError(module, block, err)
+	l.saveFailedHeight(block.Block.Height, module, err)
+}
+
+// TxError implements logging.Logger
+func (l *Logger) TxError(module modules.Module, tx *juno.Tx, err error) {
+	l.Logger.TxError(module, tx, err)
+	l.saveFailedHeight(tx.Height, module, err)
+}
+
+// MsgError implements logging.Logger
+func (l *Logger) MsgError(module modules.Module, tx *juno.Tx, msg sdk.Msg, err error) {
+	l.Logger.MsgError(module, tx, msg, err)
+
+	// Store only the failed message so that the other ones are not handled again,
+	// falling back to the whole height if it cannot be stored
+	if !l.saveFailedMessage(module, tx, msg, err) {
+		l.saveFailedHeight(tx.Height, module, err)
+	}
+}
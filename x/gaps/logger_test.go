@@ -0,0 +1,100 @@
+package gaps_test
+
+import (
+	"fmt"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/forbole/juno/v5/logging"
+	juno "github.com/forbole/juno/v5/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/x/gaps"
+)
+
+// mockDatabase stores the failed heights and messages in memory
+type mockDatabase struct {
+	heights  []types.FailedHeight
+	messages []types.FailedMessage
+	err      error
+}
+
+func (db *mockDatabase) SaveFailedHeight(failedHeight types.FailedHeight) error {
+	db.heights = append(db.heights, failedHeight)
+	return nil
+}
+
+func (db *mockDatabase) SaveFailedMessage(message types.FailedMessage) error {
+	if db.err != nil {
+		return db.err
+	}
+	db.messages = append(db.messages, message)
+	return nil
+}
+
+// postsModule represents the module whose handlers fail
+type postsModule struct{}
+
+func (m postsModule) Name() string {
+	return "posts"
+}
+
+func buildTx(t *testing.T, msgs ...sdk.Msg) *juno.Tx {
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		msgAny, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		anys[i] = msgAny
+	}
+
+	return &juno.Tx{
+		Tx:         &sdktx.Tx{Body: &sdktx.TxBody{Messages: anys}},
+		TxResponse: &sdk.TxResponse{Height: 10, TxHash: "TX_HASH"},
+	}
+}
+
+func TestLogger_MsgError(t *testing.T) {
+	msg := &banktypes.MsgSend{FromAddress: "from"}
+	executedMsg := &banktypes.MsgSend{FromAddress: "executed"}
+	msgExec := authz.NewMsgExec(sdk.AccAddress("grantee"), []sdk.Msg{&banktypes.MsgSend{}, executedMsg})
+	tx := buildTx(t, &banktypes.MsgSend{}, msg, &msgExec)
+
+	db := &mockDatabase{}
+	logger := gaps.NewLogger(logging.DefaultLogger())
+	logger.SetDatabase(db)
+
+	// The failed messages should be stored instead of their heights
+	logger.MsgError(postsModule{}, tx, msg, fmt.Errorf("post not found"))
+	logger.MsgError(postsModule{}, tx, executedMsg, fmt.Errorf("post not found"))
+	require.Empty(t, db.heights)
+	require.Len(t, db.messages, 2)
+
+	stored := db.messages[0]
+	require.Equal(t, int64(10), stored.Height)
+	require.Equal(t, "TX_HASH", stored.TxHash)
+	require.Equal(t, 1, stored.MsgIndex)
+	require.Equal(t, types.NoAuthzIndex, stored.AuthzIndex)
+	require.Equal(t, "posts", stored.Module)
+	require.Equal(t, sdk.MsgTypeURL(msg), stored.MsgType)
+	require.Equal(t, "post not found", stored.Error)
+
+	require.Equal(t, 2, db.messages[1].MsgIndex)
+	require.Equal(t, 1, db.messages[1].AuthzIndex)
+
+	// The height should be stored if the message cannot be found inside the transaction
+	logger.MsgError(postsModule{}, tx, &banktypes.MsgSend{}, fmt.Errorf("post not found"))
+	require.Len(t, db.messages, 2)
+	require.Len(t, db.heights, 1)
+	require.Equal(t, int64(10), db.heights[0].Height)
+	require.Equal(t, "posts", db.heights[0].Module)
+
+	// The height should be stored if the failed message cannot be stored
+	db.err = fmt.Errorf("connection refused")
+	logger.MsgError(postsModule{}, tx, msg, fmt.Errorf("post not found"))
+	require.Len(t, db.heights, 2)
+}
@@ -0,0 +1,53 @@
+package gaps
+
+import (
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/parser"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ modules.Module                   = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+)
+
+// Module represents the module that finds the heights that have not been parsed properly and parses them again
+type Module struct {
+	cfg     *Config
+	junoCfg config.Config
+	db      Database
+
+	// parserCtx is the context used to parse the failed heights again
+	parserCtx *parser.Context
+}
+
+// NewModule returns a new Module instance
+func NewModule(junoCfg config.Config, db Database) *Module {
+	bz, err := junoCfg.GetBytes()
+	if err != nil {
+		panic(err)
+	}
+
+	cfg, err := ParseConfig(bz)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Module{
+		cfg:     cfg,
+		junoCfg: junoCfg,
+		db:      db,
+	}
+}
+
+// WithParserContext sets the context used to parse the failed heights again.
+// Its modules are the ones the failed heights are dispatched to.
+func (m *Module) WithParserContext(ctx *parser.Context) *Module {
+	m.parserCtx = ctx
+	return m
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return "gaps"
+}
@@ -0,0 +1,31 @@
+package journal
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// Config contains the configuration of the change journal
+type Config struct {
+	// Retention is the number of heights, counted back from the latest parsed one, for which the change journal
+	// entries are kept. The database cannot be rolled back below such heights. If zero, the entries are never pruned.
+	Retention int64 `yaml:"retention"`
+}
+
+// DefaultConfig returns the configuration used when no journal configuration is provided
+func DefaultConfig() *Config {
+	return &Config{
+		Retention: 100000,
+	}
+}
+
+func ParseConfig(bz []byte) (*Config, error) {
+	type T struct {
+		Config *Config `yaml:"journal"`
+	}
+	cfg := T{Config: DefaultConfig()}
+	err := yaml.Unmarshal(bz, &cfg)
+	if cfg.Config == nil {
+		return DefaultConfig(), err
+	}
+	return cfg.Config, err
+}
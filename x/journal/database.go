@@ -0,0 +1,5 @@
+package journal
+
+type Database interface {
+	PruneChangeJournal(retention int64) error
+}
@@ -0,0 +1,32 @@
+package journal
+
+import (
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	if m.cfg.Retention == 0 {
+		return nil
+	}
+
+	log.Info().Str("module", "journal").Msg("setting up periodic tasks")
+
+	// Prune the change journal every hour
+	if _, err := scheduler.Every(1).Hour().StartImmediately().SingletonMode().Do(m.pruneChangeJournal); err != nil {
+		return fmt.Errorf("error while scheduling journal periodic operation: %s", err)
+	}
+
+	return nil
+}
+
+// pruneChangeJournal removes the change journal entries recorded before the retained heights
+func (m *Module) pruneChangeJournal() {
+	err := m.db.PruneChangeJournal(m.cfg.Retention)
+	if err != nil {
+		log.Error().Str("module", "journal").Err(err).Msg("error while pruning change journal")
+	}
+}
@@ -0,0 +1,40 @@
+package journal
+
+import (
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ modules.Module                   = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+)
+
+// Module represents the module that prunes the change journal entries that are too old to be rolled back to
+type Module struct {
+	cfg *Config
+	db  Database
+}
+
+// NewModule returns a new Module instance
+func NewModule(junoCfg config.Config, db Database) *Module {
+	bz, err := junoCfg.GetBytes()
+	if err != nil {
+		panic(err)
+	}
+
+	cfg, err := ParseConfig(bz)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Module{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return "journal"
+}
@@ -1,12 +1,18 @@
 package notifications
 
 import (
+	"time"
+
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
 	FirebaseCredentialsFilePath string `yaml:"firebase_credentials_file_path"`
 	PersistHistory              bool   `yaml:"persist_history"`
+
+	// HistoryRetention is the period after which the persisted notifications are removed.
+	// If zero, the notifications are never removed.
+	HistoryRetention time.Duration `yaml:"history_retention"`
 }
 
 func ParseConfig(bz []byte) (*Config, error) {
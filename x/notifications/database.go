@@ -1,11 +1,15 @@
 package notifications
 
 import (
+	"time"
+
 	"github.com/desmos-labs/athena/v2/types"
 )
 
 type Database interface {
 	SaveNotification(recipient types.NotificationRecipient, notification types.NotificationData) error
+	CreateNotificationPartitions(from time.Time, months int) error
+	DropNotificationPartitions(before time.Time) ([]string, error)
 	SaveToken(token types.NotificationToken) error
 	GetUserTokens(userAddress string) ([]types.NotificationToken, error)
 }
@@ -0,0 +1,57 @@
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// upcomingPartitions represents the number of monthly partitions, including the current one,
+	// that should always exist in order to store the upcoming notifications
+	upcomingPartitions = 3
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	if !m.cfg.PersistHistory {
+		return nil
+	}
+
+	log.Info().Str("module", "notifications").Msg("setting up periodic tasks")
+
+	// Maintain the notifications history partitions every day
+	if _, err := scheduler.Every(1).Day().StartImmediately().Do(m.maintainHistoryPartitions); err != nil {
+		return fmt.Errorf("error while scheduling notifications peridic operation: %s", err)
+	}
+
+	return nil
+}
+
+// maintainHistoryPartitions creates the partitions for the upcoming notifications,
+// and drops the ones containing notifications older than the retention period
+func (m *Module) maintainHistoryPartitions() {
+	now := time.Now().UTC()
+
+	err := m.db.CreateNotificationPartitions(now, upcomingPartitions)
+	if err != nil {
+		log.Error().Err(err).Msg("error while creating notification partitions")
+		return
+	}
+
+	if m.cfg.HistoryRetention == 0 {
+		return
+	}
+
+	dropped, err := m.db.DropNotificationPartitions(now.Add(-m.cfg.HistoryRetention))
+	if err != nil {
+		log.Error().Err(err).Msg("error while dropping notification partitions")
+		return
+	}
+
+	for _, partition := range dropped {
+		log.Info().Str("module", "notifications").Str("partition", partition).Msg("dropped notification partition")
+	}
+}
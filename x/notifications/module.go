@@ -15,9 +15,10 @@ import (
 )
 
 var (
-	_ modules.Module            = &Module{}
-	_ modules.TransactionModule = &Module{}
-	_ modules.MessageModule     = &Module{}
+	_ modules.Module                   = &Module{}
+	_ modules.TransactionModule        = &Module{}
+	_ modules.MessageModule            = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
 )
 
 type Module struct {
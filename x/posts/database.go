@@ -1,17 +1,33 @@
 package posts
 
 import (
+	"time"
+
 	"github.com/desmos-labs/athena/v2/types"
 )
 
 type Database interface {
+	// InPostsTransaction runs the given function passing it a copy of the database whose writes are all
+	// performed inside a single transaction, which is committed only if the function returns no error
+	InPostsTransaction(fn func(txDb Database) error) error
+
 	SavePost(post types.Post) error
+	SavePosts(posts []types.Post) error
 	HasPost(height int64, subspaceID uint64, postID uint64) (bool, error)
 	DeletePost(height int64, subspaceID uint64, postID uint64) error
 	DeleteAllPosts(height int64, subspaceID uint64) error
+	TombstonePost(height int64, subspaceID uint64, postID uint64, txHash string, timestamp time.Time) error
+	TombstoneAllPosts(height int64, subspaceID uint64, timestamp time.Time) error
+	GetPostContent(subspaceID uint64, postID uint64) (*types.PostContent, error)
+	SavePostRevision(revision types.PostRevision) error
 	SavePostTx(tx types.PostTransaction) error
+	SavePostTxs(txs []types.PostTransaction) error
 	SavePostAttachment(attachment types.PostAttachment) error
+	SavePostAttachments(attachments []types.PostAttachment) error
 	DeletePostAttachment(height int64, subspaceID uint64, postID uint64, attachmentID uint32) error
+	DeletePostAttachments(height int64, subspaceID uint64, postID uint64) error
 	SavePollAnswer(answer types.PollAnswer) error
+	SavePollAnswers(answers []types.PollAnswer) error
 	SavePostsParams(params types.PostsParams) error
+	GetPostsState(subspaceID uint64) ([]types.EntityState, error)
 }
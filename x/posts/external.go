@@ -12,6 +12,8 @@ import (
 	"github.com/desmos-labs/athena/v2/utils"
 
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/forbole/juno/v5/node/remote"
@@ -33,24 +35,65 @@ func (m *Module) RefreshPostsData(height int64, subspaceID uint64) error {
 		return err
 	}
 
-	err = m.db.DeleteAllPosts(height, subspaceID)
+	if m.tombstones {
+		err = m.tombstoneAllPosts(height, subspaceID)
+	} else {
+		err = m.db.DeleteAllPosts(height, subspaceID)
+	}
 	if err != nil {
 		return fmt.Errorf("error while deleting subspace posts: %s", err)
 	}
 
-	// Refresh posts
+	// Skip the posts for which the conversation or the references are not going to be stored
+	stored := map[uint64]bool{}
+	var storablePosts []types.Post
 	for _, post := range posts {
-		// Get the post transactions
-		postTxs := m.GetPostTxHashes(postsTxs, post)
+		if post.ConversationID != 0 && !stored[post.ConversationID] {
+			continue
+		}
+
+		if !hasAllReferences(post, stored) {
+			continue
+		}
 
-		// Refresh the post data
-		err = m.RefreshPostData(height, postTxs, post)
+		stored[post.ID] = true
+		storablePosts = append(storablePosts, post)
+	}
+
+	log.Debug().Uint64("subspace", subspaceID).Int("posts", len(storablePosts)).Msg("refreshing posts")
+
+	// Store all the posts in bulk
+	err = m.db.SavePosts(storablePosts)
+	if err != nil {
+		return fmt.Errorf("error while saving posts: %s", err)
+	}
+
+	// Refresh the posts related data in bulk
+	var txs []types.PostTransaction
+	var attachments []types.PostAttachment
+	var answers []types.PollAnswer
+	for _, post := range storablePosts {
+		postAttachments, postAnswers, err := m.queryPostRelatedData(height, post)
 		if err != nil {
 			return err
 		}
+
+		txs = append(txs, m.GetPostTxHashes(postsTxs, post)...)
+		attachments = append(attachments, postAttachments...)
+		answers = append(answers, postAnswers...)
 	}
 
-	return nil
+	return savePostsRelatedData(m.db, txs, attachments, answers)
+}
+
+// hasAllReferences tells whether all the posts referenced by the given post are contained inside the given set
+func hasAllReferences(post types.Post, posts map[uint64]bool) bool {
+	for _, reference := range post.ReferencedPosts {
+		if !posts[reference.PostID] {
+			return false
+		}
+	}
+	return true
 }
 
 // RefreshPostData refreshes the data associated with the given post at the specified height
@@ -88,46 +131,140 @@ func (m *Module) RefreshPostData(height int64, postTxs []types.PostTransaction,
 		return fmt.Errorf("error while saving post: %s", err)
 	}
 
-	// Refresh transactions
-	for _, tx := range postTxs {
-		err = m.db.SavePostTx(tx)
+	return m.refreshPostRelatedData(height, postTxs, post)
+}
+
+// RefreshPost refreshes the given post along with its transactions, attachments and poll answers.
+// If the post does not exist on chain anymore, it is removed from the database.
+func (m *Module) RefreshPost(height int64, subspaceID uint64, postID uint64) error {
+	post, err := m.GetPost(height, subspaceID, postID)
+	if status.Code(err) == codes.NotFound {
+		log.Debug().Uint64("subspace", subspaceID).Uint64("post", postID).Msg("removing deleted post")
+		return m.deletePost(height, subspaceID, postID)
+	}
+	if err != nil {
+		return fmt.Errorf("error while getting post: %s", err)
+	}
+
+	postTxs, err := m.QueryPostTxs(height, subspaceID, postID)
+	if err != nil {
+		return err
+	}
+
+	return m.storePost(height, m.GetPostTxHashes(postTxs, post), post)
+}
+
+// storePost stores the given post along with its transactions, attachments and poll answers inside a single
+// database transaction, replacing its stored attachments. Nothing is stored, and an error is returned,
+// if its conversation or referenced posts are missing
+func (m *Module) storePost(height int64, postTxs []types.PostTransaction, post types.Post) error {
+	attachments, answers, err := m.queryPostRelatedData(height, post)
+	if err != nil {
+		return err
+	}
+
+	log.Debug().Uint64("subspace", post.SubspaceID).Uint64("post", post.ID).Msg("refreshing post")
+
+	return m.db.InPostsTransaction(func(db Database) error {
+		err := checkPostReferences(db, height, post)
+		if err != nil {
+			return err
+		}
+
+		// Remove the stored attachments, so that the ones that have been deleted on chain are not kept
+		err = db.DeletePostAttachments(height, post.SubspaceID, post.ID)
+		if err != nil {
+			return fmt.Errorf("error while deleting post attachments: %s", err)
+		}
+
+		err = db.SavePost(post)
 		if err != nil {
-			return fmt.Errorf("error while saving post transaction: %s", tx.Hash)
+			return fmt.Errorf("error while saving post: %s", err)
 		}
+
+		return savePostsRelatedData(db, postTxs, attachments, answers)
+	})
+}
+
+// checkPostReferences returns an error if the conversation or any of the posts referenced by the given post
+// are not stored inside the given database
+func checkPostReferences(db Database, height int64, post types.Post) error {
+	postIDs := make([]uint64, 0, len(post.ReferencedPosts)+1)
+	if post.ConversationID != 0 {
+		postIDs = append(postIDs, post.ConversationID)
+	}
+	for _, reference := range post.ReferencedPosts {
+		postIDs = append(postIDs, reference.PostID)
 	}
 
-	attachments, err := m.QueryPostAttachments(height, post.SubspaceID, post.ID)
+	for _, postID := range postIDs {
+		exists, err := db.HasPost(height, post.SubspaceID, postID)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("post %d cannot be stored since its conversation or referenced post %d is missing: "+
+				"please refresh it first", post.ID, postID)
+		}
+	}
+
+	return nil
+}
+
+// refreshPostRelatedData refreshes the transactions, attachments and poll answers of the given post
+func (m *Module) refreshPostRelatedData(height int64, postTxs []types.PostTransaction, post types.Post) error {
+	attachments, answers, err := m.queryPostRelatedData(height, post)
 	if err != nil {
 		return err
 	}
 
-	// Refresh attachments
+	return savePostsRelatedData(m.db, postTxs, attachments, answers)
+}
+
+// queryPostRelatedData queries the attachments and poll answers of the given post
+func (m *Module) queryPostRelatedData(height int64, post types.Post) ([]types.PostAttachment, []types.PollAnswer, error) {
+	attachments, err := m.QueryPostAttachments(height, post.SubspaceID, post.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var answers []types.PollAnswer
 	for _, attachment := range attachments {
+		if _, isPoll := attachment.Content.GetCachedValue().(*poststypes.Poll); !isPoll {
+			continue
+		}
+
 		log.Debug().Uint64("subspace", attachment.SubspaceID).Uint64("post", attachment.PostID).
-			Uint32("attachment", attachment.ID).Msg("refreshing attachment")
+			Uint32("poll", attachment.ID).Msg("refreshing poll answers")
 
-		err = m.db.SavePostAttachment(attachment)
+		pollAnswers, err := m.queryPollAnswers(height, attachment.SubspaceID, attachment.PostID, attachment.ID)
 		if err != nil {
-			return fmt.Errorf("error while saving post attachment: %s", err)
+			return nil, nil, err
 		}
+		answers = append(answers, pollAnswers...)
+	}
 
-		// Refresh poll answers
-		if _, isPoll := attachment.Content.GetCachedValue().(*poststypes.Poll); isPoll {
-			log.Debug().Uint64("subspace", attachment.SubspaceID).Uint64("post", attachment.PostID).
-				Uint32("poll", attachment.ID).Msg("refreshing poll answers")
+	return attachments, answers, nil
+}
 
-			answers, err := m.queryPollAnswers(height, attachment.SubspaceID, attachment.PostID, attachment.ID)
-			if err != nil {
-				return err
-			}
+// savePostsRelatedData stores the given transactions, attachments and poll answers in bulk inside the given database
+func savePostsRelatedData(
+	db Database, txs []types.PostTransaction, attachments []types.PostAttachment, answers []types.PollAnswer,
+) error {
+	err := db.SavePostTxs(txs)
+	if err != nil {
+		return fmt.Errorf("error while saving post transactions: %s", err)
+	}
 
-			for _, answer := range answers {
-				err = m.db.SavePollAnswer(answer)
-				if err != nil {
-					return fmt.Errorf("errror while saving poll answer: %s", err)
-				}
-			}
-		}
+	err = db.SavePostAttachments(attachments)
+	if err != nil {
+		return fmt.Errorf("error while saving post attachments: %s", err)
+	}
+
+	err = db.SavePollAnswers(answers)
+	if err != nil {
+		return fmt.Errorf("error while saving poll answers: %s", err)
 	}
 
 	return nil
@@ -214,6 +351,39 @@ func (m *Module) QueryPostsTxs(height int64, subspaceID uint64) ([]*coretypes.Re
 	return txs, nil
 }
 
+// QueryPostTxs queries all the transactions related to the given post
+func (m *Module) QueryPostTxs(height int64, subspaceID uint64, postID uint64) ([]*coretypes.ResultTx, error) {
+	var txs []*coretypes.ResultTx
+	for _, eventType := range []string{
+		poststypes.EventTypeCreatedPost,
+		poststypes.EventTypeEditedPost,
+		poststypes.EventTypeAddedPostAttachment,
+		poststypes.EventTypeRemovedPostAttachment,
+	} {
+		qry := fmt.Sprintf("%[1]s.%[2]s='%[3]d' AND %[1]s.%[4]s='%[5]d' AND tx.height <= %[6]d",
+			eventType,
+			subspacestypes.AttributeKeySubspaceID,
+			subspaceID,
+			poststypes.AttributeKeyPostID,
+			postID,
+			height,
+		)
+
+		resultTxs, err := utils.QueryTxs(m.node, qry)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, resultTxs...)
+	}
+
+	// Sort the txs based on their ascending height
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Height < txs[j].Height
+	})
+
+	return txs, nil
+}
+
 // GetPostTxHashes filters the given transactions and returns only the ones related to the given post
 func (m *Module) GetPostTxHashes(txs []*coretypes.ResultTx, post types.Post) []types.PostTransaction {
 	var postTxs []types.PostTransaction
@@ -295,3 +465,26 @@ func (m *Module) queryPollAnswers(height int64, subspaceID uint64, postID uint64
 
 	return answers, nil
 }
+
+// deletePost removes the given post from the database, or marks it as deleted at the time of the block having the
+// given height if the tombstones are enabled
+func (m *Module) deletePost(height int64, subspaceID uint64, postID uint64) error {
+	if !m.tombstones {
+		return m.db.DeletePost(height, subspaceID, postID)
+	}
+
+	timestamp, err := utils.GetBlockTime(m.node, height)
+	if err != nil {
+		return err
+	}
+	return m.db.TombstonePost(height, subspaceID, postID, "", timestamp)
+}
+
+// tombstoneAllPosts marks all the posts of the given subspace as deleted at the time of the block having the given height
+func (m *Module) tombstoneAllPosts(height int64, subspaceID uint64) error {
+	timestamp, err := utils.GetBlockTime(m.node, height)
+	if err != nil {
+		return err
+	}
+	return m.db.TombstoneAllPosts(height, subspaceID, timestamp)
+}
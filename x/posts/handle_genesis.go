@@ -16,27 +16,36 @@ func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, appState map[string]json
 	m.cdc.MustUnmarshalJSON(appState[poststypes.ModuleName], &genState)
 
 	// Save posts
-	for _, post := range genState.Posts {
-		err := m.db.SavePost(types.NewPost(post, doc.InitialHeight))
-		if err != nil {
-			return err
-		}
+	posts := make([]types.Post, len(genState.Posts))
+	for i, post := range genState.Posts {
+		posts[i] = types.NewPost(post, doc.InitialHeight)
+	}
+
+	err := m.db.SavePosts(posts)
+	if err != nil {
+		return err
 	}
 
 	// Save attachments
-	for _, attachment := range genState.Attachments {
-		err := m.db.SavePostAttachment(types.NewPostAttachment(attachment, doc.InitialHeight))
-		if err != nil {
-			return err
-		}
+	attachments := make([]types.PostAttachment, len(genState.Attachments))
+	for i, attachment := range genState.Attachments {
+		attachments[i] = types.NewPostAttachment(attachment, doc.InitialHeight)
+	}
+
+	err = m.db.SavePostAttachments(attachments)
+	if err != nil {
+		return err
 	}
 
 	// Save poll answers
-	for _, answer := range genState.UserAnswers {
-		err := m.db.SavePollAnswer(types.NewPollAnswer(answer, doc.InitialHeight))
-		if err != nil {
-			return err
-		}
+	answers := make([]types.PollAnswer, len(genState.UserAnswers))
+	for i, answer := range genState.UserAnswers {
+		answers[i] = types.NewPollAnswer(answer, doc.InitialHeight)
+	}
+
+	err = m.db.SavePollAnswers(answers)
+	if err != nil {
+		return err
 	}
 
 	return m.db.SavePostsParams(types.NewPostsParams(genState.Params, doc.InitialHeight))
@@ -1,6 +1,8 @@
 package posts
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/x/authz"
 
 	"github.com/desmos-labs/athena/v2/x/filters"
@@ -8,6 +10,7 @@ import (
 	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
 
 	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/utils"
 
 	"github.com/rs/zerolog/log"
 
@@ -67,61 +70,102 @@ func (m *Module) handleMsgCreatePost(tx *juno.Tx, index int, msg *poststypes.Msg
 		return err
 	}
 
-	// Update the post
-	err = m.updatePost(tx.Height, msg.SubspaceID, postID)
+	post, err := m.GetPost(tx.Height, msg.SubspaceID, postID)
 	if err != nil {
 		return err
 	}
 
-	// Update the post attachments
-	err = m.updatePostAttachments(tx.Height, msg.SubspaceID, postID)
+	attachments, err := m.GetPostAttachments(tx.Height, msg.SubspaceID, postID)
 	if err != nil {
-		return err
+		return fmt.Errorf("error while getting post attachments: %s", err)
 	}
 
-	// Save the related transaction
-	return m.db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, postID, tx.TxHash))
+	return m.db.InPostsTransaction(func(db Database) error {
+		// Update the post
+		err := db.SavePost(post)
+		if err != nil {
+			return err
+		}
+
+		// Update the post attachments
+		err = db.SavePostAttachments(attachments)
+		if err != nil {
+			return err
+		}
+
+		// Save the related transaction
+		return db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, postID, tx.TxHash))
+	})
 }
 
 // handleMsgEditPost handles a MsgEditPost
 func (m *Module) handleMsgEditPost(tx *juno.Tx, msg *poststypes.MsgEditPost) error {
-	// Update the post
-	err := m.updatePost(tx.Height, msg.SubspaceID, msg.PostID)
+	post, err := m.GetPost(tx.Height, msg.SubspaceID, msg.PostID)
 	if err != nil {
 		return err
 	}
 
-	// Save the related transaction
-	return m.db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, msg.PostID, tx.TxHash))
+	return m.db.InPostsTransaction(func(db Database) error {
+		// Store the previous content before updating the post
+		err := savePostRevision(db, post, tx.TxHash)
+		if err != nil {
+			return err
+		}
+
+		// Update the post
+		err = db.SavePost(post)
+		if err != nil {
+			return err
+		}
+
+		// Save the related transaction
+		return db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, msg.PostID, tx.TxHash))
+	})
 }
 
 // handleMsgDeletePost handles a MsgDeletePost
 func (m *Module) handleMsgDeletePost(tx *juno.Tx, msg *poststypes.MsgDeletePost) error {
+	if m.tombstones {
+		timestamp, err := utils.GetTxTimestamp(tx)
+		if err != nil {
+			return err
+		}
+		return m.db.TombstonePost(tx.Height, msg.SubspaceID, msg.PostID, tx.TxHash, timestamp)
+	}
 	return m.db.DeletePost(tx.Height, msg.SubspaceID, msg.PostID)
 }
 
 // handleMsgAddPostAttachment handles a MsgAddPostAttachment
 func (m *Module) handleMsgAddPostAttachment(tx *juno.Tx, msg *poststypes.MsgAddPostAttachment) error {
-	// Update the attachments
-	err := m.updatePostAttachments(tx.Height, msg.SubspaceID, msg.PostID)
+	attachments, err := m.GetPostAttachments(tx.Height, msg.SubspaceID, msg.PostID)
 	if err != nil {
-		return err
+		return fmt.Errorf("error while getting post attachments: %s", err)
 	}
 
-	// Store the related post transaction
-	return m.db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, msg.PostID, tx.TxHash))
+	return m.db.InPostsTransaction(func(db Database) error {
+		// Update the attachments
+		err := db.SavePostAttachments(attachments)
+		if err != nil {
+			return err
+		}
+
+		// Store the related post transaction
+		return db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, msg.PostID, tx.TxHash))
+	})
 }
 
 // handleMsgRemovePostAttachment handles a MsgRemovePostAttachment
 func (m *Module) handleMsgRemovePostAttachment(tx *juno.Tx, msg *poststypes.MsgRemovePostAttachment) error {
-	// Delete the attachment
-	err := m.db.DeletePostAttachment(tx.Height, msg.SubspaceID, msg.PostID, msg.AttachmentID)
-	if err != nil {
-		return err
-	}
-
-	// Store the related post transaction
-	return m.db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, msg.PostID, tx.TxHash))
+	return m.db.InPostsTransaction(func(db Database) error {
+		// Delete the attachment
+		err := db.DeletePostAttachment(tx.Height, msg.SubspaceID, msg.PostID, msg.AttachmentID)
+		if err != nil {
+			return err
+		}
+
+		// Store the related post transaction
+		return db.SavePostTx(types.NewPostTransaction(msg.SubspaceID, msg.PostID, tx.TxHash))
+	})
 }
 
 // handleMsgAnswerPoll handles a MsgAnswerPoll
@@ -20,6 +20,22 @@ func (m *Module) updatePost(height int64, subspaceID uint64, postID uint64) erro
 	return m.db.SavePost(post)
 }
 
+// savePostRevision stores the currently stored content of the given post as a revision replaced by the edit
+// performed with the given transaction. Nothing is stored if the edit did not change the content, or if the stored
+// content is more recent than the edit.
+func savePostRevision(db Database, post types.Post, txHash string) error {
+	content, err := db.GetPostContent(post.SubspaceID, post.ID)
+	if err != nil {
+		return fmt.Errorf("error while getting post content: %s", err)
+	}
+
+	if content == nil || content.Height > post.Height || content.Equal(post.Post) {
+		return nil
+	}
+
+	return db.SavePostRevision(types.NewPostRevision(post.SubspaceID, post.ID, *content, post.Height, txHash))
+}
+
 // GetPost gets the given post from the chain
 func (m *Module) GetPost(height int64, subspaceID uint64, postID uint64) (types.Post, error) {
 	res, err := m.client.Post(
@@ -33,23 +49,7 @@ func (m *Module) GetPost(height int64, subspaceID uint64, postID uint64) (types.
 	return types.NewPost(res.Post, height), nil
 }
 
-// updatePostAttachments updates the stored attachments for the post having the given id
-func (m *Module) updatePostAttachments(height int64, subspaceID uint64, postID uint64) error {
-	attachments, err := m.GetPostAttachments(height, subspaceID, postID)
-	if err != nil {
-		return fmt.Errorf("error while getting post attachments: %s", err)
-	}
-
-	for _, attachment := range attachments {
-		err = m.db.SavePostAttachment(attachment)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
+// GetPostAttachments gets the attachments of the given post from the chain
 func (m *Module) GetPostAttachments(height int64, subspaceID uint64, postID uint64) ([]types.PostAttachment, error) {
 	var attachments []types.PostAttachment
 	var nextKey []byte
@@ -0,0 +1,57 @@
+package posts
+
+import (
+	"fmt"
+
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// Verify compares the posts of the given subspace stored inside the database with the chain state at the given
+// height, returning the report of the drifted posts
+func (m *Module) Verify(height int64, subspaceID uint64) (*types.VerificationReport, error) {
+	report := types.NewVerificationReport(m.Name(), subspaceID, height)
+
+	posts, err := m.QuerySubspacePosts(height, subspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying posts: %s", err)
+	}
+
+	storedPosts, err := m.db.GetPostsState(subspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting stored posts: %s", err)
+	}
+
+	chainPosts := map[string]types.Post{}
+	var chainStates []types.EntityState
+	for _, post := range posts {
+		key := types.EntityKey(post.SubspaceID, post.ID)
+		chainPosts[key] = post
+		chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+			"section_id":      fmt.Sprint(post.SectionID),
+			"external_id":     post.ExternalID,
+			"text":            post.Text,
+			"author":          post.Author,
+			"owner":           post.Owner,
+			"conversation_id": fmt.Sprint(post.ConversationID),
+			"reply_settings":  post.ReplySettings.String(),
+		}))
+	}
+
+	report.Compare("post", chainStates, storedPosts, types.NewEntityFixer(
+		func(key string) error {
+			return m.storePost(height, nil, chainPosts[key])
+		},
+		func(key string) error {
+			postID, err := poststypes.ParsePostID(types.SplitEntityKey(key)[1])
+			if err != nil {
+				return err
+			}
+
+			return m.deletePost(height, subspaceID, postID)
+		},
+	))
+
+	return report, nil
+}
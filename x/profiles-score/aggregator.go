@@ -0,0 +1,94 @@
+package profilesscore
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// RefreshProfilesScores computes the aggregated score of all the stored profiles and stores it inside the database
+func (m *Module) RefreshProfilesScores() error {
+	data, err := m.db.GetProfilesScoreData()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, profileData := range data {
+		err = m.db.SaveProfileScore(m.aggregatorCfg.ComputeScore(profileData, now))
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Debug().Str("module", "profiles score").Int("profiles", len(data)).Msg("refreshed profiles scores")
+	return nil
+}
+
+// ComputeScore returns the aggregated score of the profile having the given data at the given time.
+// Each component score ranges from 0 to 100, and the profile score is their weighted average
+func (c *AggregatorConfig) ComputeScore(data types.ProfileScoreData, now time.Time) types.AggregatedProfileScore {
+	applicationsScore := c.computeApplicationsScore(data.ApplicationsScores)
+	chainLinksScore := ratioScore(float64(data.ChainLinksCount), float64(c.MaxChainLinks))
+
+	var chainLinksAgeScore uint64
+	if data.OldestChainLinkTime != nil {
+		chainLinksAgeScore = ratioScore(float64(now.Sub(*data.OldestChainLinkTime)), float64(c.MaxChainLinksAge))
+	}
+
+	profileAgeScore := ratioScore(float64(now.Sub(data.CreationTime)), float64(c.MaxProfileAge))
+	activityScore := ratioScore(float64(data.ActivityCount), float64(c.MaxActivity))
+
+	weightedSum := c.Weights.Applications*float64(applicationsScore) +
+		c.Weights.ChainLinks*float64(chainLinksScore) +
+		c.Weights.ChainLinksAge*float64(chainLinksAgeScore) +
+		c.Weights.ProfileAge*float64(profileAgeScore) +
+		c.Weights.Activity*float64(activityScore)
+	totalWeight := c.Weights.Applications + c.Weights.ChainLinks + c.Weights.ChainLinksAge +
+		c.Weights.ProfileAge + c.Weights.Activity
+
+	var score uint64
+	if totalWeight > 0 {
+		score = uint64(math.Round(weightedSum / totalWeight))
+	}
+
+	return types.AggregatedProfileScore{
+		DesmosAddress:      data.DesmosAddress,
+		Score:              score,
+		ApplicationsScore:  applicationsScore,
+		ChainLinksScore:    chainLinksScore,
+		ChainLinksAgeScore: chainLinksAgeScore,
+		ProfileAgeScore:    profileAgeScore,
+		ActivityScore:      activityScore,
+		Timestamp:          now,
+	}
+}
+
+// computeApplicationsScore returns the weighted sum of the given applications scores, divided by the sum of all the
+// configured applications weights. This way, a profile reaches the maximum score only when it has linked all
+// the weighted applications, and each of them has the maximum score
+func (c *AggregatorConfig) computeApplicationsScore(applicationsScores map[string]uint64) uint64 {
+	var weightedSum, totalWeight float64
+	for application, weight := range c.ApplicationsWeights {
+		totalWeight += weight
+		weightedSum += weight * math.Min(float64(applicationsScores[strings.ToLower(application)]), 100)
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+	return uint64(math.Round(weightedSum / totalWeight))
+}
+
+// ratioScore returns a score from 0 to 100 proportional to the ratio between the given value and the value
+// for which the maximum score is reached
+func ratioScore(value float64, maxValue float64) uint64 {
+	if value <= 0 || maxValue <= 0 {
+		return 0
+	}
+	return uint64(math.Round(math.Min(value/maxValue, 1) * 100))
+}
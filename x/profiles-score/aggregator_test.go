@@ -0,0 +1,141 @@
+package profilesscore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/types"
+	profilesscore "github.com/desmos-labs/athena/v2/x/profiles-score"
+)
+
+func TestAggregatorConfig_ComputeScore(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := &profilesscore.AggregatorConfig{
+		Weights: profilesscore.ScoreWeights{
+			Applications:  4,
+			ChainLinks:    1,
+			ChainLinksAge: 1,
+			ProfileAge:    2,
+			Activity:      2,
+		},
+		ApplicationsWeights: map[string]float64{
+			"github":  3,
+			"twitter": 1,
+		},
+		MaxChainLinks:    4,
+		MaxChainLinksAge: 100 * time.Hour,
+		MaxProfileAge:    200 * time.Hour,
+		MaxActivity:      10,
+		RefreshInterval:  time.Hour,
+	}
+
+	testCases := []struct {
+		name     string
+		data     types.ProfileScoreData
+		expected types.AggregatedProfileScore
+	}{
+		{
+			name: "empty profile returns zero",
+			data: types.NewProfileScoreData("user", now, nil, 0, nil, 0),
+			expected: types.AggregatedProfileScore{
+				DesmosAddress: "user",
+				Timestamp:     now,
+			},
+		},
+		{
+			name: "components are computed and weighted properly",
+			data: types.NewProfileScoreData(
+				"user",
+				now.Add(-100*time.Hour),
+				map[string]uint64{"github": 80, "twitter": 40, "reddit": 100},
+				2,
+				timePtr(now.Add(-25*time.Hour)),
+				5,
+			),
+			expected: types.AggregatedProfileScore{
+				DesmosAddress:      "user",
+				Score:              56,
+				ApplicationsScore:  70,
+				ChainLinksScore:    50,
+				ChainLinksAgeScore: 25,
+				ProfileAgeScore:    50,
+				ActivityScore:      50,
+				Timestamp:          now,
+			},
+		},
+		{
+			name: "components are capped to the maximum score",
+			data: types.NewProfileScoreData(
+				"user",
+				now.Add(-1000*time.Hour),
+				map[string]uint64{"github": 100, "twitter": 100},
+				10,
+				timePtr(now.Add(-1000*time.Hour)),
+				50,
+			),
+			expected: types.AggregatedProfileScore{
+				DesmosAddress:      "user",
+				Score:              100,
+				ApplicationsScore:  100,
+				ChainLinksScore:    100,
+				ChainLinksAgeScore: 100,
+				ProfileAgeScore:    100,
+				ActivityScore:      100,
+				Timestamp:          now,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, cfg.ComputeScore(tc.data, now))
+		})
+	}
+}
+
+func TestParseAggregatorConfig(t *testing.T) {
+	cfg, err := profilesscore.ParseAggregatorConfig([]byte(`
+profile_score:
+  weights:
+    activity: 0
+  applications_weights:
+    github: 2
+    twitter: 0.5
+  max_activity: 50
+  refresh_interval: 6h
+`))
+	require.NoError(t, err)
+
+	defaultCfg := profilesscore.DefaultAggregatorConfig()
+	require.Equal(t, float64(0), cfg.Weights.Activity)
+	require.Equal(t, defaultCfg.Weights.Applications, cfg.Weights.Applications)
+	require.Equal(t, map[string]float64{"github": 2, "twitter": 0.5}, cfg.ApplicationsWeights)
+	require.Equal(t, uint64(50), cfg.MaxActivity)
+	require.Equal(t, defaultCfg.MaxProfileAge, cfg.MaxProfileAge)
+	require.Equal(t, 6*time.Hour, cfg.RefreshInterval)
+
+	cfg, err = profilesscore.ParseAggregatorConfig([]byte(`
+profile_score:
+  max_activity: 10
+`))
+	require.NoError(t, err)
+	require.Equal(t, defaultCfg.ApplicationsWeights, cfg.ApplicationsWeights)
+
+	cfg, err = profilesscore.ParseAggregatorConfig([]byte(`chain: {}`))
+	require.NoError(t, err)
+	require.Equal(t, profilesscore.DefaultAggregatorConfig(), cfg)
+
+	_, err = profilesscore.ParseAggregatorConfig([]byte(`
+profile_score:
+  weights:
+    applications: -1
+`))
+	require.Error(t, err)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
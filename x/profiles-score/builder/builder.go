@@ -13,11 +13,26 @@ import (
 )
 
 func BuildModule(junoCfg config.Config, db profilesscore.Database) *profilesscore.Module {
+	cfgBz, err := junoCfg.GetBytes()
+	if err != nil {
+		panic(err)
+	}
+
+	refreshIntervals, err := profilesscore.ParseRefreshIntervals(cfgBz)
+	if err != nil {
+		panic(err)
+	}
+
+	aggregatorCfg, err := profilesscore.ParseAggregatorConfig(cfgBz)
+	if err != nil {
+		panic(err)
+	}
+
 	return profilesscore.NewModule([]profilesscore.Scorer{
 		domain.NewScorer(),
 		github.NewScorer(junoCfg),
 		twitch.NewScorer(junoCfg),
 		twitter.NewScorer(junoCfg),
 		youtube.NewScorer(junoCfg),
-	}, db)
+	}, refreshIntervals, aggregatorCfg, db)
 }
@@ -0,0 +1,163 @@
+package profilesscore
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AggregatorConfig contains the configuration used to compute the aggregated score of each profile
+type AggregatorConfig struct {
+	// Weights contains the weight of each component inside the profile score
+	Weights ScoreWeights `yaml:"weights"`
+
+	// ApplicationsWeights contains the weight of each application score inside the applications component.
+	// Applications that are not listed, or have a zero weight, are ignored
+	ApplicationsWeights map[string]float64 `yaml:"applications_weights"`
+
+	// MaxChainLinks is the number of chain links for which the chain links component reaches its maximum score
+	MaxChainLinks uint64 `yaml:"max_chain_links"`
+
+	// MaxChainLinksAge is the age of the oldest chain link for which the chain links age component reaches its
+	// maximum score
+	MaxChainLinksAge time.Duration `yaml:"max_chain_links_age"`
+
+	// MaxProfileAge is the age of the profile for which the profile age component reaches its maximum score
+	MaxProfileAge time.Duration `yaml:"max_profile_age"`
+
+	// MaxActivity is the number of posts and reactions for which the activity component reaches its maximum score
+	MaxActivity uint64 `yaml:"max_activity"`
+
+	// RefreshInterval is the interval between two refreshes of the profiles scores
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+}
+
+// ScoreWeights contains the weight of each component of the profile score
+type ScoreWeights struct {
+	Applications  float64 `yaml:"applications"`
+	ChainLinks    float64 `yaml:"chain_links"`
+	ChainLinksAge float64 `yaml:"chain_links_age"`
+	ProfileAge    float64 `yaml:"profile_age"`
+	Activity      float64 `yaml:"activity"`
+}
+
+// DefaultAggregatorConfig returns the configuration used when no profile score configuration is provided
+func DefaultAggregatorConfig() *AggregatorConfig {
+	return &AggregatorConfig{
+		Weights: ScoreWeights{
+			Applications:  0.4,
+			ChainLinks:    0.15,
+			ChainLinksAge: 0.1,
+			ProfileAge:    0.15,
+			Activity:      0.2,
+		},
+		ApplicationsWeights: map[string]float64{
+			"domain":  1,
+			"github":  1,
+			"twitch":  1,
+			"twitter": 1,
+			"youtube": 1,
+		},
+		MaxChainLinks:    3,
+		MaxChainLinksAge: 365 * 24 * time.Hour,
+		MaxProfileAge:    2 * 365 * 24 * time.Hour,
+		MaxActivity:      100,
+		RefreshInterval:  DefaultRefreshInterval,
+	}
+}
+
+// Validate returns an error if the configuration is not valid
+func (c *AggregatorConfig) Validate() error {
+	weights := []float64{
+		c.Weights.Applications, c.Weights.ChainLinks, c.Weights.ChainLinksAge, c.Weights.ProfileAge, c.Weights.Activity,
+	}
+
+	var totalWeight float64
+	for _, weight := range weights {
+		if weight < 0 {
+			return fmt.Errorf("invalid profile score weight: %f", weight)
+		}
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return fmt.Errorf("at least one profile score weight must be greater than zero")
+	}
+
+	for application, weight := range c.ApplicationsWeights {
+		if weight < 0 {
+			return fmt.Errorf("invalid %s application weight: %f", application, weight)
+		}
+	}
+
+	if c.MaxChainLinks == 0 || c.MaxChainLinksAge <= 0 || c.MaxProfileAge <= 0 || c.MaxActivity == 0 {
+		return fmt.Errorf("profile score maximum values must be greater than zero")
+	}
+
+	if c.RefreshInterval <= 0 {
+		return fmt.Errorf("invalid profile score refresh interval: %s", c.RefreshInterval)
+	}
+
+	return nil
+}
+
+// ParseAggregatorConfig reads the profile score configuration from the profile_score section of the given config.
+// The values that are not set are taken from the default configuration, except for the applications weights that
+// replace the default ones when set
+func ParseAggregatorConfig(bz []byte) (*AggregatorConfig, error) {
+	type T struct {
+		Config *AggregatorConfig `yaml:"profile_score"`
+	}
+	cfg := T{Config: DefaultAggregatorConfig()}
+
+	// Unmarshalling a map merges it into the existing one, so the default applications weights are only set later
+	cfg.Config.ApplicationsWeights = nil
+
+	err := yaml.Unmarshal(bz, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Config == nil {
+		return DefaultAggregatorConfig(), nil
+	}
+
+	if cfg.Config.ApplicationsWeights == nil {
+		cfg.Config.ApplicationsWeights = DefaultAggregatorConfig().ApplicationsWeights
+	}
+
+	return cfg.Config, cfg.Config.Validate()
+}
+
+// ParseRefreshIntervals reads the refresh_interval field of each scorer from the scorers section of the given config,
+// returning the intervals keyed by application. The scorers without a refresh interval are not included
+func ParseRefreshIntervals(bz []byte) (map[string]time.Duration, error) {
+	type scorerConfig struct {
+		RefreshInterval time.Duration `yaml:"refresh_interval"`
+	}
+
+	type T struct {
+		Scorers map[string]*scorerConfig `yaml:"scorers"`
+	}
+
+	var cfg T
+	err := yaml.Unmarshal(bz, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshIntervals := map[string]time.Duration{}
+	for application, scorerCfg := range cfg.Scorers {
+		if scorerCfg == nil || scorerCfg.RefreshInterval == 0 {
+			continue
+		}
+
+		if scorerCfg.RefreshInterval < 0 {
+			return nil, fmt.Errorf("invalid %s scorer refresh interval: %s", application, scorerCfg.RefreshInterval)
+		}
+		refreshIntervals[application] = scorerCfg.RefreshInterval
+	}
+
+	return refreshIntervals, nil
+}
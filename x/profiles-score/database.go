@@ -8,4 +8,6 @@ import (
 type Database interface {
 	profiles.Database
 	SaveApplicationLinkScore(score *types.ProfileScore) error
+	GetProfilesScoreData() ([]types.ProfileScoreData, error)
+	SaveProfileScore(score types.AggregatedProfileScore) error
 }
@@ -1,6 +1,11 @@
 package profilesscore
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -8,76 +13,71 @@ import (
 	"github.com/desmos-labs/athena/v2/types"
 )
 
-// RefreshApplicationLinksScores reads all the applications links stored inside the database and refreshes their scores
+// RefreshApplicationLinksScores reads all the applications links stored inside the database and refreshes their scores.
+// Each scorer runs on its own goroutine, respecting its own rate limit, and only scores the links of its application
 func (m *Module) RefreshApplicationLinksScores() error {
 	applicationLinks, err := m.db.GetApplicationLinkInfos()
 	if err != nil {
 		return err
 	}
-	if applicationLinks == nil {
-		return nil
-	}
 
-	// Split the application links based on the scores rate limit
-	scorers := m.GetScorers()
-	chunks, waitTime := getApplicationLinksChunksAndWaitTime(applicationLinks, scorers.GetRateLimit())
-	for i, chunk := range chunks {
-		for _, link := range chunk {
-			for _, scorer := range scorers {
-				// Get the score details for this link
-				details, err := scorer.GetScoreDetails(link.User, link.Application, link.Username)
-				if err != nil {
-					log.Error().Err(err).Str("user", link.User).Str("application", link.Application).
-						Str("username", link.Username).Msg("error while getting score details")
-					continue
-				}
-				if details == nil {
-					continue
-				}
+	var wg sync.WaitGroup
+	errs := make([]error, len(m.scorers))
+	for i, scorer := range m.scorers {
+		wg.Add(1)
+		go func(i int, scorer *rateLimitedScorer) {
+			defer wg.Done()
+			errs[i] = m.refreshScores(scorer, applicationLinks)
+		}(i, scorer)
+	}
+	wg.Wait()
 
-				// Save the score
-				score := types.NewApplicationLinkScore(link.User, link.Application, link.Username, details, time.Now())
-				err = m.db.SaveApplicationLinkScore(score)
-				if err != nil {
-					return err
-				}
-			}
-		}
+	return errors.Join(errs...)
+}
 
-		if i > 0 && i < len(chunk)-1 {
-			// Wait before processing the next chink
-			time.Sleep(waitTime)
-		}
+// refreshScorerApplicationLinksScores reads all the applications links stored inside the database and refreshes the
+// scores of the ones associated to the application of the given scorer
+func (m *Module) refreshScorerApplicationLinksScores(scorer *rateLimitedScorer) error {
+	applicationLinks, err := m.db.GetApplicationLinkInfos()
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return m.refreshScores(scorer, applicationLinks)
 }
 
-// getApplicationLinksChunksAndWaitTime takes the given application links info slice, and splits them into multiple
-// chunks based on the max amount of requests set inside the given rate limit. It also returns the duration that
-// should be waited between each slice update based on the rate limit given
-func getApplicationLinksChunksAndWaitTime(
-	applicationLinks []types.ApplicationLinkInfo, rateLimit *ScoreRateLimit,
-) ([][]types.ApplicationLinkInfo, time.Duration) {
-	if rateLimit == nil {
-		return [][]types.ApplicationLinkInfo{applicationLinks}, 0
-	}
+// refreshScores refreshes the scores of the given application links that are associated to the application of the
+// given scorer, waiting before each request so that the scorer rate limit is not exceeded
+func (m *Module) refreshScores(scorer *rateLimitedScorer, applicationLinks []types.ApplicationLinkInfo) error {
+	application := scorer.GetApplication()
+	for _, link := range applicationLinks {
+		if !strings.EqualFold(link.Application, application) {
+			continue
+		}
 
-	var chunks [][]types.ApplicationLinkInfo
-	var chunkSize = int(rateLimit.RateLimit)
-	for {
-		if len(applicationLinks) == 0 {
-			break
+		err := scorer.limiter.Wait(context.Background())
+		if err != nil {
+			return fmt.Errorf("error while waiting for %s rate limit: %s", application, err)
 		}
 
-		// Necessary check to avoid slicing beyond slice capacity
-		if len(applicationLinks) < chunkSize {
-			chunkSize = len(applicationLinks)
+		// Get the score details for this link
+		details, err := scorer.GetScoreDetails(link.User, link.Application, link.Username)
+		if err != nil {
+			log.Error().Err(err).Str("user", link.User).Str("application", link.Application).
+				Str("username", link.Username).Msg("error while getting score details")
+			continue
+		}
+		if details == nil {
+			continue
 		}
 
-		chunks = append(chunks, applicationLinks[0:chunkSize])
-		applicationLinks = applicationLinks[chunkSize:]
+		// Save the score
+		score := types.NewApplicationLinkScore(link.User, link.Application, link.Username, details, time.Now())
+		err = m.db.SaveApplicationLinkScore(score)
+		if err != nil {
+			return err
+		}
 	}
 
-	return chunks, rateLimit.Duration
+	return nil
 }
@@ -11,18 +11,40 @@ import (
 func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
 	log.Info().Str("module", "profiles score").Msg("setting up periodic tasks")
 
-	// Update the scores every day
-	if _, err := scheduler.Every(1).Days().StartImmediately().Do(m.updateApplicationLinkScores); err != nil {
+	// Update the scores of each scorer using its own interval. Since the scheduler runs each job on its own
+	// goroutine, slow scorers do not delay the other ones
+	for _, scorer := range m.scorers {
+		_, err := scheduler.Every(scorer.refreshInterval).StartImmediately().SingletonMode().
+			Do(m.updateApplicationLinkScores, scorer)
+		if err != nil {
+			return fmt.Errorf("error while scheduling profiles score peridic operation: %s", err)
+		}
+	}
+
+	// Update the profiles scores using the application links scores computed so far
+	_, err := scheduler.Every(m.aggregatorCfg.RefreshInterval).StartImmediately().SingletonMode().
+		Do(m.updateProfilesScores)
+	if err != nil {
 		return fmt.Errorf("error while scheduling profiles score peridic operation: %s", err)
 	}
 
 	return nil
 }
 
+// updateProfilesScores updates the aggregated score of each of the stored profiles
+func (m *Module) updateProfilesScores() {
+	err := m.RefreshProfilesScores()
+	if err != nil {
+		log.Error().Err(err).Msg("error while refreshing profiles scores")
+	}
+}
+
 // updateApplicationLinkScores updates the score for each of the stored application links
-func (m *Module) updateApplicationLinkScores() {
-	err := m.RefreshApplicationLinksScores()
+// associated to the application of the given scorer
+func (m *Module) updateApplicationLinkScores(scorer *rateLimitedScorer) {
+	err := m.refreshScorerApplicationLinksScores(scorer)
 	if err != nil {
-		log.Error().Err(err).Msg("error while refreshing applications links scores")
+		log.Error().Err(err).Str("application", scorer.GetApplication()).
+			Msg("error while refreshing applications links scores")
 	}
 }
@@ -1,7 +1,10 @@
 package profilesscore
 
 import (
+	"time"
+
 	"github.com/forbole/juno/v5/modules"
+	"golang.org/x/time/rate"
 )
 
 var (
@@ -9,16 +12,40 @@ var (
 	_ modules.PeriodicOperationsModule = &Module{}
 )
 
+const (
+	// DefaultRefreshInterval represents the interval between two refreshes of the scores computed by a scorer
+	// that does not have a refresh interval set
+	DefaultRefreshInterval = 24 * time.Hour
+)
+
 type Module struct {
-	scorers Scorers
-	db      Database
+	scorers       []*rateLimitedScorer
+	aggregatorCfg *AggregatorConfig
+	db            Database
 }
 
-// NewModule returns a new Module instance
-func NewModule(scorers Scorers, db Database) *Module {
+// NewModule returns a new Module instance. The given refresh intervals are keyed by application: the scorers
+// whose application is not present use DefaultRefreshInterval
+func NewModule(
+	scorers Scorers, refreshIntervals map[string]time.Duration, aggregatorCfg *AggregatorConfig, db Database,
+) *Module {
+	var rateLimitedScorers []*rateLimitedScorer
+	for _, scorer := range scorers {
+		if scorer == nil {
+			continue
+		}
+
+		refreshInterval, found := refreshIntervals[scorer.GetApplication()]
+		if !found {
+			refreshInterval = DefaultRefreshInterval
+		}
+		rateLimitedScorers = append(rateLimitedScorers, newRateLimitedScorer(scorer, refreshInterval))
+	}
+
 	return &Module{
-		scorers: scorers,
-		db:      db,
+		scorers:       rateLimitedScorers,
+		aggregatorCfg: aggregatorCfg,
+		db:            db,
 	}
 }
 
@@ -28,11 +55,36 @@ func (m *Module) Name() string {
 }
 
 func (m *Module) GetScorers() Scorers {
-	var scorers Scorers
-	for _, scorer := range m.scorers {
-		if scorer != nil {
-			scorers = append(scorers, scorer)
-		}
+	scorers := make(Scorers, len(m.scorers))
+	for i, scorer := range m.scorers {
+		scorers[i] = scorer.Scorer
 	}
 	return scorers
 }
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// rateLimitedScorer wraps a Scorer along with the token bucket limiter used to respect its rate limit and the
+// interval between two refreshes of its scores. The limiter is shared between all the refreshes of the scorer,
+// so that concurrent refreshes do not exceed it
+type rateLimitedScorer struct {
+	Scorer
+	limiter         *rate.Limiter
+	refreshInterval time.Duration
+}
+
+func newRateLimitedScorer(scorer Scorer, refreshInterval time.Duration) *rateLimitedScorer {
+	return &rateLimitedScorer{
+		Scorer:          scorer,
+		limiter:         newLimiter(scorer.GetRateLimit()),
+		refreshInterval: refreshInterval,
+	}
+}
+
+// newLimiter returns a limiter allowing the requests evenly spaced within the given rate limit
+func newLimiter(rateLimit *ScoreRateLimit) *rate.Limiter {
+	if rateLimit == nil || rateLimit.RateLimit == 0 || rateLimit.Duration <= 0 {
+		return rate.NewLimiter(rate.Inf, 1)
+	}
+	return rate.NewLimiter(rate.Every(rateLimit.Duration/time.Duration(rateLimit.RateLimit)), 1)
+}
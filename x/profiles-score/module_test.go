@@ -0,0 +1,193 @@
+package profilesscore_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/stretchr/testify/require"
+
+	"github.com/desmos-labs/athena/v2/types"
+	profilesscore "github.com/desmos-labs/athena/v2/x/profiles-score"
+)
+
+// mockDatabase returns the given application links and stores the scores in memory
+type mockDatabase struct {
+	profilesscore.Database
+
+	mu            sync.Mutex
+	links         []types.ApplicationLinkInfo
+	scores        []*types.ProfileScore
+	profilesData  []types.ProfileScoreData
+	profileScores []types.AggregatedProfileScore
+}
+
+func (db *mockDatabase) GetApplicationLinkInfos() ([]types.ApplicationLinkInfo, error) {
+	return db.links, nil
+}
+
+func (db *mockDatabase) SaveApplicationLinkScore(score *types.ProfileScore) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.scores = append(db.scores, score)
+	return nil
+}
+
+func (db *mockDatabase) GetProfilesScoreData() ([]types.ProfileScoreData, error) {
+	return db.profilesData, nil
+}
+
+func (db *mockDatabase) SaveProfileScore(score types.AggregatedProfileScore) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.profileScores = append(db.profileScores, score)
+	return nil
+}
+
+func (db *mockDatabase) getProfileScores() []types.AggregatedProfileScore {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]types.AggregatedProfileScore(nil), db.profileScores...)
+}
+
+// mockScoreDetails represents a constant score
+type mockScoreDetails struct{}
+
+func (d mockScoreDetails) GetScore() uint64 {
+	return 100
+}
+
+// mockScorer records the usernames it has been asked to score, along with the time of each request
+type mockScorer struct {
+	application string
+	rateLimit   *profilesscore.ScoreRateLimit
+
+	mu        sync.Mutex
+	usernames []string
+	times     []time.Time
+}
+
+func (s *mockScorer) GetApplication() string {
+	return s.application
+}
+
+func (s *mockScorer) GetRateLimit() *profilesscore.ScoreRateLimit {
+	return s.rateLimit
+}
+
+func (s *mockScorer) GetScoreDetails(_ string, _ string, username string) (types.ProfileScoreDetails, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usernames = append(s.usernames, username)
+	s.times = append(s.times, time.Now())
+	return mockScoreDetails{}, nil
+}
+
+func (s *mockScorer) getUsernames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.usernames...)
+}
+
+func TestModule_RefreshApplicationLinksScores(t *testing.T) {
+	db := &mockDatabase{links: []types.ApplicationLinkInfo{
+		types.NewApplicationInfo("user-1", "github", "github-1"),
+		types.NewApplicationInfo("user-1", "twitter", "twitter-1"),
+		types.NewApplicationInfo("user-2", "GitHub", "github-2"),
+		types.NewApplicationInfo("user-3", "reddit", "reddit-1"),
+	}}
+	githubScorer := &mockScorer{application: "github"}
+	twitterScorer := &mockScorer{application: "twitter"}
+
+	module := profilesscore.NewModule(profilesscore.Scorers{githubScorer, nil, twitterScorer}, nil, profilesscore.DefaultAggregatorConfig(), db)
+	require.Len(t, module.GetScorers(), 2)
+	require.NoError(t, module.RefreshApplicationLinksScores())
+
+	require.ElementsMatch(t, []string{"github-1", "github-2"}, githubScorer.getUsernames())
+	require.ElementsMatch(t, []string{"twitter-1"}, twitterScorer.getUsernames())
+	require.Len(t, db.scores, 3)
+}
+
+func TestModule_RefreshApplicationLinksScores_RateLimit(t *testing.T) {
+	db := &mockDatabase{links: []types.ApplicationLinkInfo{
+		types.NewApplicationInfo("user-1", "github", "github-1"),
+		types.NewApplicationInfo("user-2", "github", "github-2"),
+		types.NewApplicationInfo("user-3", "github", "github-3"),
+		types.NewApplicationInfo("user-1", "twitter", "twitter-1"),
+		types.NewApplicationInfo("user-2", "twitter", "twitter-2"),
+		types.NewApplicationInfo("user-3", "twitter", "twitter-3"),
+	}}
+
+	// The GitHub scorer allows one request every 50 milliseconds, while the Twitter one is not limited
+	githubScorer := &mockScorer{
+		application: "github",
+		rateLimit:   profilesscore.NewScoreRateLimit(500*time.Millisecond, 10),
+	}
+	twitterScorer := &mockScorer{application: "twitter"}
+
+	module := profilesscore.NewModule(profilesscore.Scorers{githubScorer, twitterScorer}, nil, profilesscore.DefaultAggregatorConfig(), db)
+	require.NoError(t, module.RefreshApplicationLinksScores())
+	require.Len(t, db.scores, 6)
+
+	// Make sure the GitHub requests are spaced according to the rate limit
+	require.Len(t, githubScorer.times, 3)
+	for i := 1; i < len(githubScorer.times); i++ {
+		require.GreaterOrEqual(t, githubScorer.times[i].Sub(githubScorer.times[i-1]), 45*time.Millisecond)
+	}
+
+	// Make sure the Twitter scorer has not been slowed down by the GitHub one
+	require.Len(t, twitterScorer.times, 3)
+	require.True(t, twitterScorer.times[2].Before(githubScorer.times[1]))
+}
+
+func TestModule_RegisterPeriodicOperations(t *testing.T) {
+	db := &mockDatabase{links: []types.ApplicationLinkInfo{
+		types.NewApplicationInfo("user-1", "github", "github-1"),
+		types.NewApplicationInfo("user-1", "twitter", "twitter-1"),
+	}}
+	db.profilesData = []types.ProfileScoreData{
+		types.NewProfileScoreData("user-1", time.Now(), nil, 0, nil, 0),
+	}
+	githubScorer := &mockScorer{application: "github"}
+	twitterScorer := &mockScorer{application: "twitter"}
+
+	refreshIntervals, err := profilesscore.ParseRefreshIntervals([]byte(`
+scorers:
+  github:
+    refresh_interval: 1h
+  twitter:
+    token: token
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]time.Duration{"github": time.Hour}, refreshIntervals)
+
+	aggregatorCfg := profilesscore.DefaultAggregatorConfig()
+	aggregatorCfg.RefreshInterval = 2 * time.Hour
+	module := profilesscore.NewModule(profilesscore.Scorers{githubScorer, twitterScorer}, refreshIntervals, aggregatorCfg, db)
+
+	scheduler := gocron.NewScheduler(time.UTC)
+	require.NoError(t, module.RegisterPeriodicOperations(scheduler))
+
+	jobs := scheduler.Jobs()
+	require.Len(t, jobs, 3)
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	// Each scorer should be run immediately, only with the links of its application, along with the aggregator
+	require.Eventually(t, func() bool {
+		return len(githubScorer.getUsernames()) == 1 && len(twitterScorer.getUsernames()) == 1 &&
+			len(db.getProfileScores()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"github-1"}, githubScorer.getUsernames())
+	require.Equal(t, []string{"twitter-1"}, twitterScorer.getUsernames())
+
+	// Each scorer should be run again after its own interval
+	nextRuns := []time.Time{jobs[0].NextRun(), jobs[1].NextRun(), jobs[2].NextRun()}
+	sort.Slice(nextRuns, func(i, j int) bool { return nextRuns[i].Before(nextRuns[j]) })
+	require.WithinDuration(t, time.Now().Add(time.Hour), nextRuns[0], time.Minute)
+	require.WithinDuration(t, time.Now().Add(2*time.Hour), nextRuns[1], time.Minute)
+	require.WithinDuration(t, time.Now().Add(profilesscore.DefaultRefreshInterval), nextRuns[2], time.Minute)
+}
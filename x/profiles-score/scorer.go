@@ -20,6 +20,10 @@ func NewScoreRateLimit(duration time.Duration, rateLimit uint64) *ScoreRateLimit
 
 // Scorer represents a generic parses that gets data from an external application and converts it to a specific score
 type Scorer interface {
+	// GetApplication returns the name of the application whose links are scored by this scorer.
+	// The refresh interval of the scorer is read from the scorers.<application>.refresh_interval config field
+	GetApplication() string
+
 	// GetRateLimit returns the rate limit for this scorer, if any
 	GetRateLimit() *ScoreRateLimit
 
@@ -29,36 +33,3 @@ type Scorer interface {
 }
 
 type Scorers []Scorer
-
-func (s Scorers) GetRateLimit() *ScoreRateLimit {
-	// Get the highest duration
-	var highestDuration time.Duration
-	for _, scorer := range s {
-		scorerRateLimit := scorer.GetRateLimit()
-		if scorerRateLimit == nil {
-			continue
-		}
-
-		// Get the maximum time frame size
-		if highestDuration == 0 || scorerRateLimit.Duration > highestDuration {
-			highestDuration = scorerRateLimit.Duration
-		}
-	}
-
-	// Get the lowest rate limit
-	var lowestRateLimit uint64
-	for _, scorer := range s {
-		scorerRateLimit := scorer.GetRateLimit()
-		if scorerRateLimit == nil {
-			continue
-		}
-
-		// Convert the scorer rate to the highest duration
-		scorerConvertedRate := scorerRateLimit.RateLimit * uint64(highestDuration.Nanoseconds()/scorerRateLimit.Duration.Nanoseconds())
-		if lowestRateLimit == 0 || scorerConvertedRate < lowestRateLimit {
-			lowestRateLimit = scorerConvertedRate
-		}
-	}
-
-	return NewScoreRateLimit(highestDuration, lowestRateLimit)
-}
@@ -28,6 +28,11 @@ func NewScorer() *Scorer {
 	}
 }
 
+// GetApplication implements Scorer
+func (s *Scorer) GetApplication() string {
+	return "domain"
+}
+
 // GetRateLimit implements Scorer
 func (s *Scorer) GetRateLimit() *profilesscore.ScoreRateLimit {
 	return profilesscore.NewScoreRateLimit(time.Minute, 10)
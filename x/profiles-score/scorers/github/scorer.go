@@ -48,6 +48,11 @@ func NewScorer(junoCfg config.Config) profilesscore.Scorer {
 	}
 }
 
+// GetApplication implements Scorer
+func (s *Scorer) GetApplication() string {
+	return "github"
+}
+
 // GetRateLimit implements Scorer
 func (s *Scorer) GetRateLimit() *profilesscore.ScoreRateLimit {
 	return profilesscore.NewScoreRateLimit(time.Hour, 5000)
@@ -57,6 +57,11 @@ func NewScorer(junoCfg config.Config) profilesscore.Scorer {
 	}
 }
 
+// GetApplication implements Scorer
+func (s *Scorer) GetApplication() string {
+	return "twitch"
+}
+
 // GetRateLimit implements Scorer
 func (s *Scorer) GetRateLimit() *profilesscore.ScoreRateLimit {
 	return profilesscore.NewScoreRateLimit(time.Minute, 800)
@@ -1,27 +1,38 @@
 package profiles
 
 import (
+	"time"
+
 	profilestypes "github.com/desmos-labs/desmos/v7/x/profiles/types"
 
 	"github.com/desmos-labs/athena/v2/types"
 )
 
 type Database interface {
+	// InProfilesTransaction runs the given function passing it a copy of the database whose writes are all
+	// performed inside a single transaction, which is committed only if the function returns no error
+	InProfilesTransaction(fn func(txDb Database) error) error
+
 	SaveProfilesParams(params types.ProfilesParams) error
 	SaveUserIfNotExisting(address string, height int64) error
 	GetUserByAddress(address string) (*profilestypes.Profile, error)
 	SaveProfile(profile *types.Profile) error
+	SaveProfiles(profiles []*types.Profile) error
 	DeleteProfile(address string, height int64) error
+	TombstoneProfile(address string, height int64, txHash string, timestamp time.Time) error
 	GetProfilesAddresses() ([]string, error)
 	SaveDTagTransferRequest(request types.DTagTransferRequest) error
 	DeleteDTagTransferRequest(request types.DTagTransferRequest) error
 	SaveChainLink(link types.ChainLink) error
 	DeleteChainLink(user string, externalAddress string, chainName string, height int64) error
 	DeleteAllChainLinks(height int64) error
+	DeleteUserChainLinks(user string, height int64) error
 	SaveDefaultChainLink(chainLink types.ChainLink) error
 	DeleteAllDefaultChainLinks(height int64) error
 	SaveApplicationLink(link types.ApplicationLink) error
 	GetApplicationLinkInfos() ([]types.ApplicationLinkInfo, error)
 	DeleteApplicationLink(user, application, username string, height int64) error
 	DeleteAllApplicationLinks(height int64) error
+	DeleteUserApplicationLinks(user string, kept []types.ApplicationLink, height int64) error
+	GetProfilesState() ([]types.EntityState, error)
 }
@@ -3,6 +3,7 @@ package profiles
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/rs/zerolog/log"
@@ -15,6 +16,7 @@ import (
 	profilestypes "github.com/desmos-labs/desmos/v7/x/profiles/types"
 
 	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/utils"
 )
 
 // GetUserProfile queries the profile for the user having the given address, if any
@@ -32,21 +34,27 @@ func (m *Module) GetUserProfile(userAddress string) (*types.Profile, error) {
 	return profile, nil
 }
 
-// UpdateProfiles updates the profiles associated with the given addresses, if any
+// UpdateProfiles updates the profiles associated with the given addresses, if any.
+// All the profiles are fetched from the chain first, and then stored inside a single transaction.
 func (m *Module) UpdateProfiles(height int64, addresses []string) error {
-	for _, address := range addresses {
+	profiles := make([]*types.Profile, len(addresses))
+	for i, address := range addresses {
 		profile, err := m.getProfile(height, address)
 		if err != nil {
 			return err
 		}
-
-		err = m.db.SaveProfile(profile)
-		if err != nil {
-			return fmt.Errorf("error while saving profile: %s", err)
-		}
+		profiles[i] = profile
 	}
 
-	return nil
+	return m.db.InProfilesTransaction(func(db Database) error {
+		for _, profile := range profiles {
+			err := db.SaveProfile(profile)
+			if err != nil {
+				return fmt.Errorf("error while saving profile: %s", err)
+			}
+		}
+		return nil
+	})
 }
 
 func (m *Module) getProfile(height int64, address string) (*types.Profile, error) {
@@ -80,15 +88,86 @@ func (m *Module) RefreshProfiles(height int64) error {
 		return fmt.Errorf("error while querying profiles: %s", err)
 	}
 
-	for _, profile := range profiles {
-		log.Debug().Str("module", "profiles").Str("dTag", profile.DTag).Msg("saving profile")
-		err = m.db.SaveProfile(profile)
+	log.Debug().Str("module", "profiles").Int("profiles", len(profiles)).Msg("saving profiles")
+	return m.db.SaveProfiles(profiles)
+}
+
+// RefreshProfile refreshes the profile of the user having the given address, along with their chain links,
+// default chain links and application links. If the profile does not exist on chain anymore, it is removed from
+// the database.
+func (m *Module) RefreshProfile(height int64, address string) error {
+	profile, err := m.getProfile(height, address)
+	if err != nil {
+		return err
+	}
+
+	chainLinks, err := m.queryAllUserChainLinks(height, address)
+	if err != nil {
+		return fmt.Errorf("error while querying chain links: %s", err)
+	}
+
+	defaultChainLinks, err := m.queryAllUserDefaultChainLinks(height, address)
+	if err != nil {
+		return fmt.Errorf("error while querying default chain links: %s", err)
+	}
+
+	applicationLinks, err := m.queryAllUserApplicationLinks(height, address)
+	if err != nil {
+		return fmt.Errorf("error while querying application links: %s", err)
+	}
+
+	var deletionTime time.Time
+	if profile == nil {
+		deletionTime, err = m.getDeletionTime(height)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return m.db.InProfilesTransaction(func(db Database) error {
+		var err error
+		if profile == nil {
+			log.Debug().Str("module", "profiles").Str("address", address).Msg("removing deleted profile")
+			err = m.deleteProfile(db, address, height, deletionTime)
+		} else {
+			err = db.SaveProfile(profile)
+		}
+		if err != nil {
+			return fmt.Errorf("error while refreshing profile: %s", err)
+		}
+
+		// Remove the stored links, so that the ones that have been deleted on chain are not kept
+		err = db.DeleteUserChainLinks(address, height)
+		if err != nil {
+			return fmt.Errorf("error while deleting chain links: %s", err)
+		}
+
+		err = saveChainLinks(db, chainLinks)
+		if err != nil {
+			return fmt.Errorf("error while refreshing chain links: %s", err)
+		}
+
+		err = saveDefaultChainLinks(db, defaultChainLinks)
+		if err != nil {
+			return fmt.Errorf("error while refreshing default chain links: %s", err)
+		}
+
+		for _, applicationLink := range applicationLinks {
+			err = db.SaveApplicationLink(applicationLink)
+			if err != nil {
+				return fmt.Errorf("error while refreshing application links: %s", err)
+			}
+		}
+
+		// Remove the application links that have been deleted on chain. The existing ones are updated instead of
+		// being deleted, so that their scores are not deleted as well.
+		err = db.DeleteUserApplicationLinks(address, applicationLinks, height)
+		if err != nil {
+			return fmt.Errorf("error while deleting application links: %s", err)
+		}
+
+		return nil
+	})
 }
 
 // queryAllProfiles queries all the profiles stored inside the chain
@@ -300,3 +379,21 @@ func (m *Module) queryAllApplicationLinks(height int64) ([]types.ApplicationLink
 
 	return chainLinks, nil
 }
+
+// getDeletionTime returns the time at which the profiles deleted at the given height should be marked as deleted.
+// The block time is fetched only if the tombstones are enabled.
+func (m *Module) getDeletionTime(height int64) (time.Time, error) {
+	if !m.tombstones {
+		return time.Time{}, nil
+	}
+	return utils.GetBlockTime(m.node, height)
+}
+
+// deleteProfile removes the profile of the given user from the given database, or marks it as deleted at the given
+// time if the tombstones are enabled
+func (m *Module) deleteProfile(db Database, address string, height int64, deletionTime time.Time) error {
+	if !m.tombstones {
+		return db.DeleteProfile(address, height)
+	}
+	return db.TombstoneProfile(address, height, "", deletionTime)
+}
@@ -22,16 +22,16 @@ func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, appState map[string]json
 	}
 
 	// Store the profiles
+	var profiles []*types.Profile
 	for _, account := range accounts {
-		profile, ok := account.(*profilestypes.Profile)
-		if !ok {
-			continue
+		if profile, ok := account.(*profilestypes.Profile); ok {
+			profiles = append(profiles, types.NewProfile(profile, doc.InitialHeight))
 		}
+	}
 
-		err = m.db.SaveProfile(types.NewProfile(profile, doc.InitialHeight))
-		if err != nil {
-			return err
-		}
+	err = m.db.SaveProfiles(profiles)
+	if err != nil {
+		return err
 	}
 
 	var genState profilestypes.GenesisState
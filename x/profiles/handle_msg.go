@@ -14,6 +14,7 @@ import (
 	profilestypes "github.com/desmos-labs/desmos/v7/x/profiles/types"
 
 	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/utils"
 )
 
 // HandleMsgExec implements modules.AuthzMessageModule
@@ -84,6 +85,13 @@ func (m *Module) handleMsgSaveProfile(tx *juno.Tx, msg *profilestypes.MsgSavePro
 
 // handleMsgDeleteProfile handles a MsgDeleteProfile correctly deleting the account present inside the database
 func (m *Module) handleMsgDeleteProfile(tx *juno.Tx, msg *profilestypes.MsgDeleteProfile) error {
+	if m.tombstones {
+		timestamp, err := utils.GetTxTimestamp(tx)
+		if err != nil {
+			return err
+		}
+		return m.db.TombstoneProfile(msg.Creator, tx.Height, tx.TxHash, timestamp)
+	}
 	return m.db.DeleteProfile(msg.Creator, tx.Height)
 }
 
@@ -126,32 +134,64 @@ func (m *Module) handleDTagTransferRequestDeletion(height int64, sender, receive
 
 // handleMsgChainLink allows to handle a MsgLinkChainAccount properly
 func (m *Module) handleMsgChainLink(tx *juno.Tx, msg *profilestypes.MsgLinkChainAccount) error {
-	// Save the chain links
-	err := m.updateUserChainLinks(tx.Height, msg.Signer)
+	chainLinks, err := m.queryAllUserChainLinks(tx.Height, msg.Signer)
+	if err != nil {
+		return err
+	}
+
+	defaultChainLinks, err := m.queryAllUserDefaultChainLinks(tx.Height, msg.Signer)
 	if err != nil {
 		return err
 	}
 
-	// Update the default chain links
-	return m.updateUserDefaultChainLinks(tx.Height, msg.Signer)
+	return m.db.InProfilesTransaction(func(db Database) error {
+		// Save the chain links
+		err := saveChainLinks(db, chainLinks)
+		if err != nil {
+			return err
+		}
+
+		// Update the default chain links
+		return saveDefaultChainLinks(db, defaultChainLinks)
+	})
 }
 
 // handleMsgUnlinkChainAccount allows to handle a MsgUnlinkChainAccount properly
 func (m *Module) handleMsgUnlinkChainAccount(tx *juno.Tx, msg *profilestypes.MsgUnlinkChainAccount) error {
-	err := m.db.DeleteChainLink(msg.Owner, msg.Target, msg.ChainName, tx.Height)
+	defaultChainLinks, err := m.queryAllUserDefaultChainLinks(tx.Height, msg.Owner)
 	if err != nil {
 		return err
 	}
 
-	// Update the default chain links
-	return m.updateUserDefaultChainLinks(tx.Height, msg.Owner)
+	return m.db.InProfilesTransaction(func(db Database) error {
+		err := db.DeleteChainLink(msg.Owner, msg.Target, msg.ChainName, tx.Height)
+		if err != nil {
+			return err
+		}
+
+		// Update the default chain links
+		return saveDefaultChainLinks(db, defaultChainLinks)
+	})
 }
 
 // -----------------------------------------------------------------------------------------------------
 
 // handleMsgLinkApplication allows to handle a MsgLinkApplication properly
 func (m *Module) handleMsgLinkApplication(tx *juno.Tx, msg *profilestypes.MsgLinkApplication) error {
-	return m.updateUserApplicationLinks(tx.Height, msg.Sender)
+	applicationLinks, err := m.queryAllUserApplicationLinks(tx.Height, msg.Sender)
+	if err != nil {
+		return err
+	}
+
+	return m.db.InProfilesTransaction(func(db Database) error {
+		for _, applicationLink := range applicationLinks {
+			err := db.SaveApplicationLink(applicationLink)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // handleMsgUnlinkApplication allows to handle a MsgUnlinkApplication properly
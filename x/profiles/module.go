@@ -26,6 +26,9 @@ type Module struct {
 	node           node.Node
 	authClient     authtypes.QueryClient
 	profilesClient profilestypes.QueryClient
+
+	// tombstones tells whether the deleted rows should be marked as deleted instead of being removed
+	tombstones bool
 }
 
 // NewModule allows to build a new Module instance
@@ -39,6 +42,12 @@ func NewModule(node node.Node, grpcConnection *grpc.ClientConn, cdc codec.Codec,
 	}
 }
 
+// WithTombstones sets whether the deleted rows should be marked as deleted instead of being removed
+func (m *Module) WithTombstones(enabled bool) *Module {
+	m.tombstones = enabled
+	return m
+}
+
 // Name implements modules.Module
 func (m *Module) Name() string {
 	return "profiles"
@@ -30,21 +30,16 @@ func (m *Module) GetDisplayName(userAddress string) string {
 
 // --------------------------------------------------------------------------------------------------------------------
 
-// updateUserChainLinks updates the chain links for the given address, if any
-func (m *Module) updateUserChainLinks(height int64, address string) error {
-	chainLinks, err := m.queryAllUserChainLinks(height, address)
-	if err != nil {
-		return err
-	}
-
+// saveChainLinks stores the given chain links inside the given database
+func saveChainLinks(db Database, chainLinks []types.ChainLink) error {
 	for _, chainLink := range chainLinks {
-		err = m.db.SaveChainLink(chainLink)
+		err := db.SaveChainLink(chainLink)
 		if err != nil {
 			return err
 		}
 	}
 
-	return err
+	return nil
 }
 
 // queryAllUserChainLinks queries all the chain links for the given address
@@ -80,15 +75,10 @@ func (m *Module) queryAllUserChainLinks(height int64, address string) ([]types.C
 
 // --------------------------------------------------------------------------------------------------------------------
 
-// updateUserDefaultChainLinks updates the default chain links associated with the given address, if any
-func (m *Module) updateUserDefaultChainLinks(height int64, address string) error {
-	chainLinks, err := m.queryAllUserDefaultChainLinks(height, address)
-	if err != nil {
-		return err
-	}
-
+// saveDefaultChainLinks stores the given default chain links inside the given database
+func saveDefaultChainLinks(db Database, chainLinks []types.ChainLink) error {
 	for _, chainLink := range chainLinks {
-		err = m.db.SaveDefaultChainLink(chainLink)
+		err := db.SaveDefaultChainLink(chainLink)
 		if err != nil {
 			return err
 		}
@@ -130,23 +120,6 @@ func (m *Module) queryAllUserDefaultChainLinks(height int64, address string) ([]
 
 // --------------------------------------------------------------------------------------------------------------------
 
-// updateUserApplicationLinks updates the application links associated with the given address, if any
-func (m *Module) updateUserApplicationLinks(height int64, address string) error {
-	applicationLinks, err := m.queryAllUserApplicationLinks(height, address)
-	if err != nil {
-		return err
-	}
-
-	for _, applicationLink := range applicationLinks {
-		err = m.db.SaveApplicationLink(applicationLink)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 // queryAllUserApplicationLinks queries all the application links for the given address
 func (m *Module) queryAllUserApplicationLinks(height int64, address string) ([]types.ApplicationLink, error) {
 	var chainLinks []types.ApplicationLink
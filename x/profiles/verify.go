@@ -0,0 +1,52 @@
+package profiles
+
+import (
+	"fmt"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// Verify compares the profiles stored inside the database with the chain state at the given height,
+// returning the report of the drifted profiles
+func (m *Module) Verify(height int64) (*types.VerificationReport, error) {
+	report := types.NewVerificationReport(m.Name(), 0, height)
+
+	profiles, err := m.queryAllProfiles(height)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying profiles: %s", err)
+	}
+
+	storedProfiles, err := m.db.GetProfilesState()
+	if err != nil {
+		return nil, fmt.Errorf("error while getting stored profiles: %s", err)
+	}
+
+	chainProfiles := map[string]*types.Profile{}
+	var chainStates []types.EntityState
+	for _, profile := range profiles {
+		key := profile.GetAddress().String()
+		chainProfiles[key] = profile
+		chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+			"dtag":        profile.DTag,
+			"nickname":    profile.Nickname,
+			"bio":         profile.Bio,
+			"profile_pic": profile.Pictures.Profile,
+			"cover_pic":   profile.Pictures.Cover,
+		}))
+	}
+
+	report.Compare("profile", chainStates, storedProfiles, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveProfile(chainProfiles[key])
+		},
+		func(key string) error {
+			deletionTime, err := m.getDeletionTime(height)
+			if err != nil {
+				return err
+			}
+			return m.deleteProfile(m.db, key, height, deletionTime)
+		},
+	))
+
+	return report, nil
+}
@@ -1,6 +1,8 @@
 package reactions
 
 import (
+	"time"
+
 	"github.com/desmos-labs/athena/v2/types"
 )
 
@@ -10,8 +12,13 @@ type Database interface {
 	SaveReaction(reaction types.Reaction) error
 	DeleteReaction(height int64, subspaceID uint64, postID uint64, reactionID uint32) error
 	DeleteAllReactions(height int64, subspaceID uint64, postID uint64) error
+	TombstoneReaction(height int64, subspaceID uint64, postID uint64, reactionID uint32, txHash string, timestamp time.Time) error
+	TombstoneAllReactions(height int64, subspaceID uint64, postID uint64, timestamp time.Time) error
 	SaveRegisteredReaction(reaction types.RegisteredReaction) error
 	DeleteRegisteredReaction(height int64, subspaceID uint64, reactionID uint32) error
 	DeleteAllRegisteredReactions(height int64, subspaceID uint64) error
 	SaveReactionParams(params types.ReactionParams) error
+	GetSubspacePostIDs(subspaceID uint64) ([]uint64, error)
+	GetRegisteredReactionsState(subspaceID uint64) ([]types.EntityState, error)
+	GetReactionsState(subspaceID uint64) ([]types.EntityState, error)
 }
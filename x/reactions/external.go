@@ -2,6 +2,7 @@ package reactions
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -11,6 +12,7 @@ import (
 	reactionstypes "github.com/desmos-labs/desmos/v7/x/reactions/types"
 
 	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/utils"
 )
 
 // RefreshRegisteredReactionsData refreshes the registered reactions data for the given subspace
@@ -87,7 +89,16 @@ func (m *Module) RefreshReactionsData(height int64, subspaceID uint64, postID ui
 		return err
 	}
 
-	err = m.db.DeleteAllReactions(height, subspaceID, postID)
+	if m.tombstones {
+		var timestamp time.Time
+		timestamp, err = utils.GetBlockTime(m.node, height)
+		if err != nil {
+			return err
+		}
+		err = m.db.TombstoneAllReactions(height, subspaceID, postID, timestamp)
+	} else {
+		err = m.db.DeleteAllReactions(height, subspaceID, postID)
+	}
 	if err != nil {
 		return err
 	}
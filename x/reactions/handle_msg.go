@@ -11,6 +11,8 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	juno "github.com/forbole/juno/v5/types"
+
+	"github.com/desmos-labs/athena/v2/utils"
 )
 
 // HandleMsgExec implements modules.AuthzMessageModule
@@ -67,6 +69,13 @@ func (m *Module) handleMsgAddReaction(tx *juno.Tx, index int, msg *reactionstype
 
 // handleMsgRemoveReaction handles a MsgRemoveReaction
 func (m *Module) handleMsgRemoveReaction(tx *juno.Tx, msg *reactionstypes.MsgRemoveReaction) error {
+	if m.tombstones {
+		timestamp, err := utils.GetTxTimestamp(tx)
+		if err != nil {
+			return err
+		}
+		return m.db.TombstoneReaction(tx.Height, msg.SubspaceID, msg.PostID, msg.ReactionID, tx.TxHash, timestamp)
+	}
 	return m.db.DeleteReaction(tx.Height, msg.SubspaceID, msg.PostID, msg.ReactionID)
 }
 
@@ -0,0 +1,157 @@
+package reactions
+
+import (
+	"fmt"
+
+	poststypes "github.com/desmos-labs/desmos/v7/x/posts/types"
+	reactionstypes "github.com/desmos-labs/desmos/v7/x/reactions/types"
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/utils"
+)
+
+// Verify compares the registered reactions and the reactions of the given subspace stored inside the database
+// with the chain state at the given height, returning the report of the drifted entities
+func (m *Module) Verify(height int64, subspaceID uint64) (*types.VerificationReport, error) {
+	report := types.NewVerificationReport(m.Name(), subspaceID, height)
+
+	err := m.verifyRegisteredReactions(height, subspaceID, report)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.verifyReactions(height, subspaceID, report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// verifyRegisteredReactions compares the registered reactions of the given subspace stored inside the database
+// with the chain state at the given height, adding the drifted ones to the given report
+func (m *Module) verifyRegisteredReactions(height int64, subspaceID uint64, report *types.VerificationReport) error {
+	reactions, err := m.queryAllRegisteredReactions(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying registered reactions: %s", err)
+	}
+
+	storedReactions, err := m.db.GetRegisteredReactionsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored registered reactions: %s", err)
+	}
+
+	chainReactions := map[string]types.RegisteredReaction{}
+	var chainStates []types.EntityState
+	for _, reaction := range reactions {
+		key := types.EntityKey(reaction.SubspaceID, reaction.ID)
+		chainReactions[key] = reaction
+		chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+			"shorthand_code": reaction.ShorthandCode,
+			"display_value":  reaction.DisplayValue,
+		}))
+	}
+
+	report.Compare("registered_reaction", chainStates, storedReactions, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveRegisteredReaction(chainReactions[key])
+		},
+		func(key string) error {
+			reactionID, err := reactionstypes.ParseReactionID(types.SplitEntityKey(key)[1])
+			if err != nil {
+				return err
+			}
+			return m.db.DeleteRegisteredReaction(height, subspaceID, reactionID)
+		},
+	))
+
+	return nil
+}
+
+// verifyReactions compares the reactions to the posts of the given subspace stored inside the database
+// with the chain state at the given height, adding the drifted ones to the given report
+func (m *Module) verifyReactions(height int64, subspaceID uint64, report *types.VerificationReport) error {
+	// Only the reactions of the stored posts are verified, since the other ones can not be stored
+	postIDs, err := m.db.GetSubspacePostIDs(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored posts: %s", err)
+	}
+
+	chainReactions := map[string]types.Reaction{}
+	var chainStates []types.EntityState
+	for _, postID := range postIDs {
+		reactions, err := m.queryAllReactions(height, subspaceID, postID)
+		if err != nil {
+			return fmt.Errorf("error while querying reactions: %s", err)
+		}
+
+		for _, reaction := range reactions {
+			key := types.EntityKey(reaction.SubspaceID, reaction.PostID, reaction.ID)
+			chainReactions[key] = reaction
+			chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+				"author": reaction.Author,
+				"value":  getReactionValue(reaction),
+			}))
+		}
+	}
+
+	storedReactions, err := m.db.GetReactionsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored reactions: %s", err)
+	}
+
+	report.Compare("reaction", chainStates, storedReactions, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveReaction(chainReactions[key])
+		},
+		func(key string) error {
+			return m.deleteReaction(height, key)
+		},
+	))
+
+	return nil
+}
+
+// getReactionValue returns the value of the given reaction in the same format used when reading it
+// from the database
+func getReactionValue(reaction types.Reaction) string {
+	switch value := reaction.Value.GetCachedValue().(type) {
+	case *reactionstypes.RegisteredReactionValue:
+		return fmt.Sprintf("registered:%d", value.RegisteredReactionID)
+	case *reactionstypes.FreeTextValue:
+		return "free_text:" + value.Text
+	default:
+		return ""
+	}
+}
+
+// deleteReaction removes the reaction having the given key from the database,
+// marking it as deleted instead when tombstones are enabled
+func (m *Module) deleteReaction(height int64, key string) error {
+	ids := types.SplitEntityKey(key)
+	subspaceID, err := subspacestypes.ParseSubspaceID(ids[0])
+	if err != nil {
+		return err
+	}
+
+	postID, err := poststypes.ParsePostID(ids[1])
+	if err != nil {
+		return err
+	}
+
+	reactionID, err := reactionstypes.ParseReactionID(ids[2])
+	if err != nil {
+		return err
+	}
+
+	if !m.tombstones {
+		return m.db.DeleteReaction(height, subspaceID, postID, reactionID)
+	}
+
+	timestamp, err := utils.GetBlockTime(m.node, height)
+	if err != nil {
+		return err
+	}
+	return m.db.TombstoneReaction(height, subspaceID, postID, reactionID, "", timestamp)
+}
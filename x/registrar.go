@@ -1,16 +1,25 @@
 package x
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/rs/zerolog/log"
+
 	notificationscontext "github.com/desmos-labs/athena/v2/x/notifications/context"
 	notificationssender "github.com/desmos-labs/athena/v2/x/notifications/sender"
 
 	"github.com/desmos-labs/athena/v2/database"
+	"github.com/desmos-labs/athena/v2/utils"
 	"github.com/desmos-labs/athena/v2/x/apis"
+	"github.com/desmos-labs/athena/v2/x/apis/admin"
+	"github.com/desmos-labs/athena/v2/x/apis/cache"
 	"github.com/desmos-labs/athena/v2/x/authz"
 	contractsbuilder "github.com/desmos-labs/athena/v2/x/contracts/builder"
+	"github.com/desmos-labs/athena/v2/x/failures"
 	"github.com/desmos-labs/athena/v2/x/feegrant"
+	"github.com/desmos-labs/athena/v2/x/gaps"
+	"github.com/desmos-labs/athena/v2/x/journal"
 	"github.com/desmos-labs/athena/v2/x/notifications"
 	notificationsbuilder "github.com/desmos-labs/athena/v2/x/notifications/builder"
 	standardnotificationsbuilder "github.com/desmos-labs/athena/v2/x/notifications/builder/standard"
@@ -21,11 +30,14 @@ import (
 	"github.com/desmos-labs/athena/v2/x/relationships"
 	"github.com/desmos-labs/athena/v2/x/reports"
 	"github.com/desmos-labs/athena/v2/x/subspaces"
+	"github.com/desmos-labs/athena/v2/x/tombstones"
 
 	"github.com/forbole/juno/v5/modules"
 	"github.com/forbole/juno/v5/modules/registrar"
 	"github.com/forbole/juno/v5/modules/telemetry"
 	"github.com/forbole/juno/v5/node/remote"
+	"github.com/forbole/juno/v5/parser"
+	"github.com/forbole/juno/v5/types/config"
 )
 
 type RegistrarOptions struct {
@@ -33,6 +45,10 @@ type RegistrarOptions struct {
 	NotificationsSenderCreator  notificationssender.NotificationsSenderCreator
 	APIsRegistrar               apis.Registrar
 	APIsConfigurator            apis.Configurator
+
+	// APIsCache is the cache used to store the API responses.
+	// If not set, an in-memory cache is used when enabled inside the apis configuration.
+	APIsCache cache.Cache
 }
 
 func (o RegistrarOptions) CreateNotificationsBuilder(context notificationscontext.Context) notificationsbuilder.NotificationsBuilder {
@@ -60,16 +76,51 @@ func (o RegistrarOptions) GetAPIsConfigurator() apis.Configurator {
 	return o.APIsConfigurator
 }
 
+// GetAPIsCache returns the cache that should be used to store the API responses, or nil if caching is disabled
+func (o RegistrarOptions) GetAPIsCache(junoCfg config.Config) cache.Cache {
+	if o.APIsCache != nil {
+		return o.APIsCache
+	}
+
+	cfgBz, err := junoCfg.GetBytes()
+	if err != nil {
+		panic(err)
+	}
+
+	cfg, err := apis.ParseConfig(cfgBz)
+	if err != nil {
+		panic(err)
+	}
+
+	if cfg == nil || cfg.Cache == nil {
+		return nil
+	}
+
+	return cache.NewLRU(cfg.Cache.GetSize(), cfg.Cache.GetTTL())
+}
+
 // --------------------------------------------------------------------------------------------------------------------
 
 // ModulesRegistrar represents the modules.Registrar that allows to register all custom Athena modules
 type ModulesRegistrar struct {
 	options RegistrarOptions
+
+	// lifecycle owns the context shared by the long-running services started by the modules
+	lifecycle *utils.Lifecycle
+
+	// failed is closed when any of the long-running services fails
+	failed chan struct{}
+
+	// shutdown stops the parser the same way Juno does when receiving a termination signal
+	shutdown func()
 }
 
 // NewModulesRegistrar allows to build a new ModulesRegistrar instance
 func NewModulesRegistrar() *ModulesRegistrar {
-	return &ModulesRegistrar{}
+	return &ModulesRegistrar{
+		lifecycle: utils.NewLifecycle(context.Background()),
+		failed:    make(chan struct{}),
+	}
 }
 
 // WithOptions sets the given option inside this registrar
@@ -78,31 +129,100 @@ func (r *ModulesRegistrar) WithOptions(options RegistrarOptions) *ModulesRegistr
 	return r
 }
 
+// Stop gracefully stops all the long-running services started by the modules (e.g. the API server),
+// waiting for them to complete. It returns the first error returned by any of them, if any.
+func (r *ModulesRegistrar) Stop() error {
+	return r.lifecycle.Stop()
+}
+
+// Failed returns a channel that is closed when any of the long-running services started by the modules fails.
+// When that happens, Shutdown should be called to stop the parser.
+func (r *ModulesRegistrar) Failed() <-chan struct{} {
+	return r.failed
+}
+
+// Shutdown stops the node and closes the database, which in turn stops all the long-running services, the same way
+// Juno does when receiving a termination signal. It returns the error of the service that failed, if any.
+func (r *ModulesRegistrar) Shutdown() error {
+	if r.shutdown != nil {
+		r.shutdown()
+	}
+	return r.Stop()
+}
+
+// closeHookRegistrar represents a database that allows registering hooks to be called before being closed
+type closeHookRegistrar interface {
+	RegisterCloseHook(hook func())
+}
+
+// failuresLogger represents a logger that stores the failures of the modules handlers
+type failuresLogger interface {
+	SetDatabase(db gaps.LoggerDatabase)
+}
+
+// bindLifecycle ties the lifecycle of the long-running services to the one of the given database and node.
+// The services are stopped before the database is closed, and the Failed channel is closed if any service fails.
+func (r *ModulesRegistrar) bindLifecycle(ctx registrar.Context) {
+	r.shutdown = func() {
+		ctx.Proxy.Stop()
+		ctx.Database.Close()
+	}
+
+	if closer, ok := ctx.Database.(closeHookRegistrar); ok {
+		closer.RegisterCloseHook(func() {
+			err := r.lifecycle.Stop()
+			if err != nil {
+				log.Error().Err(err).Msg("error while stopping services")
+			}
+		})
+	}
+
+	go func() {
+		<-r.lifecycle.Done()
+		err := r.lifecycle.Err()
+		if err == nil {
+			return
+		}
+
+		log.Error().Err(err).Msg("service failed, shutting down")
+		close(r.failed)
+	}()
+}
+
 // BuildModules implements modules.Registrar
 func (r *ModulesRegistrar) BuildModules(ctx registrar.Context) modules.Modules {
 	cdc := ctx.EncodingConfig.Codec
 	athenaDb := database.Cast(ctx.Database)
 
+	// Invalidate the cached API responses as soon as the modules write the data they depend on
+	apisCache := r.options.GetAPIsCache(ctx.JunoConfig)
+	if apisCache != nil {
+		athenaDb = database.NewCacheInvalidatingDb(athenaDb, apisCache)
+	}
+
 	remoteCfg, ok := ctx.JunoConfig.Node.Details.(*remote.Details)
 	if !ok {
 		panic(fmt.Errorf("cannot run Athena on local node"))
 	}
 
 	grpcConnection := remote.MustCreateGrpcConnection(remoteCfg.GRPC)
+	r.bindLifecycle(ctx)
+
+	// Store the failures of the modules handlers so that they can be handled again
+	if logger, ok := ctx.Logger.(failuresLogger); ok {
+		logger.SetDatabase(athenaDb)
+	}
 
 	// Juno modules
 	telemetryModule := telemetry.NewModule(ctx.JunoConfig)
 
 	// Athena modules
-	apisModule := apis.NewModule(apis.NewContext(ctx, grpcConnection))
-	if apisModule != nil {
-		apisModule = apisModule.WithRegistrar(r.options.GetAPIsRegistrar())
-		apisModule = apisModule.WithConfigurator(r.options.GetAPIsConfigurator())
-	}
-
 	authzModule := authz.NewModule(ctx.Proxy, cdc, athenaDb)
 	contractsModule := contractsbuilder.BuildModule(ctx.JunoConfig, ctx.Proxy, grpcConnection, athenaDb)
 	feegrantModule := feegrant.NewModule(ctx.Proxy, cdc, athenaDb)
+	failuresModule := failures.NewModule(ctx.JunoConfig, ctx.Proxy, cdc, athenaDb)
+	gapsModule := gaps.NewModule(ctx.JunoConfig, athenaDb)
+	journalModule := journal.NewModule(ctx.JunoConfig, athenaDb)
 	postsModule := posts.NewModule(ctx.Proxy, grpcConnection, cdc, athenaDb)
 	profilesModule := profiles.NewModule(ctx.Proxy, grpcConnection, cdc, athenaDb)
 	profilesScoreModule := profilesscorebuilder.BuildModule(ctx.JunoConfig, athenaDb)
@@ -111,6 +231,35 @@ func (r *ModulesRegistrar) BuildModules(ctx registrar.Context) modules.Modules {
 	reportsModule := reports.NewModule(ctx.Proxy, grpcConnection, cdc, athenaDb)
 	subspacesModule := subspaces.NewModule(ctx.Proxy, grpcConnection, cdc, athenaDb)
 
+	// Mark the deleted rows instead of removing them for the modules that have the tombstones mode enabled
+	tombstonesModule := tombstones.NewModule(ctx.JunoConfig, athenaDb)
+	postsModule = postsModule.WithTombstones(tombstonesModule.IsEnabled(postsModule.Name()))
+	profilesModule = profilesModule.WithTombstones(tombstonesModule.IsEnabled(profilesModule.Name()))
+	reactionsModule = reactionsModule.WithTombstones(tombstonesModule.IsEnabled(reactionsModule.Name()))
+
+	apisContext := apis.NewContext(ctx, grpcConnection)
+	apisContext.Cache = apisCache
+
+	apisModule := apis.NewModule(apisContext)
+	if apisModule != nil {
+		adminRegistrar := admin.NewRegistrar(admin.Modules{
+			Authz:         authzModule,
+			Contracts:     contractsModule,
+			Feegrant:      feegrantModule,
+			Posts:         postsModule,
+			Profiles:      profilesModule,
+			ProfilesScore: profilesScoreModule,
+			Reactions:     reactionsModule,
+			Relationships: relationshipsModule,
+			Reports:       reportsModule,
+			Subspaces:     subspacesModule,
+		})
+
+		apisModule = apisModule.WithRegistrar(apis.CombinedRegistrar(r.options.GetAPIsRegistrar(), adminRegistrar))
+		apisModule = apisModule.WithConfigurator(r.options.GetAPIsConfigurator())
+		apisModule = apisModule.WithLifecycle(r.lifecycle)
+	}
+
 	context := notificationscontext.NewContext(ctx, ctx.Proxy, grpcConnection)
 	notificationsModule := notifications.NewModule(ctx.JunoConfig, postsModule, reactionsModule, cdc, athenaDb)
 	if notificationsModule != nil {
@@ -119,7 +268,7 @@ func (r *ModulesRegistrar) BuildModules(ctx registrar.Context) modules.Modules {
 			WithNotificationSender(r.options.CreateNotificationsSender(context))
 	}
 
-	return []modules.Module{
+	athenaModules := []modules.Module{
 		apisModule,
 		authzModule,
 		feegrantModule,
@@ -133,5 +282,27 @@ func (r *ModulesRegistrar) BuildModules(ctx registrar.Context) modules.Modules {
 		telemetryModule,
 		contractsModule,
 		profilesScoreModule,
+		tombstonesModule,
+		journalModule,
+		gapsModule,
+		failuresModule,
+	}
+
+	// Parse the failed heights and messages again using the same modules used when parsing the chain
+	enabled := enabledModules(athenaModules, ctx.JunoConfig.Chain.Modules)
+	gapsModule.WithParserContext(parser.NewContext(ctx.EncodingConfig, ctx.Proxy, athenaDb, ctx.Logger, enabled))
+	failuresModule.WithModules(enabled)
+
+	return athenaModules
+}
+
+// enabledModules returns the modules among the given ones whose name is contained inside the given names
+func enabledModules(mods modules.Modules, names []string) []modules.Module {
+	var enabled []modules.Module
+	for _, name := range names {
+		if module, found := mods.FindByName(name); found {
+			enabled = append(enabled, module)
+		}
 	}
+	return enabled
 }
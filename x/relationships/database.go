@@ -8,7 +8,11 @@ type Database interface {
 	SaveRelationship(relationship types.Relationship) error
 	DeleteRelationship(relationship types.Relationship) error
 	DeleteAllRelationships(height int64, subspaceID uint64) error
+	DeleteUserRelationships(height int64, subspaceID uint64, user string) error
 	SaveUserBlock(block types.Blockage) error
 	DeleteBlockage(block types.Blockage) error
 	DeleteAllUserBlocks(height int64, subspaceID uint64) error
+	DeleteUserBlocks(height int64, subspaceID uint64, blocker string) error
+	GetRelationshipsState(subspaceID uint64) ([]types.EntityState, error)
+	GetUserBlocksState(subspaceID uint64) ([]types.EntityState, error)
 }
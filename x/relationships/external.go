@@ -127,3 +127,116 @@ func (m *Module) queryAllUserBlocks(height int64, subspaceID uint64) ([]types.Bl
 	return userBlocks, nil
 
 }
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// RefreshUserData refreshes the relationships and the user blocks created by the given user inside the given subspace.
+// The ones in which the user is the counterparty or the blocked user are not refreshed, since the chain does not
+// allow to query them by counterparty.
+func (m *Module) RefreshUserData(height int64, subspaceID uint64, user string) error {
+	relationships, err := m.queryUserRelationships(height, subspaceID, user)
+	if err != nil {
+		return fmt.Errorf("error while getting relationships from gRPC: %s", err)
+	}
+
+	err = m.db.DeleteUserRelationships(height, subspaceID, user)
+	if err != nil {
+		return fmt.Errorf("error while deleting relationships: %s", err)
+	}
+
+	for _, relationship := range relationships {
+		log.Debug().Uint64("subspace", relationship.SubspaceID).Str("creator", relationship.Creator).
+			Str("counterparty", relationship.Counterparty).Msg("refreshing relationship")
+
+		err = m.db.SaveRelationship(relationship)
+		if err != nil {
+			return fmt.Errorf("error while saving relationship: %s", err)
+		}
+	}
+
+	userBlocks, err := m.queryUserBlocks(height, subspaceID, user)
+	if err != nil {
+		return fmt.Errorf("error while getting user blocks from gRPC: %s", err)
+	}
+
+	err = m.db.DeleteUserBlocks(height, subspaceID, user)
+	if err != nil {
+		return fmt.Errorf("error while deleting user blocks: %s", err)
+	}
+
+	for _, block := range userBlocks {
+		log.Debug().Uint64("subspace", block.SubspaceID).Str("blocker", block.Blocker).
+			Str("blocked", block.Blocked).Msg("refreshing block")
+
+		err = m.db.SaveUserBlock(block)
+		if err != nil {
+			return fmt.Errorf("error while saving user block: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// queryUserRelationships queries all the relationships created by the given user inside the given subspace
+func (m *Module) queryUserRelationships(height int64, subspaceID uint64, user string) ([]types.Relationship, error) {
+	var relationships []types.Relationship
+
+	var nextKey []byte
+	var stop = false
+	for !stop {
+		res, err := m.client.Relationships(
+			remote.GetHeightRequestContext(context.Background(), height),
+			&relationshipstypes.QueryRelationshipsRequest{
+				SubspaceId: subspaceID,
+				User:       user,
+				Pagination: &query.PageRequest{
+					Key: nextKey,
+				},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, relationship := range res.Relationships {
+			relationships = append(relationships, types.NewRelationship(relationship, height))
+		}
+
+		nextKey = res.Pagination.NextKey
+		stop = nextKey == nil
+	}
+
+	return relationships, nil
+}
+
+// queryUserBlocks queries all the user blocks created by the given blocker inside the given subspace
+func (m *Module) queryUserBlocks(height int64, subspaceID uint64, blocker string) ([]types.Blockage, error) {
+	var userBlocks []types.Blockage
+
+	var nextKey []byte
+	var stop = false
+	for !stop {
+		res, err := m.client.Blocks(
+			remote.GetHeightRequestContext(context.Background(), height),
+			&relationshipstypes.QueryBlocksRequest{
+				SubspaceId: subspaceID,
+				Blocker:    blocker,
+				Pagination: &query.PageRequest{
+					Key: nextKey,
+				},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blockage := range res.Blocks {
+			userBlocks = append(userBlocks, types.NewBlockage(blockage, height))
+		}
+
+		nextKey = res.Pagination.NextKey
+		stop = nextKey == nil
+	}
+
+	return userBlocks, nil
+}
@@ -0,0 +1,103 @@
+package relationships
+
+import (
+	"fmt"
+
+	relationshipstypes "github.com/desmos-labs/desmos/v7/x/relationships/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// Verify compares the relationships and the user blocks of the given subspace stored inside the database
+// with the chain state at the given height, returning the report of the drifted entities
+func (m *Module) Verify(height int64, subspaceID uint64) (*types.VerificationReport, error) {
+	report := types.NewVerificationReport(m.Name(), subspaceID, height)
+
+	err := m.verifyRelationships(height, subspaceID, report)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.verifyUserBlocks(height, subspaceID, report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// verifyRelationships compares the relationships of the given subspace stored inside the database
+// with the chain state at the given height, adding the drifted ones to the given report
+func (m *Module) verifyRelationships(height int64, subspaceID uint64, report *types.VerificationReport) error {
+	relationships, err := m.queryAllRelationships(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying relationships: %s", err)
+	}
+
+	storedRelationships, err := m.db.GetRelationshipsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored relationships: %s", err)
+	}
+
+	chainRelationships := map[string]types.Relationship{}
+	var chainStates []types.EntityState
+	for _, relationship := range relationships {
+		key := types.EntityKey(relationship.SubspaceID, relationship.Creator, relationship.Counterparty)
+		chainRelationships[key] = relationship
+		chainStates = append(chainStates, types.NewEntityState(key, nil))
+	}
+
+	report.Compare("relationship", chainStates, storedRelationships, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveRelationship(chainRelationships[key])
+		},
+		func(key string) error {
+			ids := types.SplitEntityKey(key)
+			return m.db.DeleteRelationship(types.NewRelationship(
+				relationshipstypes.NewRelationship(ids[1], ids[2], subspaceID),
+				height,
+			))
+		},
+	))
+
+	return nil
+}
+
+// verifyUserBlocks compares the user blocks of the given subspace stored inside the database
+// with the chain state at the given height, adding the drifted ones to the given report
+func (m *Module) verifyUserBlocks(height int64, subspaceID uint64, report *types.VerificationReport) error {
+	blocks, err := m.queryAllUserBlocks(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying user blocks: %s", err)
+	}
+
+	storedBlocks, err := m.db.GetUserBlocksState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored user blocks: %s", err)
+	}
+
+	chainBlocks := map[string]types.Blockage{}
+	var chainStates []types.EntityState
+	for _, block := range blocks {
+		key := types.EntityKey(block.SubspaceID, block.Blocker, block.Blocked)
+		chainBlocks[key] = block
+		chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+			"reason": block.Reason,
+		}))
+	}
+
+	report.Compare("user_block", chainStates, storedBlocks, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveUserBlock(chainBlocks[key])
+		},
+		func(key string) error {
+			ids := types.SplitEntityKey(key)
+			return m.db.DeleteBlockage(types.NewBlockage(
+				relationshipstypes.NewUserBlock(ids[1], ids[2], "", subspaceID),
+				height,
+			))
+		},
+	))
+
+	return nil
+}
@@ -10,4 +10,6 @@ type Database interface {
 	DeleteReason(height int64, subspaceID uint64, reasonID uint32) error
 	DeleteAllReasons(height int64, subspaceID uint64) error
 	SaveReportsParams(params types.ReportsParams) error
+	GetReasonsState(subspaceID uint64) ([]types.EntityState, error)
+	GetReportsState(subspaceID uint64) ([]types.EntityState, error)
 }
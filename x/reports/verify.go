@@ -0,0 +1,136 @@
+package reports
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	reportstypes "github.com/desmos-labs/desmos/v7/x/reports/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// Verify compares the reporting reasons and the reports of the given subspace stored inside the database
+// with the chain state at the given height, returning the report of the drifted entities
+func (m *Module) Verify(height int64, subspaceID uint64) (*types.VerificationReport, error) {
+	report := types.NewVerificationReport(m.Name(), subspaceID, height)
+
+	err := m.verifyReasons(height, subspaceID, report)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.verifyReports(height, subspaceID, report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// verifyReasons compares the reporting reasons of the given subspace stored inside the database
+// with the chain state at the given height, adding the drifted ones to the given report
+func (m *Module) verifyReasons(height int64, subspaceID uint64, report *types.VerificationReport) error {
+	reasons, err := m.queryAllReasons(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying reasons: %s", err)
+	}
+
+	storedReasons, err := m.db.GetReasonsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored reasons: %s", err)
+	}
+
+	chainReasons := map[string]types.Reason{}
+	var chainStates []types.EntityState
+	for _, reason := range reasons {
+		key := types.EntityKey(reason.SubspaceID, reason.ID)
+		chainReasons[key] = reason
+		chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+			"title":       reason.Title,
+			"description": reason.Description,
+		}))
+	}
+
+	report.Compare("reason", chainStates, storedReasons, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveReason(chainReasons[key])
+		},
+		func(key string) error {
+			reasonID, err := reportstypes.ParseReasonID(types.SplitEntityKey(key)[1])
+			if err != nil {
+				return err
+			}
+			return m.db.DeleteReason(height, subspaceID, reasonID)
+		},
+	))
+
+	return nil
+}
+
+// verifyReports compares the reports of the given subspace stored inside the database
+// with the chain state at the given height, adding the drifted ones to the given report
+func (m *Module) verifyReports(height int64, subspaceID uint64, report *types.VerificationReport) error {
+	reports, err := m.queryAllReports(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying reports: %s", err)
+	}
+
+	storedReports, err := m.db.GetReportsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored reports: %s", err)
+	}
+
+	chainReports := map[string]types.Report{}
+	var chainStates []types.EntityState
+	for _, chainReport := range reports {
+		key := types.EntityKey(chainReport.SubspaceID, chainReport.ID)
+		chainReports[key] = chainReport
+		chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+			"message":  chainReport.Message,
+			"reporter": chainReport.Reporter,
+			"target":   getReportTarget(chainReport),
+			"reasons":  joinReasonsIDs(chainReport.ReasonsIDs),
+		}))
+	}
+
+	report.Compare("report", chainStates, storedReports, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveReport(chainReports[key])
+		},
+		func(key string) error {
+			reportID, err := reportstypes.ParseReportID(types.SplitEntityKey(key)[1])
+			if err != nil {
+				return err
+			}
+			return m.db.DeleteReport(height, subspaceID, reportID)
+		},
+	))
+
+	return nil
+}
+
+// getReportTarget returns the target of the given report in the same format used when reading it from the database
+func getReportTarget(report types.Report) string {
+	switch target := report.Target.GetCachedValue().(type) {
+	case *reportstypes.PostTarget:
+		return fmt.Sprintf("post:%d", target.PostID)
+	case *reportstypes.UserTarget:
+		return "user:" + target.User
+	default:
+		return ""
+	}
+}
+
+// joinReasonsIDs returns the given reasons ids as a sorted comma-separated list
+func joinReasonsIDs(reasonsIDs []uint32) string {
+	sorted := make([]uint32, len(reasonsIDs))
+	copy(sorted, reasonsIDs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	ids := make([]string, len(sorted))
+	for i, id := range sorted {
+		ids[i] = fmt.Sprint(id)
+	}
+	return strings.Join(ids, ",")
+}
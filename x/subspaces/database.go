@@ -1,10 +1,16 @@
 package subspaces
 
 import (
+	"time"
+
 	"github.com/desmos-labs/athena/v2/types"
 )
 
 type Database interface {
+	// InSubspacesTransaction runs the given function passing it a copy of the database whose writes are all
+	// performed inside a single transaction, which is committed only if the function returns no error
+	InSubspacesTransaction(fn func(txDb Database) error) error
+
 	SaveSubspace(subspace types.Subspace) error
 	DeleteSubspace(height int64, id uint64) error
 	DeleteAllSubspaces(height int64) error
@@ -16,4 +22,18 @@ type Database interface {
 	RemoveUserFromGroup(member types.UserGroupMember) error
 	SaveUserPermission(permission types.UserPermission) error
 	DeleteUserPermission(permission types.UserPermission) error
+
+	SaveUserGroupMemberHistory(member types.UserGroupMember, timestamp time.Time) error
+	EndUserGroupMemberHistory(member types.UserGroupMember, timestamp time.Time) error
+	SaveUserGroupPermissionsHistory(group types.UserGroup, timestamp time.Time) error
+	EndUserGroupHistory(height int64, subspaceID uint64, groupID uint32, timestamp time.Time) error
+	SaveUserPermissionHistory(permission types.UserPermission, timestamp time.Time) error
+	EndSectionHistory(height int64, subspaceID uint64, sectionID uint32, timestamp time.Time) error
+	EndSubspaceHistory(height int64, subspaceID uint64, timestamp time.Time) error
+
+	GetSubspacesState() ([]types.EntityState, error)
+	GetSectionsState(subspaceID uint64) ([]types.EntityState, error)
+	GetUserGroupsState(subspaceID uint64) ([]types.EntityState, error)
+	GetUserGroupMembersState(subspaceID uint64) ([]types.EntityState, error)
+	GetUserPermissionsState(subspaceID uint64) ([]types.EntityState, error)
 }
@@ -24,97 +24,138 @@ func (m *Module) RefreshSubspacesData(height int64) error {
 		return fmt.Errorf("error while querying subspaces: %s", err)
 	}
 
-	err = m.db.DeleteAllSubspaces(height)
-	if err != nil {
-		return fmt.Errorf("error while deleting subspaces: %s", err)
-	}
-
-	for _, subspace := range subspaces {
-		err = m.refreshSubspaceData(height, subspace)
+	subspacesData := make([]subspaceData, len(subspaces))
+	for i, subspace := range subspaces {
+		subspacesData[i], err = m.querySubspaceData(height, subspace)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		err := db.DeleteAllSubspaces(height)
+		if err != nil {
+			return fmt.Errorf("error while deleting subspaces: %s", err)
+		}
+
+		for _, data := range subspacesData {
+			err = saveSubspaceData(db, data)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 // RefreshSubspaceData refreshes all the data related to the subspace with the given id
 func (m *Module) RefreshSubspaceData(height int64, subspaceID uint64) error {
-	err := m.db.DeleteSubspace(height, subspaceID)
+	subspace, err := m.QuerySubspace(height, subspaceID)
 	if err != nil {
-		return fmt.Errorf("error while deleting subspace: %s", err)
+		return fmt.Errorf("error while querying subspace from gRPC: %s", err)
 	}
 
-	subspace, err := m.QuerySubspace(height, subspaceID)
+	data, err := m.querySubspaceData(height, subspace)
 	if err != nil {
-		return fmt.Errorf("error while querying subspace from gRPC: %s", err)
+		return err
 	}
 
 	log.Info().Uint64("subspace", subspace.ID).Msg("refreshing subspace")
-	return m.refreshSubspaceData(height, subspace)
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		return refreshSubspaceData(db, height, data)
+	})
 }
 
-// refreshSubspaceData refreshes all the data related to the given subspace, storing them inside the database
-func (m *Module) refreshSubspaceData(height int64, subspace types.Subspace) error {
-	// Save the subspace
-	err := m.db.SaveSubspace(subspace)
+// refreshSubspaceData replaces the stored data of the subspace with the given data
+func refreshSubspaceData(db Database, height int64, data subspaceData) error {
+	err := db.DeleteSubspace(height, data.Subspace.ID)
 	if err != nil {
-		return fmt.Errorf("error while saving subspace: %s", err)
+		return fmt.Errorf("error while deleting subspace: %s", err)
 	}
 
-	// Update the sections
+	return saveSubspaceData(db, data)
+}
+
+// subspaceData contains all the data related to a subspace
+type subspaceData struct {
+	Subspace    types.Subspace
+	Sections    []types.Section
+	Groups      []types.UserGroup
+	Members     []types.UserGroupMember
+	Permissions []types.UserPermission
+}
+
+// querySubspaceData queries all the data related to the given subspace
+func (m *Module) querySubspaceData(height int64, subspace types.Subspace) (subspaceData, error) {
 	sections, err := m.queryAllSections(height, subspace.ID)
 	if err != nil {
-		return fmt.Errorf("error while querying subspace sections: %s", err)
+		return subspaceData{}, fmt.Errorf("error while querying subspace sections: %s", err)
 	}
 
-	for _, section := range sections {
-		err = m.db.SaveSection(section)
-		if err != nil {
-			return fmt.Errorf("error while saving subspace section: %s", err)
-		}
-	}
-
-	// Update the user groups
 	groups, err := m.queryAllUserGroups(height, subspace.ID)
 	if err != nil {
-		return fmt.Errorf("error while querying subspace user groups: %s", err)
+		return subspaceData{}, fmt.Errorf("error while querying subspace user groups: %s", err)
 	}
 
+	var members []types.UserGroupMember
 	for _, group := range groups {
-		err = m.db.SaveUserGroup(group)
+		groupMembers, err := m.queryAllUserGroupMembers(height, group.SubspaceID, group.ID)
 		if err != nil {
-			return fmt.Errorf("error while saving subspace user group: %s", err)
+			return subspaceData{}, fmt.Errorf("error while querying user group members: %s", err)
 		}
+		members = append(members, groupMembers...)
+	}
+
+	permissions, err := m.queryAllUserPermissions(height, subspace.ID)
+	if err != nil {
+		return subspaceData{}, fmt.Errorf("error while querying user permissions: %s", err)
+	}
+
+	return subspaceData{
+		Subspace:    subspace,
+		Sections:    sections,
+		Groups:      groups,
+		Members:     members,
+		Permissions: permissions,
+	}, nil
+}
+
+// saveSubspaceData stores the given subspace data inside the given database
+func saveSubspaceData(db Database, data subspaceData) error {
+	err := db.SaveSubspace(data.Subspace)
+	if err != nil {
+		return fmt.Errorf("error while saving subspace: %s", err)
+	}
 
-		// Update the members
-		members, err := m.queryAllUserGroupMembers(height, group.SubspaceID, group.ID)
+	for _, section := range data.Sections {
+		err = db.SaveSection(section)
 		if err != nil {
-			return fmt.Errorf("error while querying user group members: %s", err)
+			return fmt.Errorf("error while saving subspace section: %s", err)
 		}
+	}
 
-		// Save the members
-		for _, member := range members {
-			err = m.db.AddUserToGroup(member)
-			if err != nil {
-				return fmt.Errorf("error while saving user group member: %s", err)
-			}
+	for _, group := range data.Groups {
+		err = db.SaveUserGroup(group)
+		if err != nil {
+			return fmt.Errorf("error while saving subspace user group: %s", err)
 		}
 	}
 
-	// Update the user permissions
-	permissions, err := m.queryAllUserPermissions(height, subspace.ID)
-	if err != nil {
-		return fmt.Errorf("error while querying user permissions: %s", err)
+	for _, member := range data.Members {
+		err = db.AddUserToGroup(member)
+		if err != nil {
+			return fmt.Errorf("error while saving user group member: %s", err)
+		}
 	}
 
-	for _, permission := range permissions {
-		err = m.db.SaveUserPermission(permission)
+	for _, permission := range data.Permissions {
+		err = db.SaveUserPermission(permission)
 		if err != nil {
 			return fmt.Errorf("error while saving user permissions: %s", err)
 		}
 	}
+
 	return nil
 }
 
@@ -33,7 +33,13 @@ func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, appState map[string]json
 
 	// Save user permissions
 	for _, permission := range genState.UserPermissions {
-		err := m.db.SaveUserPermission(types.NewUserPermission(permission, doc.InitialHeight))
+		userPermission := types.NewUserPermission(permission, doc.InitialHeight)
+		err := m.db.SaveUserPermission(userPermission)
+		if err != nil {
+			return err
+		}
+
+		err = m.db.SaveUserPermissionHistory(userPermission, doc.GenesisTime)
 		if err != nil {
 			return err
 		}
@@ -41,7 +47,13 @@ func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, appState map[string]json
 
 	// Save user groups
 	for _, group := range genState.UserGroups {
-		err := m.db.SaveUserGroup(types.NewUserGroup(group, doc.InitialHeight))
+		userGroup := types.NewUserGroup(group, doc.InitialHeight)
+		err := m.db.SaveUserGroup(userGroup)
+		if err != nil {
+			return err
+		}
+
+		err = m.db.SaveUserGroupPermissionsHistory(userGroup, doc.GenesisTime)
 		if err != nil {
 			return err
 		}
@@ -49,7 +61,13 @@ func (m *Module) HandleGenesis(doc *tmtypes.GenesisDoc, appState map[string]json
 
 	// Save user group members
 	for _, entry := range genState.UserGroupsMembers {
-		err := m.db.AddUserToGroup(types.NewUserGroupMember(entry.SubspaceID, entry.GroupID, entry.User, doc.InitialHeight))
+		member := types.NewUserGroupMember(entry.SubspaceID, entry.GroupID, entry.User, doc.InitialHeight)
+		err := m.db.AddUserToGroup(member)
+		if err != nil {
+			return err
+		}
+
+		err = m.db.SaveUserGroupMemberHistory(member, doc.GenesisTime)
 		if err != nil {
 			return err
 		}
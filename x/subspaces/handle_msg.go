@@ -1,6 +1,8 @@
 package subspaces
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/x/authz"
 
 	"github.com/desmos-labs/athena/v2/x/filters"
@@ -13,6 +15,7 @@ import (
 	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
 
 	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/utils"
 )
 
 // HandleMsgExec implements modules.AuthzMessageModule
@@ -97,7 +100,35 @@ func (m *Module) handleMsgCreateSubspace(tx *juno.Tx, index int) error {
 		return err
 	}
 
-	return m.RefreshSubspaceData(tx.Height, subspaceID)
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	subspace, err := m.QuerySubspace(tx.Height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying subspace from gRPC: %s", err)
+	}
+
+	data, err := m.querySubspaceData(tx.Height, subspace)
+	if err != nil {
+		return err
+	}
+
+	defaultGroup, err := m.queryUserGroup(tx.Height, subspaceID, 0)
+	if err != nil {
+		return err
+	}
+
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		err := refreshSubspaceData(db, tx.Height, data)
+		if err != nil {
+			return err
+		}
+
+		// Start the permissions history of the default user group
+		return saveUserGroup(db, defaultGroup, timestamp)
+	})
 }
 
 // handleMsgEditSubspace handles a MsgEditSubspace
@@ -107,7 +138,19 @@ func (m *Module) handleMsgEditSubspace(tx *juno.Tx, msg *subspacestypes.MsgEditS
 
 // handleMsgDeleteSubspace handles a MsgDeleteSubspace
 func (m *Module) handleMsgDeleteSubspace(tx *juno.Tx, msg *subspacestypes.MsgDeleteSubspace) error {
-	return m.db.DeleteSubspace(tx.Height, msg.SubspaceID)
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		err := db.DeleteSubspace(tx.Height, msg.SubspaceID)
+		if err != nil {
+			return err
+		}
+
+		return db.EndSubspaceHistory(tx.Height, msg.SubspaceID, timestamp)
+	})
 }
 
 // -----------------------------------------------------------------------------------------------------
@@ -143,7 +186,19 @@ func (m *Module) handleMsgMoveSection(tx *juno.Tx, msg *subspacestypes.MsgMoveSe
 
 // handleMsgDeleteSection handles a MsgDeleteSection
 func (m *Module) handleMsgDeleteSection(tx *juno.Tx, msg *subspacestypes.MsgDeleteSection) error {
-	return m.db.DeleteSection(tx.Height, msg.SubspaceID, msg.SectionID)
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		err := db.DeleteSection(tx.Height, msg.SubspaceID, msg.SectionID)
+		if err != nil {
+			return err
+		}
+
+		return db.EndSectionHistory(tx.Height, msg.SubspaceID, msg.SectionID, timestamp)
+	})
 }
 
 // -----------------------------------------------------------------------------------------------------
@@ -164,58 +219,102 @@ func (m *Module) handleMsgCreateUserGroup(tx *juno.Tx, index int, msg *subspaces
 		return err
 	}
 
-	// Update the user group
-	err = m.updateUserGroup(tx.Height, msg.SubspaceID, groupID)
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	group, err := m.queryUserGroup(tx.Height, msg.SubspaceID, groupID)
 	if err != nil {
 		return err
 	}
 
-	// Handle initial members
-	for _, member := range msg.InitialMembers {
-		err = m.db.AddUserToGroup(types.NewUserGroupMember(msg.SubspaceID, groupID, member, tx.Height))
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		// Update the user group
+		err := saveUserGroup(db, group, timestamp)
 		if err != nil {
 			return err
 		}
-	}
 
-	return nil
+		// Handle initial members
+		for _, member := range msg.InitialMembers {
+			err = addUserToGroup(db, types.NewUserGroupMember(msg.SubspaceID, groupID, member, tx.Height), timestamp)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 // handleMsgEditUserGroup handles a MsgEditUserGroup
 func (m *Module) handleMsgEditUserGroup(tx *juno.Tx, msg *subspacestypes.MsgEditUserGroup) error {
-	return m.updateUserGroup(tx.Height, msg.SubspaceID, msg.GroupID)
+	return m.updateUserGroup(tx, msg.SubspaceID, msg.GroupID)
 }
 
 // handleMsgMoveUserGroup handles a MsgMoveUserGroup
 func (m *Module) handleMsgMoveUserGroup(tx *juno.Tx, msg *subspacestypes.MsgMoveUserGroup) error {
-	return m.updateUserGroup(tx.Height, msg.SubspaceID, msg.GroupID)
+	return m.updateUserGroup(tx, msg.SubspaceID, msg.GroupID)
 }
 
 // handleMsgSetUserGroupPermissions handles a MsgSetUserGroupPermissions properly
 func (m *Module) handleMsgSetUserGroupPermissions(tx *juno.Tx, msg *subspacestypes.MsgSetUserGroupPermissions) error {
-	return m.updateUserGroup(tx.Height, msg.SubspaceID, msg.GroupID)
+	return m.updateUserGroup(tx, msg.SubspaceID, msg.GroupID)
 }
 
 // handleMsgDeleteUserGroup handles a MsgDeleteUserGroup
 func (m *Module) handleMsgDeleteUserGroup(tx *juno.Tx, msg *subspacestypes.MsgDeleteUserGroup) error {
-	return m.db.DeleteUserGroup(tx.Height, msg.SubspaceID, msg.GroupID)
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		err := db.DeleteUserGroup(tx.Height, msg.SubspaceID, msg.GroupID)
+		if err != nil {
+			return err
+		}
+
+		return db.EndUserGroupHistory(tx.Height, msg.SubspaceID, msg.GroupID, timestamp)
+	})
 }
 
 // -----------------------------------------------------------------------------------------------------
 
 // handleMsgAddUserToUserGroup handles a MsgAddUserToUserGroup
 func (m *Module) handleMsgAddUserToUserGroup(tx *juno.Tx, msg *subspacestypes.MsgAddUserToUserGroup) error {
-	return m.db.AddUserToGroup(types.NewUserGroupMember(msg.SubspaceID, msg.GroupID, msg.User, tx.Height))
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		return addUserToGroup(db, types.NewUserGroupMember(msg.SubspaceID, msg.GroupID, msg.User, tx.Height), timestamp)
+	})
 }
 
 // handleMsgRemoveUserFromUserGroup handles a MsgRemoveUserFromUserGroup
 func (m *Module) handleMsgRemoveUserFromUserGroup(tx *juno.Tx, msg *subspacestypes.MsgRemoveUserFromUserGroup) error {
-	return m.db.RemoveUserFromGroup(types.NewUserGroupMember(msg.SubspaceID, msg.GroupID, msg.User, tx.Height))
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	member := types.NewUserGroupMember(msg.SubspaceID, msg.GroupID, msg.User, tx.Height)
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		err := db.RemoveUserFromGroup(member)
+		if err != nil {
+			return err
+		}
+
+		return db.EndUserGroupMemberHistory(member, timestamp)
+	})
 }
 
 // -----------------------------------------------------------------------------------------------------
 
 // handleMsgSetUserPermissions handles a MsgSetUserPermissions
 func (m *Module) handleMsgSetUserPermissions(tx *juno.Tx, msg *subspacestypes.MsgSetUserPermissions) error {
-	return m.updateUserPermissions(tx.Height, msg.SubspaceID, msg.SectionID, msg.User)
+	return m.updateUserPermissions(tx, msg.SubspaceID, msg.SectionID, msg.User)
 }
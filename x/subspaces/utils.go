@@ -2,12 +2,15 @@ package subspaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/forbole/juno/v5/node/remote"
+	juno "github.com/forbole/juno/v5/types"
 
 	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
 
 	"github.com/desmos-labs/athena/v2/types"
+	"github.com/desmos-labs/athena/v2/utils"
 )
 
 // updateSubspace updates the stored data for the given subspace at the specified height
@@ -40,26 +43,58 @@ func (m *Module) updateSection(height int64, subspaceID uint64, sectionID uint32
 	return m.db.SaveSection(types.NewSection(res.Section, height))
 }
 
-// updateUserGroup updates the stored data for the given user group at the specified height
-func (m *Module) updateUserGroup(height int64, subspaceID uint64, groupID uint32) error {
-	// Get the user group
+// updateUserGroup updates the stored data and permissions history for the given user group at the height of the
+// given transaction
+func (m *Module) updateUserGroup(tx *juno.Tx, subspaceID uint64, groupID uint32) error {
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
+	group, err := m.queryUserGroup(tx.Height, subspaceID, groupID)
+	if err != nil {
+		return err
+	}
+
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		return saveUserGroup(db, group, timestamp)
+	})
+}
+
+// queryUserGroup queries the given user group at the specified height
+func (m *Module) queryUserGroup(height int64, subspaceID uint64, groupID uint32) (types.UserGroup, error) {
 	res, err := m.client.UserGroup(
 		remote.GetHeightRequestContext(context.Background(), height),
 		subspacestypes.NewQueryUserGroupRequest(subspaceID, groupID),
 	)
+	if err != nil {
+		return types.UserGroup{}, err
+	}
+
+	return types.NewUserGroup(res.Group, height), nil
+}
+
+// saveUserGroup stores the given user group and its permissions history, which starts at the given time
+func saveUserGroup(db Database, group types.UserGroup, timestamp time.Time) error {
+	err := db.SaveUserGroup(group)
 	if err != nil {
 		return err
 	}
 
-	// Save the user group
-	return m.db.SaveUserGroup(types.NewUserGroup(res.Group, height))
+	return db.SaveUserGroupPermissionsHistory(group, timestamp)
 }
 
-// updateUserPermissions updates the stored permissions for the given user at the specified height
-func (m *Module) updateUserPermissions(height int64, subspaceID uint64, sectionID uint32, user string) error {
+// updateUserPermissions updates the stored permissions and permissions history for the given user at the height
+// of the given transaction
+func (m *Module) updateUserPermissions(tx *juno.Tx, subspaceID uint64, sectionID uint32, user string) error {
+	timestamp, err := utils.GetTxTimestamp(tx)
+	if err != nil {
+		return err
+	}
+
 	// Get the permissions
 	res, err := m.client.UserPermissions(
-		remote.GetHeightRequestContext(context.Background(), height),
+		remote.GetHeightRequestContext(context.Background(), tx.Height),
 		&subspacestypes.QueryUserPermissionsRequest{
 			SubspaceId: subspaceID,
 			SectionId:  sectionID,
@@ -71,8 +106,26 @@ func (m *Module) updateUserPermissions(height int64, subspaceID uint64, sectionI
 	}
 
 	// Save the user permissions
-	return m.db.SaveUserPermission(types.NewUserPermission(
+	permission := types.NewUserPermission(
 		subspacestypes.NewUserPermission(subspaceID, sectionID, user, res.Permissions),
-		height,
-	))
+		tx.Height,
+	)
+	return m.db.InSubspacesTransaction(func(db Database) error {
+		err := db.SaveUserPermission(permission)
+		if err != nil {
+			return err
+		}
+
+		return db.SaveUserPermissionHistory(permission, timestamp)
+	})
+}
+
+// addUserToGroup stores the given member and starts its membership period at the given time
+func addUserToGroup(db Database, member types.UserGroupMember, timestamp time.Time) error {
+	err := db.AddUserToGroup(member)
+	if err != nil {
+		return err
+	}
+
+	return db.SaveUserGroupMemberHistory(member, timestamp)
 }
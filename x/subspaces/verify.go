@@ -0,0 +1,236 @@
+package subspaces
+
+import (
+	"fmt"
+
+	subspacestypes "github.com/desmos-labs/desmos/v7/x/subspaces/types"
+
+	"github.com/desmos-labs/athena/v2/types"
+)
+
+// Verify compares the subspaces data stored inside the database with the chain state at the given height,
+// returning the report of the drifted entities. If the given subspace id is 0, all the subspaces are verified.
+func (m *Module) Verify(height int64, subspaceID uint64) (*types.VerificationReport, error) {
+	report := types.NewVerificationReport(m.Name(), subspaceID, height)
+
+	subspaces, err := m.QueryAllSubspaces(height)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying subspaces: %s", err)
+	}
+
+	storedSubspaces, err := m.db.GetSubspacesState()
+	if err != nil {
+		return nil, fmt.Errorf("error while getting stored subspaces: %s", err)
+	}
+
+	chainSubspaces := map[string]types.Subspace{}
+	var chainStates []types.EntityState
+	for _, subspace := range subspaces {
+		if subspaceID != 0 && subspace.ID != subspaceID {
+			continue
+		}
+
+		key := types.EntityKey(subspace.ID)
+		chainSubspaces[key] = subspace
+		chainStates = append(chainStates, types.NewEntityState(key, map[string]string{
+			"name":        subspace.Name,
+			"description": subspace.Description,
+			"treasury":    subspace.Treasury,
+			"owner":       subspace.Owner,
+			"creator":     subspace.Creator,
+		}))
+	}
+
+	var storedStates []types.EntityState
+	for _, state := range storedSubspaces {
+		if subspaceID == 0 || state.Key == types.EntityKey(subspaceID) {
+			storedStates = append(storedStates, state)
+		}
+	}
+
+	report.Compare("subspace", chainStates, storedStates, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveSubspace(chainSubspaces[key])
+		},
+		func(key string) error {
+			id, err := subspacestypes.ParseSubspaceID(key)
+			if err != nil {
+				return err
+			}
+			return m.db.DeleteSubspace(height, id)
+		},
+	))
+
+	// Verify the contents of the subspaces existing on chain
+	for _, subspace := range subspaces {
+		if _, found := chainSubspaces[types.EntityKey(subspace.ID)]; !found {
+			continue
+		}
+
+		err = m.verifySubspaceContents(height, subspace.ID, report)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// verifySubspaceContents compares the sections, user groups, members and permissions of the given subspace
+// stored inside the database with the chain state at the given height, adding the drifted ones to the given report
+func (m *Module) verifySubspaceContents(height int64, subspaceID uint64, report *types.VerificationReport) error {
+	// Verify the sections
+	sections, err := m.queryAllSections(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying subspace sections: %s", err)
+	}
+
+	storedSections, err := m.db.GetSectionsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored sections: %s", err)
+	}
+
+	chainSections := map[string]types.Section{}
+	var sectionsStates []types.EntityState
+	for _, section := range sections {
+		key := types.EntityKey(section.SubspaceID, section.ID)
+		chainSections[key] = section
+		sectionsStates = append(sectionsStates, types.NewEntityState(key, map[string]string{
+			"parent_id":   fmt.Sprint(section.ParentID),
+			"name":        section.Name,
+			"description": section.Description,
+		}))
+	}
+
+	report.Compare("section", sectionsStates, storedSections, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveSection(chainSections[key])
+		},
+		func(key string) error {
+			sectionID, err := subspacestypes.ParseSectionID(types.SplitEntityKey(key)[1])
+			if err != nil {
+				return err
+			}
+			return m.db.DeleteSection(height, subspaceID, sectionID)
+		},
+	))
+
+	// Verify the user groups and their members
+	groups, err := m.queryAllUserGroups(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying subspace user groups: %s", err)
+	}
+
+	storedGroups, err := m.db.GetUserGroupsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored user groups: %s", err)
+	}
+
+	storedMembers, err := m.db.GetUserGroupMembersState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored user group members: %s", err)
+	}
+
+	chainGroups := map[string]types.UserGroup{}
+	chainMembers := map[string]types.UserGroupMember{}
+	var groupsStates, membersStates []types.EntityState
+	for _, group := range groups {
+		key := types.EntityKey(group.SubspaceID, group.ID)
+		chainGroups[key] = group
+		groupsStates = append(groupsStates, types.NewEntityState(key, map[string]string{
+			"section_id":  fmt.Sprint(group.SectionID),
+			"name":        group.Name,
+			"description": group.Description,
+			"permissions": types.JoinPermissions(group.Permissions),
+		}))
+
+		members, err := m.queryAllUserGroupMembers(height, group.SubspaceID, group.ID)
+		if err != nil {
+			return fmt.Errorf("error while querying user group members: %s", err)
+		}
+
+		for _, member := range members {
+			memberKey := types.EntityKey(member.SubspaceID, member.GroupID, member.Member)
+			chainMembers[memberKey] = member
+			membersStates = append(membersStates, types.NewEntityState(memberKey, nil))
+		}
+	}
+
+	report.Compare("user_group", groupsStates, storedGroups, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveUserGroup(chainGroups[key])
+		},
+		func(key string) error {
+			groupID, err := subspacestypes.ParseGroupID(types.SplitEntityKey(key)[1])
+			if err != nil {
+				return err
+			}
+			return m.db.DeleteUserGroup(height, subspaceID, groupID)
+		},
+	))
+
+	report.Compare("user_group_member", membersStates, storedMembers, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.AddUserToGroup(chainMembers[key])
+		},
+		func(key string) error {
+			parts := types.SplitEntityKey(key)
+			groupID, err := subspacestypes.ParseGroupID(parts[1])
+			if err != nil {
+				return err
+			}
+			return m.db.RemoveUserFromGroup(types.NewUserGroupMember(subspaceID, groupID, parts[2], height))
+		},
+	))
+
+	// Verify the user permissions
+	permissions, err := m.queryAllUserPermissions(height, subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while querying user permissions: %s", err)
+	}
+
+	storedPermissions, err := m.db.GetUserPermissionsState(subspaceID)
+	if err != nil {
+		return fmt.Errorf("error while getting stored user permissions: %s", err)
+	}
+
+	// The permissions are built from the transactions, so only the latest ones for each user are kept
+	chainPermissions := map[string]types.UserPermission{}
+	var permissionsKeys []string
+	for _, permission := range permissions {
+		key := types.EntityKey(permission.SubspaceID, permission.SectionID, permission.User)
+		if _, found := chainPermissions[key]; !found {
+			permissionsKeys = append(permissionsKeys, key)
+		}
+		chainPermissions[key] = permission
+	}
+
+	var permissionsStates []types.EntityState
+	for _, key := range permissionsKeys {
+		permission := chainPermissions[key]
+		if len(permission.Permissions) == 0 {
+			continue
+		}
+
+		permissionsStates = append(permissionsStates, types.NewEntityState(key, map[string]string{
+			"permissions": types.JoinPermissions(permission.Permissions),
+		}))
+	}
+
+	report.Compare("user_permission", permissionsStates, storedPermissions, types.NewEntityFixer(
+		func(key string) error {
+			return m.db.SaveUserPermission(chainPermissions[key])
+		},
+		func(key string) error {
+			parts := types.SplitEntityKey(key)
+			sectionID, err := subspacestypes.ParseSectionID(parts[1])
+			if err != nil {
+				return err
+			}
+			permission := subspacestypes.NewUserPermission(subspaceID, sectionID, parts[2], nil)
+			return m.db.DeleteUserPermission(types.NewUserPermission(permission, height))
+		},
+	))
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package tombstones
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config contains the configuration of the tombstones mode
+type Config struct {
+	// Modules contains the names of the modules that should mark the deleted rows instead of removing them.
+	// Supported modules are "posts", "reactions" and "profiles".
+	Modules []string `yaml:"modules"`
+
+	// Retention is the period after which the deleted rows are physically removed.
+	// If zero, the deleted rows are never removed.
+	Retention time.Duration `yaml:"retention"`
+}
+
+// IsEnabled tells whether the tombstones mode is enabled for the module having the given name
+func (c *Config) IsEnabled(module string) bool {
+	for _, name := range c.Modules {
+		if name == module {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseConfig(bz []byte) (*Config, error) {
+	type T struct {
+		Config *Config `yaml:"tombstones"`
+	}
+	var cfg T
+	err := yaml.Unmarshal(bz, &cfg)
+	return cfg.Config, err
+}
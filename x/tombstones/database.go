@@ -0,0 +1,9 @@
+package tombstones
+
+import (
+	"time"
+)
+
+type Database interface {
+	PurgeTombstones(retention time.Duration) error
+}
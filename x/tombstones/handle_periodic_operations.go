@@ -0,0 +1,32 @@
+package tombstones
+
+import (
+	"fmt"
+
+	"github.com/go-co-op/gocron"
+	"github.com/rs/zerolog/log"
+)
+
+// RegisterPeriodicOperations implements modules.PeriodicOperationsModule
+func (m *Module) RegisterPeriodicOperations(scheduler *gocron.Scheduler) error {
+	if m.cfg.Retention == 0 {
+		return nil
+	}
+
+	log.Info().Str("module", "tombstones").Msg("setting up periodic tasks")
+
+	// Purge the expired tombstones every hour
+	if _, err := scheduler.Every(1).Hour().StartImmediately().Do(m.purgeTombstones); err != nil {
+		return fmt.Errorf("error while scheduling tombstones peridic operation: %s", err)
+	}
+
+	return nil
+}
+
+// purgeTombstones removes the rows that have been marked as deleted longer than the retention period ago
+func (m *Module) purgeTombstones() {
+	err := m.db.PurgeTombstones(m.cfg.Retention)
+	if err != nil {
+		log.Error().Err(err).Msg("error while purging tombstones")
+	}
+}
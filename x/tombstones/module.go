@@ -0,0 +1,49 @@
+package tombstones
+
+import (
+	"github.com/forbole/juno/v5/modules"
+	"github.com/forbole/juno/v5/types/config"
+)
+
+var (
+	_ modules.Module                   = &Module{}
+	_ modules.PeriodicOperationsModule = &Module{}
+)
+
+// Module represents the module that purges the rows marked as deleted by the other modules
+type Module struct {
+	cfg *Config
+	db  Database
+}
+
+// NewModule returns a new Module instance, or nil if the tombstones mode is not configured
+func NewModule(junoCfg config.Config, db Database) *Module {
+	bz, err := junoCfg.GetBytes()
+	if err != nil {
+		panic(err)
+	}
+
+	cfg, err := ParseConfig(bz)
+	if err != nil {
+		panic(err)
+	}
+
+	if cfg == nil {
+		return nil
+	}
+
+	return &Module{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// Name implements modules.Module
+func (m *Module) Name() string {
+	return "tombstones"
+}
+
+// IsEnabled tells whether the module having the given name should mark the deleted rows instead of removing them
+func (m *Module) IsEnabled(module string) bool {
+	return m != nil && m.cfg.IsEnabled(module)
+}